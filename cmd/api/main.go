@@ -1,9 +1,12 @@
 package main
 
 import (
+	"GonPay_Backend/internal/auth"
 	"GonPay_Backend/internal/config"
 	httpDelivery "GonPay_Backend/internal/delivery/http"
 	"GonPay_Backend/internal/delivery/middleware"
+	"GonPay_Backend/internal/domain"
+	"GonPay_Backend/internal/provider"
 	"GonPay_Backend/internal/repository"
 	"GonPay_Backend/internal/usecase"
 	"GonPay_Backend/pkg/logger"
@@ -15,6 +18,7 @@ import (
 	"os"
 	"os/signal"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/gorilla/mux"
 )
@@ -51,36 +55,304 @@ func main() {
 	userRepo := repository.NewUserRepository(db)
 	walletRepo := repository.NewWalletRepository(db)
 	transactionRepo := repository.NewTransactionRepository(db)
+	transactionLimitRepo := repository.NewTransactionLimitRepository(db)
+	limitPolicyRepo := repository.NewLimitPolicyRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	beneficiaryRepo := repository.NewBeneficiaryRepository(db)
+	payoutRepo := repository.NewPayoutRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+	twoFactorRepo := repository.NewTwoFactorRepository(db)
+	loginAttemptRepo := repository.NewLoginAttemptRepository(db)
+	passwordResetRepo := repository.NewPasswordResetRepository(db)
+	verificationCodeRepo := repository.NewVerificationCodeRepository(db)
+	signingKeyRepo := repository.NewSigningKeyRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	signingSecretRepo := repository.NewSigningSecretRepository(db)
+	requestNonceRepo := repository.NewRequestNonceRepository(db)
+	transactionPINRepo := repository.NewTransactionPINRepository(db)
+	kycRepo := repository.NewKYCRepository(db)
+	walletAdjustmentRepo := repository.NewWalletAdjustmentRepository(db)
+	notificationDeliveryRepo := repository.NewNotificationDeliveryRepository(db)
+	pushDeviceRepo := repository.NewPushDeviceRepository(db)
+
+	// Initialize external providers
+	var bankLookup domain.BankAccountLookup
+	var payoutProvider domain.PayoutProvider
+	switch cfg.Bank.Provider {
+	case "", "local":
+		bankProvider := provider.NewLocalBankProvider()
+		bankLookup = bankProvider
+		payoutProvider = bankProvider
+	default:
+		logger.Error("Unknown bank provider", "provider", cfg.Bank.Provider)
+		os.Exit(1)
+	}
+
+	var mailSender domain.MailSender
+	switch cfg.Mail.Provider {
+	case "smtp":
+		mailSender = provider.NewSMTPMailSender(cfg.Mail.SMTPHost, cfg.Mail.SMTPPort, cfg.Mail.SMTPUsername, cfg.Mail.SMTPPassword, cfg.Mail.From)
+	case "file":
+		mailSender = provider.NewFileMailSender(cfg.Mail.FileDir, cfg.Mail.From)
+	case "", "log":
+		mailSender = provider.NewLogMailSender(logger)
+	default:
+		logger.Error("Unknown mail provider", "provider", cfg.Mail.Provider)
+		os.Exit(1)
+	}
+
+	var smsSender domain.SMSSender
+	switch cfg.SMS.Provider {
+	case "", "local":
+		smsSender = provider.NewLocalSMSSender(logger)
+	default:
+		logger.Error("Unknown SMS provider", "provider", cfg.SMS.Provider)
+		os.Exit(1)
+	}
+
+	var pushProvider domain.PushProvider
+	switch cfg.Push.Provider {
+	case "", "local":
+		pushProvider = provider.NewLocalPushProvider(logger)
+	default:
+		logger.Error("Unknown push provider", "provider", cfg.Push.Provider)
+		os.Exit(1)
+	}
+
+	var blobStore domain.BlobStore
+	switch cfg.KYC.Storage {
+	case "", "local":
+		blobStore = provider.NewLocalBlobStore(cfg.KYC.StorageDir)
+	default:
+		logger.Error("Unknown KYC storage", "storage", cfg.KYC.Storage)
+		os.Exit(1)
+	}
+
+	var exportStore domain.BlobStore
+	switch cfg.Privacy.Storage {
+	case "", "local":
+		exportStore = provider.NewLocalBlobStore(cfg.Privacy.StorageDir)
+	default:
+		logger.Error("Unknown privacy storage", "storage", cfg.Privacy.Storage)
+		os.Exit(1)
+	}
+
+	// Load the JWT signing keys
+	keyCipher, err := auth.NewKeyCipher(cfg.JWT.KeyEncryptionKey)
+	if err != nil {
+		logger.Error("Cannot load signing key encryption key", "error", err)
+		os.Exit(1)
+	}
+	tokens := auth.NewTokenManager(signingKeyRepo, keyCipher, cfg.JWT.Issuer)
+	if err := tokens.Load(); err != nil {
+		logger.Error("Cannot load signing keys", "error", err)
+		os.Exit(1)
+	}
 
 	// Initialize use cases
-	userUseCase := usecase.NewUserUseCase(userRepo, validator, cfg.JWT.Secret, cfg.JWT.TTL)
-	walletUseCase := usecase.NewWalletUseCase(walletRepo, transactionRepo)
+	auditUseCase := usecase.NewAuditUseCase(auditRepo)
+	notificationUseCase := usecase.NewNotificationUseCase(notificationRepo, notificationDeliveryRepo, pushDeviceRepo, userRepo)
+	notificationDeliveryUseCase := usecase.NewNotificationDeliveryUseCase(
+		notificationDeliveryRepo,
+		notificationRepo,
+		userRepo,
+		map[domain.NotificationChannel]domain.NotificationSender{
+			domain.NotificationChannelEmail: provider.NewEmailChannel(mailSender),
+			domain.NotificationChannelSMS:   provider.NewSMSChannel(smsSender),
+			domain.NotificationChannelPush:  provider.NewPushChannel(pushProvider, pushDeviceRepo),
+		},
+		auditUseCase,
+		logger,
+		usecase.DeliveryPolicy{
+			PollInterval: time.Second * time.Duration(cfg.Notifications.PollIntervalSeconds),
+			BatchSize:    cfg.Notifications.BatchSize,
+			Lease:        time.Second * time.Duration(cfg.Notifications.LeaseSeconds),
+			MaxAttempts:  cfg.Notifications.MaxAttempts,
+			BaseBackoff:  time.Second * time.Duration(cfg.Notifications.BaseBackoffSeconds),
+			MaxBackoff:   time.Second * time.Duration(cfg.Notifications.MaxBackoffSeconds),
+		},
+	)
+	sessionUseCase := usecase.NewSessionUseCase(
+		sessionRepo,
+		userRepo,
+		auditUseCase,
+		notificationUseCase,
+		tokens,
+		cfg.JWT.AccessTTL,
+		cfg.JWT.RefreshTTL,
+	)
+	impersonationUseCase := usecase.NewImpersonationUseCase(
+		sessionRepo,
+		userRepo,
+		auditUseCase,
+		notificationUseCase,
+		tokens,
+		cfg.JWT.ImpersonationTTL,
+	)
+	lockoutUseCase := usecase.NewLockoutUseCase(userRepo, loginAttemptRepo, auditUseCase, notificationUseCase, usecase.LockoutPolicy{
+		DelayAfterAttempts:  cfg.Login.DelayAfterAttempts,
+		BaseDelay:           time.Second * time.Duration(cfg.Login.BaseDelaySeconds),
+		MaxFailedAttempts:   cfg.Login.MaxFailedAttempts,
+		LockoutDuration:     time.Minute * time.Duration(cfg.Login.LockoutMinutes),
+		IPMaxFailedAttempts: cfg.Login.IPMaxFailedAttempts,
+		IPWindow:            time.Minute * time.Duration(cfg.Login.IPWindowMinutes),
+	})
+	twoFactorUseCase := usecase.NewTwoFactorUseCase(twoFactorRepo, userRepo, auditUseCase, sessionUseCase, lockoutUseCase, tokens)
+	verificationUseCase := usecase.NewVerificationUseCase(verificationCodeRepo, userRepo, auditUseCase, mailSender, smsSender, usecase.VerificationPolicy{
+		CodeTTL:        time.Minute * time.Duration(cfg.Verification.CodeTTLMinutes),
+		MaxAttempts:    cfg.Verification.MaxAttempts,
+		ResendInterval: time.Second * time.Duration(cfg.Verification.ResendIntervalSeconds),
+		RequireEmail:   cfg.Verification.RequireEmail,
+		RequirePhone:   cfg.Verification.RequirePhone,
+	})
+	transactionPINUseCase := usecase.NewTransactionPINUseCase(transactionPINRepo, userRepo, twoFactorUseCase, lockoutUseCase, auditUseCase, notificationUseCase, usecase.StepUpPolicy{
+		Threshold:         cfg.StepUp.Threshold,
+		NewRecipient:      cfg.StepUp.NewRecipient,
+		MaxFailedAttempts: cfg.StepUp.PINMaxAttempts,
+		LockoutDuration:   time.Minute * time.Duration(cfg.StepUp.PINLockoutMinutes),
+	})
+	userUseCase := usecase.NewUserUseCase(userRepo, validator, sessionUseCase, twoFactorUseCase, lockoutUseCase, verificationUseCase)
+	adminUserUseCase := usecase.NewAdminUserUseCase(
+		userRepo,
+		walletRepo,
+		transactionRepo,
+		sessionRepo,
+		twoFactorRepo,
+		lockoutUseCase,
+		auditUseCase,
+		notificationUseCase,
+	)
+	adminWalletUseCase := usecase.NewAdminWalletUseCase(
+		walletRepo,
+		walletAdjustmentRepo,
+		auditUseCase,
+		notificationUseCase,
+	)
+	passwordResetUseCase := usecase.NewPasswordResetUseCase(
+		passwordResetRepo,
+		userRepo,
+		auditUseCase,
+		notificationUseCase,
+		mailSender,
+		validator,
+		logger,
+		usecase.PasswordResetPolicy{
+			TokenTTL:       time.Minute * time.Duration(cfg.PasswordReset.TokenTTLMinutes),
+			ResendInterval: time.Second * time.Duration(cfg.PasswordReset.ResendIntervalSeconds),
+			IPMaxRequests:  cfg.PasswordReset.IPMaxRequests,
+			IPWindow:       time.Minute * time.Duration(cfg.PasswordReset.IPWindowMinutes),
+			URL:            cfg.PasswordReset.URL,
+		},
+	)
+	transactionLimitUseCase := usecase.NewTransactionLimitUseCase(transactionLimitRepo, limitPolicyRepo, userRepo, auditUseCase)
+	beneficiaryUseCase := usecase.NewBeneficiaryUseCase(
+		beneficiaryRepo,
+		userRepo,
+		walletRepo,
+		bankLookup,
+		transactionPINUseCase,
+		notificationUseCase,
+		cfg.Beneficiary.CoolingOffHours,
+		cfg.Beneficiary.CoolingOffMaxAmount,
+	)
+	kycUseCase := usecase.NewKYCUseCase(kycRepo, userRepo, walletRepo, blobStore, auditUseCase, notificationUseCase, usecase.KYCPolicy{
+		MaxDocumentSize: cfg.KYC.MaxDocumentBytes,
+		TierMaxBalances: cfg.KYC.TierMaxBalances,
+	})
+	walletUseCase := usecase.NewWalletUseCase(
+		walletRepo,
+		transactionRepo,
+		payoutRepo,
+		payoutProvider,
+		transactionLimitUseCase,
+		beneficiaryUseCase,
+		verificationUseCase,
+		transactionPINUseCase,
+		kycUseCase,
+	)
 	transactionUseCase := usecase.NewTransactionUseCase(transactionRepo)
+	apiKeyUseCase := usecase.NewAPIKeyUseCase(apiKeyRepo, userRepo, auditUseCase, notificationUseCase)
+	requestSigningUseCase := usecase.NewRequestSigningUseCase(
+		signingSecretRepo,
+		requestNonceRepo,
+		userRepo,
+		auditUseCase,
+		cfg.RequestSigning.MaxSkewSeconds,
+	)
 
 	// Initialize payment method repository and usecase
 	paymentMethodRepo := repository.NewPaymentMethodRepository(db)
 	paymentMethodUseCase := usecase.NewPaymentMethodUseCase(paymentMethodRepo)
 	paymentMethodHandler := httpDelivery.NewPaymentMethodHandler(paymentMethodUseCase)
 
-	// Initialize beneficiary repository and usecase
-	beneficiaryRepo := repository.NewBeneficiaryRepository(db)
-	beneficiaryUseCase := usecase.NewBeneficiaryUseCase(beneficiaryRepo)
+	// Initialize data export repository and privacy usecase
+	dataExportRepo := repository.NewDataExportRepository(db)
+	privacyUseCase := usecase.NewPrivacyUseCase(
+		userRepo,
+		walletRepo,
+		transactionRepo,
+		beneficiaryRepo,
+		paymentMethodRepo,
+		notificationRepo,
+		auditRepo,
+		dataExportRepo,
+		exportStore,
+		lockoutUseCase,
+		auditUseCase,
+		notificationUseCase,
+		logger,
+		usecase.PrivacyPolicy{
+			ExportTTL:       time.Hour * time.Duration(cfg.Privacy.ExportTTLHours),
+			RetentionPeriod: time.Hour * 24 * 365 * time.Duration(cfg.Privacy.RetentionYears),
+		},
+	)
+	privacyHandler := httpDelivery.NewPrivacyHandler(privacyUseCase)
+
+	// Initialize preferences usecase
+	preferencesUseCase := usecase.NewPreferencesUseCase(userRepo, walletRepo, auditUseCase)
+	preferencesHandler := httpDelivery.NewPreferencesHandler(preferencesUseCase)
+
+	// Initialize beneficiary handler
 	beneficiaryHandler := httpDelivery.NewBeneficiaryHandler(beneficiaryUseCase)
 
 	// Initialize handlers
 	userHandler := httpDelivery.NewUserHandler(userUseCase)
+	adminUserHandler := httpDelivery.NewAdminUserHandler(adminUserUseCase)
+	adminWalletHandler := httpDelivery.NewAdminWalletHandler(adminWalletUseCase)
+	impersonationHandler := httpDelivery.NewImpersonationHandler(impersonationUseCase)
+	sessionHandler := httpDelivery.NewSessionHandler(sessionUseCase)
+	twoFactorHandler := httpDelivery.NewTwoFactorHandler(twoFactorUseCase)
+	passwordResetHandler := httpDelivery.NewPasswordResetHandler(passwordResetUseCase)
+	verificationHandler := httpDelivery.NewVerificationHandler(verificationUseCase)
+	transactionPINHandler := httpDelivery.NewTransactionPINHandler(transactionPINUseCase)
+	kycHandler := httpDelivery.NewKYCHandler(kycUseCase)
 	walletHandler := httpDelivery.NewWalletHandler(walletUseCase)
 	transactionHandler := httpDelivery.NewTransactionHandler(transactionUseCase)
+	jwksHandler := httpDelivery.NewJWKSHandler(tokens)
+	apiKeyHandler := httpDelivery.NewAPIKeyHandler(apiKeyUseCase, requestSigningUseCase)
 
 	// Initialize middleware
-	mid := middleware.NewMiddleware(logger, cfg.JWT.Secret)
+	trustedProxies, err := middleware.ParseTrustedProxies(cfg.Server.TrustedProxies)
+	if err != nil {
+		logger.Error("Cannot parse trusted proxies", "error", err)
+		os.Exit(1)
+	}
+	mid := middleware.NewMiddleware(logger, tokens, sessionUseCase, apiKeyUseCase, requestSigningUseCase, impersonationUseCase, preferencesUseCase, trustedProxies)
 
 	// Initialize router
 	router := mux.NewRouter()
+	router.Use(mid.ClientInfoMiddleware)
+	router.Use(mid.LanguageMiddleware)
 
 	// Public routes
+	router.HandleFunc("/.well-known/jwks.json", jwksHandler.GetJWKS).Methods("GET")
 	router.HandleFunc("/api/register", userHandler.Register).Methods("POST")
 	router.HandleFunc("/api/login", userHandler.Login).Methods("POST")
+	router.HandleFunc("/api/login/2fa", twoFactorHandler.Login).Methods("POST")
+	router.HandleFunc("/api/token/refresh", sessionHandler.Refresh).Methods("POST")
+	router.HandleFunc("/api/password/forgot", passwordResetHandler.ForgotPassword).Methods("POST")
+	router.HandleFunc("/api/password/reset", passwordResetHandler.ResetPassword).Methods("POST")
 
 	// Protected routes
 	api := router.PathPrefix("/api").Subrouter()
@@ -92,17 +364,67 @@ func main() {
 	api.HandleFunc("/users/profile", userHandler.UpdateProfile).Methods("PUT")
 	api.HandleFunc("/users/password", userHandler.ChangePassword).Methods("PUT")
 
+	api.HandleFunc("/users/preferences", preferencesHandler.GetPreferences).Methods("GET")
+	api.HandleFunc("/users/preferences", preferencesHandler.UpdatePreferences).Methods("PATCH")
+
+	// Personal data export and account closure routes
+	api.HandleFunc("/users/export", privacyHandler.RequestExport).Methods("POST")
+	api.HandleFunc("/users/export", privacyHandler.GetExport).Methods("GET")
+	api.HandleFunc("/users/export/{id}/download", privacyHandler.DownloadExport).Methods("GET")
+	api.HandleFunc("/users/close", privacyHandler.CloseAccount).Methods("POST")
+
+	// Session routes
+	api.HandleFunc("/logout", sessionHandler.Logout).Methods("POST")
+	api.HandleFunc("/sessions", sessionHandler.GetSessions).Methods("GET")
+	api.HandleFunc("/sessions/{id}", sessionHandler.RevokeSession).Methods("DELETE")
+
+	// Email and phone verification routes
+	api.HandleFunc("/verification/{channel}/send", verificationHandler.SendCode).Methods("POST")
+	api.HandleFunc("/verification/{channel}/verify", verificationHandler.Verify).Methods("POST")
+
+	// Two-factor authentication routes
+	api.HandleFunc("/2fa", twoFactorHandler.GetStatus).Methods("GET")
+	api.HandleFunc("/2fa/setup", twoFactorHandler.Setup).Methods("POST")
+	api.HandleFunc("/2fa/confirm", twoFactorHandler.Confirm).Methods("POST")
+	api.HandleFunc("/2fa/disable", twoFactorHandler.Disable).Methods("POST")
+	api.HandleFunc("/2fa/recovery-codes", twoFactorHandler.RegenerateRecoveryCodes).Methods("POST")
+
+	// Transaction PIN routes
+	api.HandleFunc("/pin", transactionPINHandler.GetStatus).Methods("GET")
+	api.HandleFunc("/pin", transactionPINHandler.SetPIN).Methods("POST")
+	api.HandleFunc("/pin", transactionPINHandler.ChangePIN).Methods("PUT")
+
+	// Identity verification routes
+	api.HandleFunc("/kyc", kycHandler.GetState).Methods("GET")
+	api.HandleFunc("/kyc", kycHandler.Submit).Methods("POST")
+
 	// Wallet routes
 	api.HandleFunc("/wallets", walletHandler.CreateWallet).Methods("POST")
-	api.HandleFunc("/wallets", walletHandler.GetUserWallets).Methods("GET")
 	api.HandleFunc("/wallets/{id}", walletHandler.GetWallet).Methods("GET")
 	api.HandleFunc("/wallets/{id}/deactivate", walletHandler.DeactivateWallet).Methods("POST")
-	api.HandleFunc("/wallets/transfer", walletHandler.Transfer).Methods("POST")
 	api.HandleFunc("/wallets/{id}/deposit", walletHandler.Deposit).Methods("POST")
 	api.HandleFunc("/wallets/{id}/withdraw", walletHandler.Withdraw).Methods("POST")
 
-	// Transaction routes
-	api.HandleFunc("/transactions", transactionHandler.GetUserTransactions).Methods("GET")
+	// Merchant API key routes
+	api.HandleFunc("/api-keys", apiKeyHandler.CreateKey).Methods("POST")
+	api.HandleFunc("/api-keys", apiKeyHandler.GetKeys).Methods("GET")
+	api.HandleFunc("/api-keys/signing-secret", apiKeyHandler.RotateSigningSecret).Methods("POST")
+	api.HandleFunc("/api-keys/{id}", apiKeyHandler.RevokeKey).Methods("DELETE")
+
+	// Routes merchant servers may also call with an API key, each guarded by
+	// the scope it needs
+	merchantApi := router.PathPrefix("/api").Subrouter()
+	merchantApi.Use(mid.AuthOrAPIKeyMiddleware)
+	merchantApi.Use(mid.SignatureMiddleware)
+	merchantApi.Use(mid.LoggingMiddleware)
+
+	scoped := func(scope domain.APIScope, handler http.HandlerFunc) http.Handler {
+		return mid.RequireScope(scope)(handler)
+	}
+
+	merchantApi.Handle("/wallets", scoped(domain.APIScopeWalletsRead, walletHandler.GetUserWallets)).Methods("GET")
+	merchantApi.Handle("/wallets/transfer", scoped(domain.APIScopePaymentsCreate, walletHandler.Transfer)).Methods("POST")
+	merchantApi.Handle("/transactions", scoped(domain.APIScopePaymentsRead, transactionHandler.GetUserTransactions)).Methods("GET")
 
 	// Payment Method routes
 	api.HandleFunc("/payment-methods", paymentMethodHandler.GetUserPaymentMethods).Methods("GET")
@@ -118,41 +440,90 @@ func main() {
 	api.HandleFunc("/beneficiaries/{id}", beneficiaryHandler.GetBeneficiary).Methods("GET")
 	api.HandleFunc("/beneficiaries/{id}", beneficiaryHandler.UpdateBeneficiary).Methods("PUT")
 	api.HandleFunc("/beneficiaries/{id}", beneficiaryHandler.DeleteBeneficiary).Methods("DELETE")
-
-	// Initialize repositories
-	auditRepo := repository.NewAuditRepository(db)
-	notificationRepo := repository.NewNotificationRepository(db)
-	transactionLimitRepo := repository.NewTransactionLimitRepository(db)
-
-	// Initialize use cases
-	auditUseCase := usecase.NewAuditUseCase(auditRepo)
-	notificationUseCase := usecase.NewNotificationUseCase(notificationRepo)
-	transactionLimitUseCase := usecase.NewTransactionLimitUseCase(transactionLimitRepo)
+	api.HandleFunc("/beneficiaries/{id}/lift-restriction", beneficiaryHandler.LiftCoolingOff).Methods("POST")
+	api.HandleFunc("/beneficiaries/{id}/transfer", walletHandler.TransferToBeneficiary).Methods("POST")
 
 	// Initialize handlers
 	auditHandler := httpDelivery.NewAuditHandler(auditUseCase)
 	notificationHandler := httpDelivery.NewNotificationHandler(notificationUseCase)
+	adminNotificationHandler := httpDelivery.NewAdminNotificationHandler(notificationDeliveryUseCase)
 	transactionLimitHandler := httpDelivery.NewTransactionLimitHandler(transactionLimitUseCase)
 
 	// Transaction Limits routes
 	api.HandleFunc("/limits", transactionLimitHandler.SetLimit).Methods("POST")
 	api.HandleFunc("/limits", transactionLimitHandler.GetLimits).Methods("GET")
+	api.HandleFunc("/limits/usage", transactionLimitHandler.GetUsage).Methods("GET")
 
 	// Notifications routes
 	api.HandleFunc("/notifications", notificationHandler.GetNotifications).Methods("GET")
 	api.HandleFunc("/notifications/unread/count", notificationHandler.GetUnreadCount).Methods("GET")
+	api.HandleFunc("/notifications/devices", notificationHandler.GetDevices).Methods("GET")
+	api.HandleFunc("/notifications/devices", notificationHandler.RegisterDevice).Methods("POST")
+	api.HandleFunc("/notifications/devices/{id}", notificationHandler.DeleteDevice).Methods("DELETE")
+	api.HandleFunc("/notifications/{id}", notificationHandler.GetNotification).Methods("GET")
 	api.HandleFunc("/notifications/{id}/read", notificationHandler.MarkAsRead).Methods("PUT")
 	api.HandleFunc("/notifications/read/all", notificationHandler.MarkAllAsRead).Methods("PUT")
 
-	// Audit routes (with admin middleware)
+	// Admin routes, each guarded by the permission it needs
 	adminApi := router.PathPrefix("/api/admin").Subrouter()
 	adminApi.Use(mid.AuthMiddleware)
-	adminApi.Use(mid.AdminMiddleware)
+	adminApi.Use(mid.StaffMiddleware)
 	adminApi.Use(mid.LoggingMiddleware)
 
-	adminApi.HandleFunc("/audit/logs", auditHandler.GetDateRangeLogs).Methods("GET")
-	adminApi.HandleFunc("/audit/logs/action", auditHandler.GetActionLogs).Methods("GET")
-	adminApi.HandleFunc("/audit/logs/entity", auditHandler.GetEntityLogs).Methods("GET")
+	requires := func(permission domain.Permission, handler http.HandlerFunc) http.Handler {
+		return mid.RequirePermission(permission)(handler)
+	}
+
+	// Audit routes
+	adminApi.Handle("/audit/logs", requires(domain.PermissionAuditRead, auditHandler.GetDateRangeLogs)).Methods("GET")
+	adminApi.Handle("/audit/logs/action", requires(domain.PermissionAuditRead, auditHandler.GetActionLogs)).Methods("GET")
+	adminApi.Handle("/audit/logs/entity", requires(domain.PermissionAuditRead, auditHandler.GetEntityLogs)).Methods("GET")
+
+	// Admin user and role routes
+	adminApi.HandleFunc("/roles", adminUserHandler.GetRoles).Methods("GET")
+	adminApi.Handle("/users", requires(domain.PermissionUsersRead, adminUserHandler.SearchUsers)).Methods("GET")
+	adminApi.Handle("/users/{id}", requires(domain.PermissionUsersRead, adminUserHandler.GetUser)).Methods("GET")
+	adminApi.Handle("/users/{id}/wallets", requires(domain.PermissionWalletRead, adminUserHandler.GetUserWallets)).Methods("GET")
+	adminApi.Handle("/users/{id}/transactions", requires(domain.PermissionWalletRead, adminUserHandler.GetUserTransactions)).Methods("GET")
+	adminApi.Handle("/users/{id}/limits", requires(domain.PermissionLimitsRead, transactionLimitHandler.GetUserLimits)).Methods("GET")
+	adminApi.Handle("/users/{id}/role", requires(domain.PermissionRolesWrite, adminUserHandler.AssignRole)).Methods("PUT")
+	adminApi.Handle("/users/{id}/unlock", requires(domain.PermissionUsersUnlock, adminUserHandler.UnlockUser)).Methods("POST")
+	adminApi.Handle("/users/{id}/suspend", requires(domain.PermissionUsersSuspend, adminUserHandler.Suspend)).Methods("POST")
+	adminApi.Handle("/users/{id}/reactivate", requires(domain.PermissionUsersSuspend, adminUserHandler.Reactivate)).Methods("POST")
+	adminApi.Handle("/users/{id}/logout", requires(domain.PermissionUsersLogout, adminUserHandler.ForceLogout)).Methods("POST")
+	adminApi.Handle("/users/{id}/2fa/reset", requires(domain.PermissionUsersReset2FA, adminUserHandler.Reset2FA)).Methods("POST")
+	adminApi.Handle("/users/{id}/impersonate", requires(domain.PermissionUsersImpersonate, impersonationHandler.Start)).Methods("POST")
+	adminApi.Handle("/impersonations/{id}", requires(domain.PermissionUsersImpersonate, impersonationHandler.End)).Methods("DELETE")
+	adminApi.Handle("/api-keys/{id}", requires(domain.PermissionAPIKeysRevoke, apiKeyHandler.AdminRevokeKey)).Methods("DELETE")
+
+	// Admin wallet status and adjustment routes
+	adminApi.Handle("/wallets/{id}", requires(domain.PermissionWalletRead, adminWalletHandler.GetWallet)).Methods("GET")
+	adminApi.Handle("/wallets/{id}/freeze", requires(domain.PermissionWalletFreeze, adminWalletHandler.Freeze)).Methods("POST")
+	adminApi.Handle("/wallets/{id}/block", requires(domain.PermissionWalletFreeze, adminWalletHandler.Block)).Methods("POST")
+	adminApi.Handle("/wallets/{id}/unfreeze", requires(domain.PermissionWalletFreeze, adminWalletHandler.Unfreeze)).Methods("POST")
+	adminApi.Handle("/wallets/{id}/adjustments", requires(domain.PermissionWalletAdjust, adminWalletHandler.ProposeAdjustment)).Methods("POST")
+	adminApi.Handle("/adjustments", requires(domain.PermissionWalletAdjust, adminWalletHandler.GetAdjustments)).Methods("GET")
+	adminApi.Handle("/adjustments/{id}/approve", requires(domain.PermissionWalletAdjust, adminWalletHandler.ApproveAdjustment)).Methods("POST")
+	adminApi.Handle("/adjustments/{id}/reject", requires(domain.PermissionWalletAdjust, adminWalletHandler.RejectAdjustment)).Methods("POST")
+
+	// KYC review queue routes
+	adminApi.Handle("/kyc", requires(domain.PermissionKYCReview, kycHandler.GetQueue)).Methods("GET")
+	adminApi.Handle("/kyc/{id}", requires(domain.PermissionKYCReview, kycHandler.GetSubmission)).Methods("GET")
+	adminApi.Handle("/kyc/{id}/documents/{documentId}", requires(domain.PermissionKYCReview, kycHandler.GetDocument)).Methods("GET")
+	adminApi.Handle("/kyc/{id}/approve", requires(domain.PermissionKYCReview, kycHandler.Approve)).Methods("POST")
+	adminApi.Handle("/kyc/{id}/reject", requires(domain.PermissionKYCReview, kycHandler.Reject)).Methods("POST")
+
+	// Limit policy routes
+	adminApi.Handle("/limits/policies", requires(domain.PermissionLimitsRead, transactionLimitHandler.GetPolicies)).Methods("GET")
+	adminApi.Handle("/limits/policies", requires(domain.PermissionLimitsWrite, transactionLimitHandler.CreatePolicy)).Methods("POST")
+	adminApi.Handle("/limits/policies/{id}", requires(domain.PermissionLimitsWrite, transactionLimitHandler.UpdatePolicy)).Methods("PUT")
+	adminApi.Handle("/limits/policies/{id}", requires(domain.PermissionLimitsWrite, transactionLimitHandler.DeletePolicy)).Methods("DELETE")
+	adminApi.Handle("/limits/users/{id}", requires(domain.PermissionLimitsRead, transactionLimitHandler.GetUserLimits)).Methods("GET")
+	adminApi.Handle("/limits/users/{id}", requires(domain.PermissionLimitsWrite, transactionLimitHandler.SetUserOverride)).Methods("PUT")
+
+	// Notification delivery routes
+	adminApi.Handle("/notifications/dead-letters", requires(domain.PermissionNotificationsRead, adminNotificationHandler.GetDeadLetters)).Methods("GET")
+	adminApi.Handle("/notifications/deliveries/{id}/retry", requires(domain.PermissionNotificationsRetry, adminNotificationHandler.RetryDelivery)).Methods("POST")
 
 	// User-specific audit logs are available through the regular API
 	api.HandleFunc("/audit/logs", auditHandler.GetUserAuditLogs).Methods("GET")
@@ -163,6 +534,14 @@ func main() {
 		Handler: router,
 	}
 
+	// Start the notification dispatcher
+	if cfg.Notifications.PollIntervalSeconds <= 0 || cfg.Notifications.BatchSize <= 0 {
+		logger.Error("Notification poll interval and batch size must be positive")
+		os.Exit(1)
+	}
+	dispatcherCtx, stopDispatcher := context.WithCancel(context.Background())
+	go notificationDeliveryUseCase.Run(dispatcherCtx)
+
 	// Start server
 	go func() {
 		logger.Info("Server starting on port " + cfg.Server.Port)
@@ -177,6 +556,8 @@ func main() {
 	signal.Notify(quit, os.Interrupt)
 	<-quit
 
+	stopDispatcher()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -0,0 +1,76 @@
+// Command keys manages the RS256 keys that sign GonPay JWTs.
+//
+//	go run ./cmd/keys rotate   generate a new signing key and retire the old ones
+//	go run ./cmd/keys list     show the keys that can still verify tokens
+//
+// Retired keys keep verifying tokens for the longest token lifetime plus the
+// API's key refresh interval, then they are deleted by the next rotation.
+package main
+
+import (
+	"GonPay_Backend/internal/auth"
+	"GonPay_Backend/internal/config"
+	"GonPay_Backend/internal/repository"
+	"GonPay_Backend/internal/usecase"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: keys rotate|list")
+		os.Exit(2)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatal("Cannot load config:", err)
+	}
+
+	db, err := repository.NewPostgresDB(fmt.Sprintf(
+		"postgresql://%s:%s@%s:%s/%s?sslmode=%s",
+		cfg.Database.User,
+		cfg.Database.Password,
+		cfg.Database.Host,
+		cfg.Database.Port,
+		cfg.Database.DBName,
+		cfg.Database.SSLMode,
+	))
+	if err != nil {
+		log.Fatal("Cannot connect to database:", err)
+	}
+
+	keyRepo := repository.NewSigningKeyRepository(db)
+	keyCipher, err := auth.NewKeyCipher(cfg.JWT.KeyEncryptionKey)
+	if err != nil {
+		log.Fatal("Cannot load signing key encryption key:", err)
+	}
+
+	switch os.Args[1] {
+	case "rotate":
+		retireAfter := usecase.MaxTokenLifetime(cfg.JWT.AccessTTL, cfg.JWT.ImpersonationTTL) + auth.KeyRefreshInterval
+		key, retired, err := auth.Rotate(keyRepo, keyCipher, retireAfter)
+		if err != nil {
+			log.Fatal("Cannot rotate signing key:", err)
+		}
+		fmt.Printf("new signing key %s, %d key(s) retired and valid until %s\n",
+			key.KID, retired, time.Now().Add(retireAfter).Format(time.RFC3339))
+	case "list":
+		keys, err := keyRepo.GetVerifiable(time.Now())
+		if err != nil {
+			log.Fatal("Cannot list signing keys:", err)
+		}
+		for _, key := range keys {
+			status := "active"
+			if !key.Active() {
+				status = "retired, valid until " + key.ExpiresAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%s  %s  created %s  %s\n", key.KID, key.Algorithm, key.CreatedAt.Format(time.RFC3339), status)
+		}
+	default:
+		fmt.Fprintln(os.Stderr, "usage: keys rotate|list")
+		os.Exit(2)
+	}
+}
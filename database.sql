@@ -271,3 +271,411 @@ CREATE TRIGGER user_role_audit
     FOR EACH ROW
     WHEN (OLD.role IS DISTINCT FROM NEW.role)
     EXECUTE FUNCTION audit_role_changes();
+
+-- Per-transaction and velocity limits (0 means no limit)
+ALTER TABLE transaction_limits
+    ADD COLUMN max_single_amount         NUMERIC(15, 2) NOT NULL DEFAULT 0 CHECK (max_single_amount >= 0),
+    ADD COLUMN min_amount                NUMERIC(15, 2) NOT NULL DEFAULT 0 CHECK (min_amount >= 0),
+    ADD COLUMN hourly_count_limit        INTEGER        NOT NULL DEFAULT 0 CHECK (hourly_count_limit >= 0),
+    ADD COLUMN daily_count_limit         INTEGER        NOT NULL DEFAULT 0 CHECK (daily_count_limit >= 0),
+    ADD COLUMN new_recipient_max_amount  NUMERIC(15, 2) NOT NULL DEFAULT 0 CHECK (new_recipient_max_amount >= 0),
+    ADD COLUMN new_recipient_daily_limit NUMERIC(15, 2) NOT NULL DEFAULT 0 CHECK (new_recipient_daily_limit >= 0);
+
+CREATE INDEX idx_transactions_destination ON transactions (destination_wallet_id);
+
+-- KYC tier drives which limit policy applies to a user (0 = unverified)
+ALTER TABLE users
+    ADD COLUMN kyc_tier SMALLINT NOT NULL DEFAULT 0 CHECK (kyc_tier >= 0);
+
+-- Admin-managed limit policies. scope_value is the role name, KYC tier or
+-- user ID the policy applies to and is empty for the DEFAULT scope.
+CREATE TABLE limit_policies
+(
+    policy_id                 BIGSERIAL PRIMARY KEY,
+    scope                     VARCHAR(20)      NOT NULL CHECK (scope IN ('DEFAULT', 'ROLE', 'KYC_TIER', 'USER')),
+    scope_value               VARCHAR(50)      NOT NULL DEFAULT '',
+    transaction_type          transaction_type NOT NULL,
+    daily_limit               NUMERIC(15, 2)   NOT NULL CHECK (daily_limit > 0),
+    monthly_limit             NUMERIC(15, 2)   NOT NULL CHECK (monthly_limit > 0),
+    max_single_amount         NUMERIC(15, 2)   NOT NULL DEFAULT 0 CHECK (max_single_amount >= 0),
+    min_amount                NUMERIC(15, 2)   NOT NULL DEFAULT 0 CHECK (min_amount >= 0),
+    hourly_count_limit        INTEGER          NOT NULL DEFAULT 0 CHECK (hourly_count_limit >= 0),
+    daily_count_limit         INTEGER          NOT NULL DEFAULT 0 CHECK (daily_count_limit >= 0),
+    new_recipient_max_amount  NUMERIC(15, 2)   NOT NULL DEFAULT 0 CHECK (new_recipient_max_amount >= 0),
+    new_recipient_daily_limit NUMERIC(15, 2)   NOT NULL DEFAULT 0 CHECK (new_recipient_daily_limit >= 0),
+    updated_by                BIGINT REFERENCES users (user_id),
+    created_at                TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at                TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (scope, scope_value, transaction_type),
+    CONSTRAINT check_policy_monthly_greater_daily CHECK (monthly_limit >= daily_limit)
+);
+
+INSERT INTO limit_policies (scope, scope_value, transaction_type, daily_limit, monthly_limit, max_single_amount)
+VALUES ('DEFAULT', '', 'DEPOSIT', 100000000, 1000000000, 50000000),
+       ('DEFAULT', '', 'WITHDRAW', 50000000, 500000000, 20000000),
+       ('DEFAULT', '', 'TRANSFER', 50000000, 500000000, 20000000);
+
+-- Limit windows: CALENDAR resets in the user's timezone, ROLLING covers the last 1h/24h/30d
+ALTER TYPE transaction_status ADD VALUE 'REVERSED';
+
+ALTER TABLE transaction_limits
+    ADD COLUMN window_mode VARCHAR(10) NOT NULL DEFAULT 'CALENDAR' CHECK (window_mode IN ('CALENDAR', 'ROLLING'));
+
+ALTER TABLE limit_policies
+    ADD COLUMN window_mode VARCHAR(10) NOT NULL DEFAULT 'CALENDAR' CHECK (window_mode IN ('CALENDAR', 'ROLLING'));
+
+CREATE INDEX idx_transactions_source_created ON transactions (source_wallet_id, created_at);
+
+-- New beneficiaries are in a cooling-off period until this time, during
+-- which each transfer to them is capped
+ALTER TABLE beneficiaries
+    ADD COLUMN cooling_off_until TIMESTAMP WITH TIME ZONE;
+
+-- Beneficiary name verification against the real account holder
+ALTER TABLE beneficiaries
+    ADD COLUMN verified_name     VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN name_match_status VARCHAR(20)  NOT NULL DEFAULT 'UNVERIFIED'
+        CHECK (name_match_status IN ('MATCHED', 'MISMATCH', 'UNVERIFIED'));
+
+-- Outbound payouts to bank account beneficiaries
+CREATE TABLE payouts
+(
+    payout_id          BIGSERIAL PRIMARY KEY,
+    transaction_id     BIGINT             NOT NULL REFERENCES transactions (transaction_id),
+    beneficiary_id     BIGINT             REFERENCES beneficiaries (beneficiary_id) ON DELETE SET NULL,
+    bank_name          VARCHAR(100)       NOT NULL,
+    account_number     VARCHAR(50)        NOT NULL,
+    account_name       VARCHAR(100)       NOT NULL,
+    amount             NUMERIC(15, 2)     NOT NULL CHECK (amount > 0),
+    status             transaction_status NOT NULL DEFAULT 'PENDING',
+    provider_reference VARCHAR(100),
+    created_at         TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_payouts_transaction ON payouts (transaction_id);
+
+-- Sessions: one row per signed-in device, kept alive by rotating refresh tokens
+CREATE TABLE sessions
+(
+    session_id   BIGSERIAL PRIMARY KEY,
+    user_id      BIGINT                   NOT NULL REFERENCES users (user_id),
+    user_agent   TEXT                     NOT NULL DEFAULT '',
+    ip_address   VARCHAR(45)              NOT NULL DEFAULT '',
+    expires_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at   TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    created_at   TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_sessions_user_active ON sessions (user_id) WHERE revoked_at IS NULL;
+
+-- Only the SHA-256 hash of a refresh token is stored. A used token that is
+-- presented again revokes its session.
+CREATE TABLE refresh_tokens
+(
+    token_hash CHAR(64) PRIMARY KEY,
+    session_id BIGINT NOT NULL REFERENCES sessions (session_id) ON DELETE CASCADE,
+    used_at    TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_refresh_tokens_session ON refresh_tokens (session_id);
+
+-- TOTP two-factor authentication (RFC 6238)
+CREATE TABLE user_two_factor
+(
+    user_id        BIGINT PRIMARY KEY REFERENCES users (user_id),
+    secret         VARCHAR(64) NOT NULL,
+    enabled        BOOLEAN     NOT NULL DEFAULT false,
+    last_used_step BIGINT      NOT NULL DEFAULT 0,
+    confirmed_at   TIMESTAMP WITH TIME ZONE,
+    created_at     TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One-time recovery codes, stored as SHA-256 hashes
+CREATE TABLE recovery_codes
+(
+    code_id    BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT   NOT NULL REFERENCES users (user_id),
+    code_hash  CHAR(64) NOT NULL,
+    used_at    TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, code_hash)
+);
+
+-- Brute-force protection on login
+ALTER TABLE users
+    ADD COLUMN failed_login_attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN last_failed_login_at  TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN locked_until          TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE login_attempts
+(
+    attempt_id BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT REFERENCES users (user_id),
+    email      VARCHAR(100) NOT NULL,
+    ip_address VARCHAR(45)  NOT NULL DEFAULT '',
+    user_agent TEXT         NOT NULL DEFAULT '',
+    success    BOOLEAN      NOT NULL,
+    reason     VARCHAR(50)  NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_login_attempts_ip_failed ON login_attempts (ip_address, created_at) WHERE NOT success;
+CREATE INDEX idx_login_attempts_user ON login_attempts (user_id, created_at);
+
+-- Single-use password reset tokens, stored as SHA-256 hashes
+CREATE TABLE password_reset_tokens
+(
+    token_id   BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT                   NOT NULL REFERENCES users (user_id),
+    token_hash CHAR(64)                 NOT NULL UNIQUE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at    TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_password_reset_tokens_user ON password_reset_tokens (user_id) WHERE used_at IS NULL;
+
+-- Email and phone verification
+ALTER TABLE users
+    ADD COLUMN email_verified_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN phone_verified_at TIMESTAMP WITH TIME ZONE;
+
+-- Accounts that existed before verification was introduced are trusted
+UPDATE users
+SET email_verified_at = created_at,
+    phone_verified_at = created_at;
+
+CREATE TABLE verification_codes
+(
+    code_id     BIGSERIAL PRIMARY KEY,
+    user_id     BIGINT                   NOT NULL REFERENCES users (user_id),
+    channel     VARCHAR(10)              NOT NULL CHECK (channel IN ('EMAIL', 'PHONE')),
+    destination VARCHAR(100)             NOT NULL,
+    code_hash   VARCHAR(100)             NOT NULL,
+    attempts    INTEGER                  NOT NULL DEFAULT 0,
+    expires_at  TIMESTAMP WITH TIME ZONE NOT NULL,
+    verified_at TIMESTAMP WITH TIME ZONE,
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_verification_codes_user_channel ON verification_codes (user_id, channel, created_at);
+
+-- Transaction PIN for step-up authentication of payments
+CREATE TABLE transaction_pins
+(
+    user_id    BIGINT PRIMARY KEY REFERENCES users (user_id),
+    pin_hash   VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Wrong step-up credentials in a row; PINs and TOTP codes share the counter
+-- and the lock
+CREATE TABLE step_up_failures
+(
+    user_id         BIGINT PRIMARY KEY REFERENCES users (user_id),
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    locked_until    TIMESTAMP WITH TIME ZONE,
+    updated_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Staff roles for fine-grained permissions (see internal/domain/permission.go)
+ALTER TYPE user_role ADD VALUE IF NOT EXISTS 'SUPPORT';
+ALTER TYPE user_role ADD VALUE IF NOT EXISTS 'COMPLIANCE';
+ALTER TYPE user_role ADD VALUE IF NOT EXISTS 'FINANCE';
+
+-- RS256 keys that sign JWTs; see cmd/keys for rotation. Private keys are
+-- AES-GCM encrypted with jwt.key_encryption_key and deleted on retirement
+CREATE TABLE signing_keys
+(
+    kid                   VARCHAR(64) PRIMARY KEY,
+    algorithm             VARCHAR(10) NOT NULL,
+    private_key_encrypted BYTEA,
+    public_key_pem        TEXT        NOT NULL,
+    created_at            TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    retired_at            TIMESTAMP WITH TIME ZONE,
+    expires_at            TIMESTAMP WITH TIME ZONE
+);
+
+-- Merchant accounts and their API keys; only a hash of the secret is stored
+ALTER TYPE user_role ADD VALUE IF NOT EXISTS 'MERCHANT';
+
+CREATE TABLE api_keys
+(
+    api_key_id   BIGSERIAL PRIMARY KEY,
+    user_id      BIGINT       NOT NULL REFERENCES users (user_id),
+    name         VARCHAR(100) NOT NULL,
+    prefix       VARCHAR(20)  NOT NULL UNIQUE,
+    secret_hash  VARCHAR(64)  NOT NULL,
+    scopes       TEXT[]       NOT NULL,
+    usage_count  BIGINT       NOT NULL DEFAULT 0,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    last_used_ip INET,
+    expires_at   TIMESTAMP WITH TIME ZONE,
+    revoked_at   TIMESTAMP WITH TIME ZONE,
+    created_at   TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_api_keys_user ON api_keys (user_id);
+
+-- HMAC signing of merchant server-to-server requests
+CREATE TABLE merchant_signing_secrets
+(
+    user_id    BIGINT PRIMARY KEY REFERENCES users (user_id),
+    secret     VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE request_nonces
+(
+    user_id    BIGINT                   NOT NULL REFERENCES users (user_id),
+    nonce      VARCHAR(64)              NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (user_id, nonce)
+);
+
+CREATE INDEX idx_request_nonces_created_at ON request_nonces (created_at);
+
+-- KYC identity verification; document files live in the blob store, only
+-- their keys are stored here
+CREATE TABLE kyc_submissions
+(
+    kyc_submission_id BIGSERIAL PRIMARY KEY,
+    user_id           BIGINT      NOT NULL REFERENCES users (user_id),
+    requested_tier    SMALLINT    NOT NULL CHECK (requested_tier > 0),
+    status            VARCHAR(20) NOT NULL CHECK (status IN ('PENDING', 'VERIFIED', 'REJECTED')),
+    rejection_reason  TEXT,
+    reviewed_by       BIGINT REFERENCES users (user_id),
+    reviewed_at       TIMESTAMP WITH TIME ZONE,
+    created_at        TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_kyc_submissions_user_id ON kyc_submissions (user_id, created_at);
+CREATE INDEX idx_kyc_submissions_status ON kyc_submissions (status, created_at);
+CREATE UNIQUE INDEX idx_kyc_submissions_one_pending ON kyc_submissions (user_id) WHERE status = 'PENDING';
+
+CREATE TABLE kyc_documents
+(
+    kyc_document_id   BIGSERIAL PRIMARY KEY,
+    kyc_submission_id BIGINT       NOT NULL REFERENCES kyc_submissions (kyc_submission_id),
+    document_type     VARCHAR(20)  NOT NULL CHECK (document_type IN ('ID_FRONT', 'ID_BACK', 'SELFIE')),
+    storage_key       VARCHAR(255) NOT NULL,
+    content_type      VARCHAR(100) NOT NULL,
+    size_bytes        BIGINT       NOT NULL,
+    created_at        TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Wallet status: FROZEN wallets can only receive money, BLOCKED wallets can
+-- neither send nor receive, CLOSED wallets were deactivated by their owner
+CREATE TYPE wallet_status AS ENUM ('ACTIVE', 'FROZEN', 'BLOCKED', 'CLOSED');
+
+ALTER TABLE wallets
+    ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE wallets
+    ALTER COLUMN status TYPE wallet_status
+        USING (CASE status WHEN 'INACTIVE' THEN 'CLOSED' ELSE status::text END)::wallet_status;
+ALTER TABLE wallets
+    ALTER COLUMN status SET DEFAULT 'ACTIVE';
+
+-- Manual balance corrections, proposed by one admin and approved by another
+ALTER TYPE transaction_type ADD VALUE 'ADJUSTMENT_CREDIT';
+ALTER TYPE transaction_type ADD VALUE 'ADJUSTMENT_DEBIT';
+
+CREATE TABLE wallet_adjustments
+(
+    wallet_adjustment_id BIGSERIAL PRIMARY KEY,
+    wallet_id            BIGINT         NOT NULL REFERENCES wallets (wallet_id),
+    amount               NUMERIC(15, 2) NOT NULL CHECK (amount <> 0),
+    reason               TEXT           NOT NULL,
+    status               VARCHAR(20)    NOT NULL CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED', 'FAILED')),
+    proposed_by          BIGINT         NOT NULL REFERENCES users (user_id),
+    reviewed_by          BIGINT REFERENCES users (user_id),
+    review_note          TEXT,
+    reviewed_at          TIMESTAMP WITH TIME ZONE,
+    transaction_id       BIGINT REFERENCES transactions (transaction_id),
+    created_at           TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT check_adjustment_four_eyes CHECK (reviewed_by IS NULL OR reviewed_by <> proposed_by)
+);
+
+CREATE INDEX idx_wallet_adjustments_status ON wallet_adjustments (status, created_at);
+CREATE INDEX idx_wallet_adjustments_wallet_id ON wallet_adjustments (wallet_id);
+
+-- Impersonation: sessions an admin opened to view a user's account read-only
+ALTER TABLE sessions
+    ADD COLUMN impersonated_by BIGINT REFERENCES users (user_id);
+
+-- Account closure: the account is pseudonymised and its financial records
+-- are kept until retain_until
+ALTER TYPE user_status ADD VALUE 'CLOSED';
+ALTER TABLE users
+    ADD COLUMN closed_at    TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN retain_until TIMESTAMP WITH TIME ZONE;
+
+-- Personal data exports requested by users
+CREATE TABLE data_exports
+(
+    data_export_id BIGSERIAL PRIMARY KEY,
+    user_id        BIGINT      NOT NULL REFERENCES users (user_id),
+    status         VARCHAR(20) NOT NULL CHECK (status IN ('PENDING', 'READY', 'FAILED')),
+    storage_key    VARCHAR(255),
+    size_bytes     BIGINT      NOT NULL DEFAULT 0,
+    completed_at   TIMESTAMP WITH TIME ZONE,
+    expires_at     TIMESTAMP WITH TIME ZONE,
+    created_at     TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_data_exports_user_id ON data_exports (user_id, created_at);
+
+-- Preferences are a versioned document; the application upgrades older
+-- versions when it reads them
+ALTER TABLE users
+    ADD CONSTRAINT check_preferences_object CHECK (jsonb_typeof(preferences) = 'object');
+
+-- Notification outbox: one row per notification and channel, written in the
+-- same transaction as the notification and worked by the dispatcher
+CREATE TABLE notification_deliveries
+(
+    notification_delivery_id BIGSERIAL PRIMARY KEY,
+    notification_id          BIGINT      NOT NULL REFERENCES notifications (notification_id),
+    user_id                  BIGINT      NOT NULL REFERENCES users (user_id),
+    channel                  VARCHAR(20) NOT NULL CHECK (channel IN ('EMAIL', 'SMS', 'PUSH')),
+    status                   VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'SENT', 'SKIPPED', 'DEAD')),
+    attempts                 INT         NOT NULL DEFAULT 0,
+    next_attempt_at          TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error               TEXT,
+    sent_at                  TIMESTAMP WITH TIME ZONE,
+    created_at               TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at               TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (notification_id, channel)
+);
+
+CREATE INDEX idx_notification_deliveries_due ON notification_deliveries (next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX idx_notification_deliveries_dead ON notification_deliveries (updated_at) WHERE status = 'DEAD';
+CREATE INDEX idx_notification_deliveries_user_id ON notification_deliveries (user_id);
+
+-- App installs that receive push messages; token is the FCM registration
+-- token or the APNs device token
+CREATE TABLE push_devices
+(
+    push_device_id BIGSERIAL PRIMARY KEY,
+    user_id        BIGINT      NOT NULL REFERENCES users (user_id),
+    platform       VARCHAR(20) NOT NULL CHECK (platform IN ('ANDROID', 'IOS')),
+    token          TEXT        NOT NULL UNIQUE,
+    created_at     TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at   TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_push_devices_user_id ON push_devices (user_id);
+
+-- Password reset requests by IP address, for throttling; kept apart from the
+-- tokens because requests for unknown emails must be counted too
+CREATE TABLE password_reset_requests
+(
+    request_id BIGSERIAL PRIMARY KEY,
+    ip_address VARCHAR(45) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_password_reset_requests_ip ON password_reset_requests (ip_address, created_at);
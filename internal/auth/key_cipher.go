@@ -0,0 +1,62 @@
+// internal/auth/key_cipher.go
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+const keyEncryptionKeySize = 32
+
+// KeyCipher encrypts signing private keys with AES-256-GCM before they are
+// stored, so that a copy of the database alone cannot be used to forge
+// tokens. The key encryption key comes from configuration and never touches
+// the database.
+type KeyCipher struct {
+	aead cipher.AEAD
+}
+
+// NewKeyCipher takes the base64 encoded 32 byte key encryption key.
+func NewKeyCipher(encodedKey string) (*KeyCipher, error) {
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("key encryption key: %w", err)
+	}
+	if len(key) != keyEncryptionKeySize {
+		return nil, fmt.Errorf("key encryption key must be %d bytes, got %d", keyEncryptionKeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyCipher{aead: aead}, nil
+}
+
+// Seal encrypts a private key. The kid is authenticated along with it, so a
+// ciphertext copied to another key's row does not decrypt.
+func (c *KeyCipher) Seal(kid string, privateKeyPEM []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return c.aead.Seal(nonce, nonce, privateKeyPEM, []byte(kid)), nil
+}
+
+// Open decrypts a private key sealed for kid.
+func (c *KeyCipher) Open(kid string, sealed []byte) ([]byte, error) {
+	if len(sealed) < c.aead.NonceSize() {
+		return nil, errors.New("encrypted private key is too short")
+	}
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	return c.aead.Open(nil, nonce, ciphertext, []byte(kid))
+}
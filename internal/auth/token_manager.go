@@ -0,0 +1,284 @@
+// internal/auth/token_manager.go
+package auth
+
+import (
+	"GonPay_Backend/internal/domain"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const (
+	AlgorithmRS256 = "RS256"
+	rsaKeyBits     = 2048
+
+	// KeyRefreshInterval is how often keys are reloaded from the database so
+	// keys rotated by another process are picked up.
+	KeyRefreshInterval = time.Minute
+	// An unknown kid triggers a reload, but not more often than this.
+	minReloadInterval = 5 * time.Second
+)
+
+// TokenManager signs and verifies GonPay JWTs with RS256. Tokens carry the
+// kid of the key that signed them, so several keys can be valid at once
+// while keys are rotated.
+type TokenManager struct {
+	keyRepo domain.SigningKeyRepository
+	cipher  *KeyCipher
+	issuer  string
+
+	mu         sync.RWMutex
+	signingKID string
+	signingKey *rsa.PrivateKey
+	publicKeys map[string]*rsa.PublicKey
+	jwks       *domain.JWKS
+	loadedAt   time.Time
+}
+
+func NewTokenManager(keyRepo domain.SigningKeyRepository, cipher *KeyCipher, issuer string) *TokenManager {
+	return &TokenManager{
+		keyRepo: keyRepo,
+		cipher:  cipher,
+		issuer:  issuer,
+	}
+}
+
+// Load reads all keys that can still verify tokens. On a fresh install, or
+// when the newest active key has no private key left, a signing key is
+// generated.
+func (m *TokenManager) Load() error {
+	keys, err := m.keyRepo.GetVerifiable(time.Now())
+	if err != nil {
+		return err
+	}
+
+	if len(keys) == 0 || !keys[0].Active() || keys[0].EncryptedPrivateKey == nil {
+		key, err := GenerateKey(m.cipher)
+		if err != nil {
+			return err
+		}
+		if err := m.keyRepo.Create(key); err != nil {
+			return err
+		}
+		keys = append([]*domain.SigningKey{key}, keys...)
+	}
+
+	publicKeys := make(map[string]*rsa.PublicKey, len(keys))
+	jwks := &domain.JWKS{Keys: make([]domain.JWK, 0, len(keys))}
+	var signingKID string
+	var signingKey *rsa.PrivateKey
+
+	for _, key := range keys {
+		publicKey, err := jwt.ParseRSAPublicKeyFromPEM([]byte(key.PublicKeyPEM))
+		if err != nil {
+			return fmt.Errorf("signing key %s: %w", key.KID, err)
+		}
+		publicKeys[key.KID] = publicKey
+		jwks.Keys = append(jwks.Keys, publicJWK(key.KID, publicKey))
+
+		if signingKey == nil && key.Active() {
+			privateKeyPEM, err := m.cipher.Open(key.KID, key.EncryptedPrivateKey)
+			if err != nil {
+				return fmt.Errorf("signing key %s: cannot decrypt private key: %w", key.KID, err)
+			}
+			signingKey, err = jwt.ParseRSAPrivateKeyFromPEM(privateKeyPEM)
+			if err != nil {
+				return fmt.Errorf("signing key %s: %w", key.KID, err)
+			}
+			signingKID = key.KID
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.signingKID = signingKID
+	m.signingKey = signingKey
+	m.publicKeys = publicKeys
+	m.jwks = jwks
+	m.loadedAt = time.Now()
+
+	return nil
+}
+
+// Sign signs the claims with the current key, adding the issuer.
+func (m *TokenManager) Sign(claims jwt.MapClaims) (string, error) {
+	if err := m.refresh(KeyRefreshInterval); err != nil {
+		return "", err
+	}
+
+	m.mu.RLock()
+	kid, key := m.signingKID, m.signingKey
+	m.mu.RUnlock()
+
+	claims["iss"] = m.issuer
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+
+	return token.SignedString(key)
+}
+
+// Parse verifies the token's signature, expiry and issuer and returns its
+// claims. Any failure is reported as domain.ErrInvalidToken.
+func (m *TokenManager) Parse(tokenString string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if token.Method != jwt.SigningMethodRS256 {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		kid, _ := token.Header["kid"].(string)
+		return m.publicKey(kid)
+	})
+	if err != nil || !token.Valid {
+		return nil, domain.ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !claims.VerifyIssuer(m.issuer, true) {
+		return nil, domain.ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// JWKS returns the public keys other services use to verify our tokens.
+func (m *TokenManager) JWKS() (*domain.JWKS, error) {
+	if err := m.refresh(KeyRefreshInterval); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.jwks, nil
+}
+
+func (m *TokenManager) publicKey(kid string) (*rsa.PublicKey, error) {
+	if err := m.refresh(KeyRefreshInterval); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	key, ok := m.publicKeys[kid]
+	m.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	// The key may have been created by a rotation in another process
+	if err := m.refresh(minReloadInterval); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	key, ok = m.publicKeys[kid]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+// refresh reloads the keys if they are older than maxAge. A failed reload
+// keeps the keys already loaded, if any.
+func (m *TokenManager) refresh(maxAge time.Duration) error {
+	m.mu.RLock()
+	loaded, fresh := m.signingKey != nil, time.Since(m.loadedAt) < maxAge
+	m.mu.RUnlock()
+	if fresh {
+		return nil
+	}
+
+	if err := m.Load(); err != nil && !loaded {
+		return err
+	}
+	return nil
+}
+
+// Rotate creates a new signing key and retires the previous ones. Retired keys
+// keep verifying tokens for retireAfter, which must cover the longest token
+// lifetime, and are deleted after that.
+func Rotate(keyRepo domain.SigningKeyRepository, cipher *KeyCipher, retireAfter time.Duration) (*domain.SigningKey, int64, error) {
+	key, err := GenerateKey(cipher)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := keyRepo.Create(key); err != nil {
+		return nil, 0, err
+	}
+
+	now := time.Now()
+	retired, err := keyRepo.RetireAllExcept(key.KID, now, now.Add(retireAfter))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if _, err := keyRepo.DeleteExpired(now); err != nil {
+		return nil, 0, err
+	}
+
+	return key, retired, nil
+}
+
+// GenerateKey creates a new RSA key pair with the private key encrypted by
+// cipher. Its kid is the RFC 7638 thumbprint of the public key.
+func GenerateKey(cipher *KeyCipher) (*domain.SigningKey, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		return nil, err
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	publicDER, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	kid := thumbprint(&privateKey.PublicKey)
+	encryptedPrivateKey, err := cipher.Seal(kid, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.SigningKey{
+		KID:                 kid,
+		Algorithm:           AlgorithmRS256,
+		EncryptedPrivateKey: encryptedPrivateKey,
+		PublicKeyPEM:        string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})),
+	}, nil
+}
+
+func publicJWK(kid string, key *rsa.PublicKey) domain.JWK {
+	n, e := rsaComponents(key)
+	return domain.JWK{
+		KeyType:   "RSA",
+		Use:       "sig",
+		Algorithm: AlgorithmRS256,
+		KID:       kid,
+		N:         n,
+		E:         e,
+	}
+}
+
+func thumbprint(key *rsa.PublicKey) string {
+	n, e := rsaComponents(key)
+	// Members in lexicographic order, without whitespace (RFC 7638 section 3)
+	sum := sha256.Sum256([]byte(`{"e":"` + e + `","kty":"RSA","n":"` + n + `"}`))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func rsaComponents(key *rsa.PublicKey) (string, string) {
+	return base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+}
@@ -6,13 +6,29 @@ import (
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
+	Server         ServerConfig
+	Database       DatabaseConfig
+	JWT            JWTConfig
+	Beneficiary    BeneficiaryConfig
+	Bank           BankConfig
+	Login          LoginConfig
+	Mail           MailConfig
+	PasswordReset  PasswordResetConfig `mapstructure:"password_reset"`
+	Verification   VerificationConfig
+	SMS            SMSConfig
+	StepUp         StepUpConfig         `mapstructure:"step_up"`
+	RequestSigning RequestSigningConfig `mapstructure:"request_signing"`
+	KYC            KYCConfig
+	Privacy        PrivacyConfig
+	Push           PushConfig
+	Notifications  NotificationsConfig
 }
 
 type ServerConfig struct {
 	Port string
+	// Reverse proxies whose X-Forwarded-For header is believed, as CIDR
+	// ranges or IP addresses
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
 }
 
 type DatabaseConfig struct {
@@ -24,9 +40,133 @@ type DatabaseConfig struct {
 	SSLMode  string
 }
 
+// JWTConfig sets the issuer of GonPay tokens, the lifetime of access tokens
+// (minutes), of the sessions kept alive by refresh tokens (hours) and of the
+// read-only tokens admins get to impersonate a user (minutes). Tokens are
+// signed with RS256 keys stored in the database, see cmd/keys.
 type JWTConfig struct {
-	Secret string
-	TTL    int64
+	Issuer string
+	// Base64 encoded 32 byte key that encrypts the signing private keys
+	// stored in the database
+	KeyEncryptionKey string `mapstructure:"key_encryption_key"`
+	AccessTTL        int64  `mapstructure:"access_ttl"`
+	RefreshTTL       int64  `mapstructure:"refresh_ttl"`
+	ImpersonationTTL int64  `mapstructure:"impersonation_ttl"`
+}
+
+// BeneficiaryConfig controls the cooling-off period of newly added
+// beneficiaries, during which each transfer to them is capped.
+type BeneficiaryConfig struct {
+	CoolingOffHours     int64   `mapstructure:"cooling_off_hours"`
+	CoolingOffMaxAmount float64 `mapstructure:"cooling_off_max_amount"`
+}
+
+// BankConfig selects the provider used for bank account name lookups and
+// payouts. Only "local" is available for now.
+type BankConfig struct {
+	Provider string
+}
+
+// LoginConfig controls brute-force protection on login. Zero limits disable
+// the corresponding check.
+type LoginConfig struct {
+	DelayAfterAttempts  int   `mapstructure:"delay_after_attempts"`
+	BaseDelaySeconds    int64 `mapstructure:"base_delay_seconds"`
+	MaxFailedAttempts   int   `mapstructure:"max_failed_attempts"`
+	LockoutMinutes      int64 `mapstructure:"lockout_minutes"`
+	IPMaxFailedAttempts int   `mapstructure:"ip_max_failed_attempts"`
+	IPWindowMinutes     int64 `mapstructure:"ip_window_minutes"`
+}
+
+// MailConfig selects how outgoing mail is delivered: "smtp", or "file" and
+// "log" for local development.
+type MailConfig struct {
+	Provider     string
+	From         string
+	SMTPHost     string `mapstructure:"smtp_host"`
+	SMTPPort     int    `mapstructure:"smtp_port"`
+	SMTPUsername string `mapstructure:"smtp_username"`
+	SMTPPassword string `mapstructure:"smtp_password"`
+	FileDir      string `mapstructure:"file_dir"`
+}
+
+// PasswordResetConfig sets how long reset links stay valid, how often they
+// can be requested and the link sent to the user, where %s is replaced by
+// the token.
+type PasswordResetConfig struct {
+	TokenTTLMinutes       int64  `mapstructure:"token_ttl_minutes"`
+	ResendIntervalSeconds int64  `mapstructure:"resend_interval_seconds"`
+	IPMaxRequests         int    `mapstructure:"ip_max_requests"`
+	IPWindowMinutes       int64  `mapstructure:"ip_window_minutes"`
+	URL                   string `mapstructure:"url"`
+}
+
+// VerificationConfig controls email and phone OTP codes and whether
+// unverified users may move money.
+type VerificationConfig struct {
+	CodeTTLMinutes        int64 `mapstructure:"code_ttl_minutes"`
+	MaxAttempts           int   `mapstructure:"max_attempts"`
+	ResendIntervalSeconds int64 `mapstructure:"resend_interval_seconds"`
+	RequireEmail          bool  `mapstructure:"require_email"`
+	RequirePhone          bool  `mapstructure:"require_phone"`
+}
+
+// SMSConfig selects the SMS provider. Only "local" is available for now.
+type SMSConfig struct {
+	Provider string
+}
+
+// StepUpConfig decides which payments need the transaction PIN or a TOTP
+// code, and how long the PIN is locked after too many wrong attempts.
+type StepUpConfig struct {
+	Threshold         float64 `mapstructure:"threshold"`
+	NewRecipient      bool    `mapstructure:"new_recipient"`
+	PINMaxAttempts    int     `mapstructure:"pin_max_attempts"`
+	PINLockoutMinutes int64   `mapstructure:"pin_lockout_minutes"`
+}
+
+// RequestSigningConfig sets how far a signed merchant request's timestamp may
+// be from the server clock.
+type RequestSigningConfig struct {
+	MaxSkewSeconds int64 `mapstructure:"max_skew_seconds"`
+}
+
+// KYCConfig selects where identity documents are stored ("local" keeps them
+// in StorageDir), caps their size and sets the most a user may hold at each
+// KYC tier, indexed by tier. A cap of 0 means no limit.
+type KYCConfig struct {
+	Storage          string
+	StorageDir       string    `mapstructure:"storage_dir"`
+	MaxDocumentBytes int64     `mapstructure:"max_document_bytes"`
+	TierMaxBalances  []float64 `mapstructure:"tier_max_balances"`
+}
+
+// PrivacyConfig selects where personal data exports are stored, how long a
+// finished export can be downloaded and how many years the financial records
+// of a closed account are kept.
+type PrivacyConfig struct {
+	Storage        string
+	StorageDir     string `mapstructure:"storage_dir"`
+	ExportTTLHours int64  `mapstructure:"export_ttl_hours"`
+	RetentionYears int    `mapstructure:"retention_years"`
+}
+
+// PushConfig selects the mobile push provider. Only "local" is available for
+// now.
+type PushConfig struct {
+	Provider string
+}
+
+// NotificationsConfig controls the dispatcher that sends notifications by
+// email, SMS and push: how often it checks the outbox, how many deliveries
+// it takes at once and how failed deliveries are retried.
+type NotificationsConfig struct {
+	PollIntervalSeconds int `mapstructure:"poll_interval_seconds"`
+	BatchSize           int `mapstructure:"batch_size"`
+	LeaseSeconds        int `mapstructure:"lease_seconds"`
+	MaxAttempts         int `mapstructure:"max_attempts"`
+	BaseBackoffSeconds  int `mapstructure:"base_backoff_seconds"`
+	MaxBackoffSeconds   int `mapstructure:"max_backoff_seconds"`
 }
 
 func LoadConfig() (*Config, error) {
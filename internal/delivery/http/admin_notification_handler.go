@@ -0,0 +1,54 @@
+// internal/delivery/http/admin_notification_handler.go
+package http
+
+import (
+	"GonPay_Backend/internal/delivery/middleware"
+	"GonPay_Backend/internal/delivery/problem"
+	"GonPay_Backend/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type AdminNotificationHandler struct {
+	deliveryUseCase *usecase.NotificationDeliveryUseCase
+}
+
+func NewAdminNotificationHandler(deliveryUseCase *usecase.NotificationDeliveryUseCase) *AdminNotificationHandler {
+	return &AdminNotificationHandler{
+		deliveryUseCase: deliveryUseCase,
+	}
+}
+
+// GetDeadLetters lists deliveries that failed on every attempt. Filter with
+// ?channel=EMAIL, SMS or PUSH.
+func (h *AdminNotificationHandler) GetDeadLetters(w http.ResponseWriter, r *http.Request) {
+	page, limit := getPaginationParams(r)
+
+	deliveries, err := h.deliveryUseCase.GetDeadLetters(r.URL.Query().Get("channel"), page, limit)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, deliveries)
+}
+
+func (h *AdminNotificationHandler) RetryDelivery(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid delivery ID"))
+		return
+	}
+
+	adminID := r.Context().Value("user_id").(int64)
+
+	delivery, err := h.deliveryUseCase.Retry(adminID, id, middleware.ClientInfo(r))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, delivery)
+}
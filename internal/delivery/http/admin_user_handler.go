@@ -0,0 +1,218 @@
+// internal/delivery/http/admin_user_handler.go
+package http
+
+import (
+	"GonPay_Backend/internal/delivery/middleware"
+	"GonPay_Backend/internal/delivery/problem"
+	"GonPay_Backend/internal/domain"
+	"GonPay_Backend/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type AdminUserHandler struct {
+	adminUserUseCase *usecase.AdminUserUseCase
+}
+
+type AssignRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=USER SUPPORT COMPLIANCE FINANCE ADMIN MERCHANT"`
+}
+
+type SuspendUserRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+func NewAdminUserHandler(adminUserUseCase *usecase.AdminUserUseCase) *AdminUserHandler {
+	return &AdminUserHandler{
+		adminUserUseCase: adminUserUseCase,
+	}
+}
+
+// SearchUsers finds users by username, email or phone number with ?q=
+func (h *AdminUserHandler) SearchUsers(w http.ResponseWriter, r *http.Request) {
+	page, limit := getPaginationParams(r)
+
+	users, err := h.adminUserUseCase.SearchUsers(r.URL.Query().Get("q"), page, limit)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, users)
+}
+
+func (h *AdminUserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := adminUserID(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.adminUserUseCase.GetUser(userID)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, user)
+}
+
+func (h *AdminUserHandler) GetUserWallets(w http.ResponseWriter, r *http.Request) {
+	userID, ok := adminUserID(w, r)
+	if !ok {
+		return
+	}
+
+	wallets, err := h.adminUserUseCase.GetUserWallets(userID)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, wallets)
+}
+
+func (h *AdminUserHandler) GetUserTransactions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := adminUserID(w, r)
+	if !ok {
+		return
+	}
+
+	page, limit := getPaginationParams(r)
+
+	transactions, err := h.adminUserUseCase.GetUserTransactions(userID, page, limit)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, transactions)
+}
+
+func (h *AdminUserHandler) Suspend(w http.ResponseWriter, r *http.Request) {
+	userID, ok := adminUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req SuspendUserRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	adminID := r.Context().Value("user_id").(int64)
+
+	if err := h.adminUserUseCase.Suspend(adminID, userID, req.Reason, middleware.ClientInfo(r)); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithMessage(w, r, http.StatusOK, "message.user_suspended")
+}
+
+func (h *AdminUserHandler) Reactivate(w http.ResponseWriter, r *http.Request) {
+	userID, ok := adminUserID(w, r)
+	if !ok {
+		return
+	}
+
+	adminID := r.Context().Value("user_id").(int64)
+
+	if err := h.adminUserUseCase.Reactivate(adminID, userID, middleware.ClientInfo(r)); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithMessage(w, r, http.StatusOK, "message.user_reactivated")
+}
+
+func (h *AdminUserHandler) ForceLogout(w http.ResponseWriter, r *http.Request) {
+	userID, ok := adminUserID(w, r)
+	if !ok {
+		return
+	}
+
+	adminID := r.Context().Value("user_id").(int64)
+
+	if err := h.adminUserUseCase.ForceLogout(adminID, userID, middleware.ClientInfo(r)); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithMessage(w, r, http.StatusOK, "message.user_signed_out")
+}
+
+func (h *AdminUserHandler) Reset2FA(w http.ResponseWriter, r *http.Request) {
+	userID, ok := adminUserID(w, r)
+	if !ok {
+		return
+	}
+
+	adminID := r.Context().Value("user_id").(int64)
+
+	if err := h.adminUserUseCase.Reset2FA(adminID, userID, middleware.ClientInfo(r)); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithMessage(w, r, http.StatusOK, "message.user_2fa_reset")
+}
+
+func (h *AdminUserHandler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := adminUserID(w, r)
+	if !ok {
+		return
+	}
+
+	adminID := r.Context().Value("user_id").(int64)
+
+	if err := h.adminUserUseCase.UnlockUser(adminID, userID, middleware.ClientInfo(r)); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithMessage(w, r, http.StatusOK, "message.user_unlocked")
+}
+
+func (h *AdminUserHandler) GetRoles(w http.ResponseWriter, r *http.Request) {
+	respondWithJSON(w, http.StatusOK, domain.Roles())
+}
+
+func (h *AdminUserHandler) AssignRole(w http.ResponseWriter, r *http.Request) {
+	userID, ok := adminUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req AssignRoleRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	adminID := r.Context().Value("user_id").(int64)
+
+	user, err := h.adminUserUseCase.AssignRole(adminID, userID, req.Role, middleware.ClientInfo(r))
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidOperation:
+			problem.Write(w, r, domain.NewAppError(domain.CodeForbidden, "You cannot change your own role"))
+		default:
+			problem.Write(w, r, err)
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, user)
+}
+
+func adminUserID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	userID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid user ID"))
+		return 0, false
+	}
+	return userID, true
+}
@@ -0,0 +1,183 @@
+// internal/delivery/http/admin_wallet_handler.go
+package http
+
+import (
+	"GonPay_Backend/internal/delivery/middleware"
+	"GonPay_Backend/internal/delivery/problem"
+	"GonPay_Backend/internal/domain"
+	"GonPay_Backend/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type AdminWalletHandler struct {
+	adminWalletUseCase *usecase.AdminWalletUseCase
+}
+
+type WalletStatusRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+type ProposeAdjustmentRequest struct {
+	Amount float64 `json:"amount" validate:"required"`
+	Reason string  `json:"reason" validate:"required,max=500"`
+}
+
+type ReviewAdjustmentRequest struct {
+	Note string `json:"note" validate:"max=500"`
+}
+
+func NewAdminWalletHandler(adminWalletUseCase *usecase.AdminWalletUseCase) *AdminWalletHandler {
+	return &AdminWalletHandler{
+		adminWalletUseCase: adminWalletUseCase,
+	}
+}
+
+func (h *AdminWalletHandler) GetWallet(w http.ResponseWriter, r *http.Request) {
+	walletID, ok := adminWalletID(w, r)
+	if !ok {
+		return
+	}
+
+	wallet, err := h.adminWalletUseCase.GetWallet(walletID)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, wallet)
+}
+
+func (h *AdminWalletHandler) Freeze(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.adminWalletUseCase.Freeze)
+}
+
+func (h *AdminWalletHandler) Block(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.adminWalletUseCase.Block)
+}
+
+func (h *AdminWalletHandler) Unfreeze(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.adminWalletUseCase.Unfreeze)
+}
+
+func (h *AdminWalletHandler) changeStatus(
+	w http.ResponseWriter,
+	r *http.Request,
+	change func(adminID int64, walletID int64, reason string, client domain.ClientInfo) (*domain.Wallet, error),
+) {
+	walletID, ok := adminWalletID(w, r)
+	if !ok {
+		return
+	}
+
+	var req WalletStatusRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	adminID := r.Context().Value("user_id").(int64)
+
+	wallet, err := change(adminID, walletID, req.Reason, middleware.ClientInfo(r))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, wallet)
+}
+
+func (h *AdminWalletHandler) ProposeAdjustment(w http.ResponseWriter, r *http.Request) {
+	walletID, ok := adminWalletID(w, r)
+	if !ok {
+		return
+	}
+
+	var req ProposeAdjustmentRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	adminID := r.Context().Value("user_id").(int64)
+
+	adjustment, err := h.adminWalletUseCase.ProposeAdjustment(adminID, walletID, req.Amount, req.Reason, middleware.ClientInfo(r))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, adjustment)
+}
+
+// GetAdjustments lists adjustments, by default those awaiting approval.
+// Filter with ?status=APPROVED, REJECTED or FAILED.
+func (h *AdminWalletHandler) GetAdjustments(w http.ResponseWriter, r *http.Request) {
+	page, limit := getPaginationParams(r)
+
+	adjustments, err := h.adminWalletUseCase.GetAdjustments(r.URL.Query().Get("status"), page, limit)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, adjustments)
+}
+
+func (h *AdminWalletHandler) ApproveAdjustment(w http.ResponseWriter, r *http.Request) {
+	h.reviewAdjustment(w, r, h.adminWalletUseCase.ApproveAdjustment)
+}
+
+func (h *AdminWalletHandler) RejectAdjustment(w http.ResponseWriter, r *http.Request) {
+	h.reviewAdjustment(w, r, h.adminWalletUseCase.RejectAdjustment)
+}
+
+func (h *AdminWalletHandler) reviewAdjustment(
+	w http.ResponseWriter,
+	r *http.Request,
+	review func(adminID int64, adjustmentID int64, note string, client domain.ClientInfo) (*domain.WalletAdjustment, error),
+) {
+	id, ok := adjustmentID(w, r)
+	if !ok {
+		return
+	}
+
+	// The note is optional when approving, so an empty body is accepted
+	var req ReviewAdjustmentRequest
+	if r.ContentLength != 0 {
+		if err := decodeRequest(w, r, &req); err != nil {
+			problem.Write(w, r, err)
+			return
+		}
+	}
+
+	adminID := r.Context().Value("user_id").(int64)
+
+	adjustment, err := review(adminID, id, req.Note, middleware.ClientInfo(r))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, adjustment)
+}
+
+func adminWalletID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	walletID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid wallet ID"))
+		return 0, false
+	}
+	return walletID, true
+}
+
+func adjustmentID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid adjustment ID"))
+		return 0, false
+	}
+	return id, true
+}
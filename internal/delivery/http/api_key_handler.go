@@ -0,0 +1,109 @@
+// internal/delivery/http/api_key_handler.go
+package http
+
+import (
+	"GonPay_Backend/internal/delivery/middleware"
+	"GonPay_Backend/internal/delivery/problem"
+	"GonPay_Backend/internal/domain"
+	"GonPay_Backend/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type APIKeyHandler struct {
+	apiKeyUseCase  *usecase.APIKeyUseCase
+	signingUseCase *usecase.RequestSigningUseCase
+}
+
+type CreateAPIKeyRequest struct {
+	Name          string            `json:"name" validate:"required,max=100"`
+	Scopes        []domain.APIScope `json:"scopes" validate:"required"`
+	ExpiresInDays int               `json:"expires_in_days" validate:"min=0"`
+}
+
+func NewAPIKeyHandler(apiKeyUseCase *usecase.APIKeyUseCase, signingUseCase *usecase.RequestSigningUseCase) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyUseCase:  apiKeyUseCase,
+		signingUseCase: signingUseCase,
+	}
+}
+
+func (h *APIKeyHandler) CreateKey(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+
+	var req CreateAPIKeyRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	key, err := h.apiKeyUseCase.CreateKey(userID, req.Name, req.Scopes, req.ExpiresInDays, middleware.ClientInfo(r))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, key)
+}
+
+func (h *APIKeyHandler) GetKeys(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+
+	keys, err := h.apiKeyUseCase.GetKeys(userID)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, keys)
+}
+
+func (h *APIKeyHandler) RevokeKey(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+	vars := mux.Vars(r)
+	keyID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid API key ID"))
+		return
+	}
+
+	if err := h.apiKeyUseCase.RevokeKey(userID, keyID, middleware.ClientInfo(r)); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithMessage(w, r, http.StatusOK, "message.api_key_revoked")
+}
+
+func (h *APIKeyHandler) AdminRevokeKey(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value("user_id").(int64)
+	vars := mux.Vars(r)
+	keyID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid API key ID"))
+		return
+	}
+
+	if err := h.apiKeyUseCase.AdminRevokeKey(adminID, keyID, middleware.ClientInfo(r)); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithMessage(w, r, http.StatusOK, "message.api_key_revoked")
+}
+
+// RotateSigningSecret creates the secret the merchant signs API key requests
+// with. The old secret stops working immediately.
+func (h *APIKeyHandler) RotateSigningSecret(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+
+	secret, err := h.signingUseCase.RotateSecret(userID, middleware.ClientInfo(r))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]string{"signing_secret": secret})
+}
@@ -2,6 +2,7 @@
 package http
 
 import (
+	"GonPay_Backend/internal/delivery/problem"
 	"GonPay_Backend/internal/domain"
 	"GonPay_Backend/internal/usecase"
 	"net/http"
@@ -27,7 +28,7 @@ func (h *AuditHandler) GetUserAuditLogs(w http.ResponseWriter, r *http.Request)
 
 	logs, err := h.auditUseCase.GetUserAuditLogs(userID, page, limit)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -38,7 +39,7 @@ func (h *AuditHandler) GetUserAuditLogs(w http.ResponseWriter, r *http.Request)
 func (h *AuditHandler) GetActionLogs(w http.ResponseWriter, r *http.Request) {
 	action := domain.AuditAction(r.URL.Query().Get("action"))
 	if action == "" {
-		respondWithError(w, http.StatusBadRequest, "action parameter is required")
+		problem.Write(w, r, invalidRequest("action parameter is required"))
 		return
 	}
 
@@ -46,7 +47,7 @@ func (h *AuditHandler) GetActionLogs(w http.ResponseWriter, r *http.Request) {
 
 	logs, err := h.auditUseCase.GetActionLogs(action, page, limit)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -57,7 +58,7 @@ func (h *AuditHandler) GetActionLogs(w http.ResponseWriter, r *http.Request) {
 func (h *AuditHandler) GetDateRangeLogs(w http.ResponseWriter, r *http.Request) {
 	startDate, endDate, err := getDateRangeParams(r)
 	if err != nil {
-		respondWithError(w, http.StatusBadRequest, err.Error())
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -65,7 +66,7 @@ func (h *AuditHandler) GetDateRangeLogs(w http.ResponseWriter, r *http.Request)
 
 	logs, err := h.auditUseCase.GetDateRangeLogs(startDate, endDate, page, limit)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -76,19 +77,19 @@ func (h *AuditHandler) GetDateRangeLogs(w http.ResponseWriter, r *http.Request)
 func (h *AuditHandler) GetEntityLogs(w http.ResponseWriter, r *http.Request) {
 	entityType := r.URL.Query().Get("entity_type")
 	if entityType == "" {
-		respondWithError(w, http.StatusBadRequest, "entity_type parameter is required")
+		problem.Write(w, r, invalidRequest("entity_type parameter is required"))
 		return
 	}
 
 	entityID, err := strconv.ParseInt(r.URL.Query().Get("entity_id"), 10, 64)
 	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "invalid entity_id")
+		problem.Write(w, r, invalidRequest("invalid entity_id"))
 		return
 	}
 
 	logs, err := h.auditUseCase.GetEntityLogs(entityType, entityID)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -118,7 +119,7 @@ func getDateRangeParams(r *http.Request) (startDate, endDate time.Time, err erro
 	} else {
 		startDate, err = time.Parse(time.RFC3339, startDateStr)
 		if err != nil {
-			return time.Time{}, time.Time{}, err
+			return time.Time{}, time.Time{}, invalidRequest("start_date must be an RFC 3339 timestamp")
 		}
 	}
 
@@ -128,7 +129,7 @@ func getDateRangeParams(r *http.Request) (startDate, endDate time.Time, err erro
 	} else {
 		endDate, err = time.Parse(time.RFC3339, endDateStr)
 		if err != nil {
-			return time.Time{}, time.Time{}, err
+			return time.Time{}, time.Time{}, invalidRequest("end_date must be an RFC 3339 timestamp")
 		}
 	}
 
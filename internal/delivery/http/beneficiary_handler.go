@@ -2,9 +2,9 @@
 package http
 
 import (
+	"GonPay_Backend/internal/delivery/problem"
 	"GonPay_Backend/internal/domain"
 	"GonPay_Backend/internal/usecase"
-	"encoding/json"
 	"github.com/gorilla/mux"
 	"net/http"
 	"strconv"
@@ -15,17 +15,21 @@ type BeneficiaryHandler struct {
 }
 
 type CreateBeneficiaryRequest struct {
-	Name              string             `json:"name" validate:"required"`
-	AccountIdentifier string             `json:"account_identifier" validate:"required"`
-	AccountType       domain.AccountType `json:"account_type" validate:"required"`
-	BankName          string             `json:"bank_name"`
+	Name              string             `json:"name" validate:"required,max=100"`
+	AccountIdentifier string             `json:"account_identifier" validate:"required,max=50"`
+	AccountType       domain.AccountType `json:"account_type" validate:"required,oneof=WALLET BANK_ACCOUNT"`
+	BankName          string             `json:"bank_name" validate:"max=100"`
 }
 
 type UpdateBeneficiaryRequest struct {
-	Name              string             `json:"name" validate:"required"`
-	AccountIdentifier string             `json:"account_identifier" validate:"required"`
-	AccountType       domain.AccountType `json:"account_type" validate:"required"`
-	BankName          string             `json:"bank_name"`
+	Name              string             `json:"name" validate:"required,max=100"`
+	AccountIdentifier string             `json:"account_identifier" validate:"required,max=50"`
+	AccountType       domain.AccountType `json:"account_type" validate:"required,oneof=WALLET BANK_ACCOUNT"`
+	BankName          string             `json:"bank_name" validate:"max=100"`
+}
+
+type LiftCoolingOffRequest struct {
+	StepUpRequest
 }
 
 func NewBeneficiaryHandler(beneficiaryUseCase *usecase.BeneficiaryUseCase) *BeneficiaryHandler {
@@ -36,8 +40,8 @@ func NewBeneficiaryHandler(beneficiaryUseCase *usecase.BeneficiaryUseCase) *Bene
 
 func (h *BeneficiaryHandler) CreateBeneficiary(w http.ResponseWriter, r *http.Request) {
 	var req CreateBeneficiaryRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -51,7 +55,7 @@ func (h *BeneficiaryHandler) CreateBeneficiary(w http.ResponseWriter, r *http.Re
 		req.BankName,
 	)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -62,13 +66,13 @@ func (h *BeneficiaryHandler) UpdateBeneficiary(w http.ResponseWriter, r *http.Re
 	vars := mux.Vars(r)
 	id, err := strconv.ParseInt(vars["id"], 10, 64)
 	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid beneficiary ID")
+		problem.Write(w, r, invalidRequest("Invalid beneficiary ID"))
 		return
 	}
 
 	var req UpdateBeneficiaryRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -85,9 +89,9 @@ func (h *BeneficiaryHandler) UpdateBeneficiary(w http.ResponseWriter, r *http.Re
 	if err != nil {
 		switch err {
 		case domain.ErrInvalidOperation:
-			respondWithError(w, http.StatusForbidden, "Cannot modify this beneficiary")
+			problem.Write(w, r, domain.NewAppError(domain.CodeForbidden, "Cannot modify this beneficiary"))
 		default:
-			respondWithError(w, http.StatusInternalServerError, err.Error())
+			problem.Write(w, r, err)
 		}
 		return
 	}
@@ -99,7 +103,7 @@ func (h *BeneficiaryHandler) DeleteBeneficiary(w http.ResponseWriter, r *http.Re
 	vars := mux.Vars(r)
 	id, err := strconv.ParseInt(vars["id"], 10, 64)
 	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid beneficiary ID")
+		problem.Write(w, r, invalidRequest("Invalid beneficiary ID"))
 		return
 	}
 
@@ -108,21 +112,21 @@ func (h *BeneficiaryHandler) DeleteBeneficiary(w http.ResponseWriter, r *http.Re
 	if err := h.beneficiaryUseCase.DeleteBeneficiary(id, userID); err != nil {
 		switch err {
 		case domain.ErrInvalidOperation:
-			respondWithError(w, http.StatusForbidden, "Cannot delete this beneficiary")
+			problem.Write(w, r, domain.NewAppError(domain.CodeForbidden, "Cannot delete this beneficiary"))
 		default:
-			respondWithError(w, http.StatusInternalServerError, err.Error())
+			problem.Write(w, r, err)
 		}
 		return
 	}
 
-	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Beneficiary deleted successfully"})
+	respondWithMessage(w, r, http.StatusOK, "message.beneficiary_deleted")
 }
  
 func (h *BeneficiaryHandler) GetBeneficiary(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.ParseInt(vars["id"], 10, 64)
 	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid beneficiary ID")
+		problem.Write(w, r, invalidRequest("Invalid beneficiary ID"))
 		return
 	}
 
@@ -132,9 +136,9 @@ func (h *BeneficiaryHandler) GetBeneficiary(w http.ResponseWriter, r *http.Reque
 	if err != nil {
 		switch err {
 		case domain.ErrInvalidOperation:
-			respondWithError(w, http.StatusNotFound, "Beneficiary not found")
+			problem.Write(w, r, domain.NewAppError(domain.CodeNotFound, "Beneficiary not found"))
 		default:
-			respondWithError(w, http.StatusInternalServerError, err.Error())
+			problem.Write(w, r, err)
 		}
 		return
 	}
@@ -147,9 +151,40 @@ func (h *BeneficiaryHandler) GetUserBeneficiaries(w http.ResponseWriter, r *http
 
 	beneficiaries, err := h.beneficiaryUseCase.GetUserBeneficiaries(userID)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		problem.Write(w, r, err)
 		return
 	}
 
 	respondWithJSON(w, http.StatusOK, beneficiaries)
 }
+
+// LiftCoolingOff removes the cooling-off transfer cap after the user confirms with their PIN or a TOTP code
+func (h *BeneficiaryHandler) LiftCoolingOff(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid beneficiary ID"))
+		return
+	}
+
+	var req LiftCoolingOffRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	userID := r.Context().Value("user_id").(int64)
+
+	beneficiary, err := h.beneficiaryUseCase.LiftCoolingOff(id, userID, req.credentials())
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidOperation:
+			problem.Write(w, r, domain.NewAppError(domain.CodeNotFound, "Beneficiary not found"))
+		default:
+			problem.Write(w, r, err)
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, beneficiary)
+}
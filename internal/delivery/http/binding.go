@@ -0,0 +1,112 @@
+// internal/delivery/http/binding.go
+package http
+
+import (
+	"GonPay_Backend/internal/domain"
+	"GonPay_Backend/pkg/validator"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// maxRequestBodyBytes caps JSON request bodies. The largest request is a
+// few hundred bytes.
+const maxRequestBodyBytes = 64 << 10
+
+var requestValidator = validator.NewValidator()
+
+// decodeRequest decodes the JSON body of r into dst and checks dst's
+// validate tags. Unknown fields, trailing data and bodies larger than
+// maxRequestBodyBytes are refused. The error is an AppError ready for
+// problem.Write; failed fields are listed with their JSON names.
+func decodeRequest(w http.ResponseWriter, r *http.Request, dst interface{}) error {
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodyBytes)
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		return decodeError(err)
+	}
+	if err := decoder.Decode(&struct{}{}); err != io.EOF {
+		return invalidRequest("Request body must contain a single JSON object")
+	}
+
+	return validateRequest(dst)
+}
+
+// validateRequest checks the validate tags of a decoded request.
+func validateRequest(req interface{}) error {
+	err := requestValidator.ValidateStruct(req)
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err
+	}
+
+	fields := make([]domain.FieldError, len(validationErrs))
+	for i, fieldErr := range validationErrs {
+		fields[i] = domain.FieldError{
+			Field:   fieldErr.Field,
+			Rule:    fieldErr.Rule,
+			Message: fieldErr.Message,
+			Param:   fieldErr.Param,
+			Unit:    fieldErr.Unit,
+		}
+	}
+	return domain.NewValidationError(fields)
+}
+
+func decodeError(err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.Is(err, io.EOF):
+		return invalidRequest("Request body must not be empty")
+	case errors.As(err, &maxBytesErr):
+		return domain.NewAppError(domain.CodePayloadTooLarge, fmt.Sprintf("Request body must not exceed %d bytes", maxBytesErr.Limit))
+	case errors.As(err, &syntaxErr):
+		return invalidRequest(fmt.Sprintf("Malformed JSON at position %d", syntaxErr.Offset))
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		kind := jsonKind(typeErr.Type.Kind())
+		return domain.NewValidationError([]domain.FieldError{{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Message: "must be of type " + kind,
+			Param:   kind,
+		}})
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json has no error type for unknown fields
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return domain.NewValidationError([]domain.FieldError{{
+			Field:   field,
+			Rule:    "unknown",
+			Message: "is not a known field",
+		}})
+	default:
+		return errInvalidPayload
+	}
+}
+
+// jsonKind names the JSON type a Go kind is decoded from.
+func jsonKind(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
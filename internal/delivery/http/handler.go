@@ -2,14 +2,12 @@
 package http
 
 import (
+	"GonPay_Backend/internal/domain"
+	"GonPay_Backend/internal/i18n"
 	"encoding/json"
 	"net/http"
 )
 
-type ResponseError struct {
-	Message string `json:"message"`
-}
-
 type Handler struct {
 	UserHandler        *UserHandler
 	WalletHandler      *WalletHandler
@@ -24,8 +22,21 @@ func NewHandler(userHandler *UserHandler, walletHandler *WalletHandler, transact
 	}
 }
 
-func respondWithError(w http.ResponseWriter, code int, message string) {
-	respondWithJSON(w, code, ResponseError{Message: message})
+// errInvalidPayload is returned when the request body cannot be decoded.
+var errInvalidPayload = domain.NewAppError(domain.CodeInvalidRequest, "Invalid request payload")
+
+// invalidRequest reports a malformed request, such as a bad path parameter.
+// Errors are written with problem.Write.
+func invalidRequest(message string) error {
+	return domain.NewAppError(domain.CodeInvalidRequest, message)
+}
+
+// respondWithMessage responds with a confirmation message from the i18n
+// catalog, in the language chosen for the request.
+func respondWithMessage(w http.ResponseWriter, r *http.Request, code int, key string) {
+	lang := i18n.FromContext(r.Context())
+	w.Header().Set("Content-Language", lang)
+	respondWithJSON(w, code, map[string]string{"message": i18n.T(lang, key)})
 }
 
 func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
@@ -0,0 +1,67 @@
+// internal/delivery/http/impersonation_handler.go
+package http
+
+import (
+	"GonPay_Backend/internal/delivery/middleware"
+	"GonPay_Backend/internal/delivery/problem"
+	"GonPay_Backend/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type ImpersonationHandler struct {
+	impersonationUseCase *usecase.ImpersonationUseCase
+}
+
+type ImpersonateRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+func NewImpersonationHandler(impersonationUseCase *usecase.ImpersonationUseCase) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		impersonationUseCase: impersonationUseCase,
+	}
+}
+
+// Start returns a read-only access token for the user in the path.
+func (h *ImpersonationHandler) Start(w http.ResponseWriter, r *http.Request) {
+	userID, ok := adminUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req ImpersonateRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	adminID := r.Context().Value("user_id").(int64)
+
+	response, err := h.impersonationUseCase.Start(adminID, userID, req.Reason, middleware.ClientInfo(r))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, response)
+}
+
+func (h *ImpersonationHandler) End(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid session ID"))
+		return
+	}
+
+	adminID := r.Context().Value("user_id").(int64)
+
+	if err := h.impersonationUseCase.End(adminID, sessionID, middleware.ClientInfo(r)); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithMessage(w, r, http.StatusOK, "message.impersonation_ended")
+}
@@ -0,0 +1,32 @@
+// internal/delivery/http/jwks_handler.go
+package http
+
+import (
+	"GonPay_Backend/internal/auth"
+	"GonPay_Backend/internal/delivery/problem"
+	"net/http"
+)
+
+type JWKSHandler struct {
+	tokens *auth.TokenManager
+}
+
+func NewJWKSHandler(tokens *auth.TokenManager) *JWKSHandler {
+	return &JWKSHandler{
+		tokens: tokens,
+	}
+}
+
+// GetJWKS publishes the public keys that verify GonPay tokens so other
+// services can check them without being able to mint them.
+func (h *JWKSHandler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	jwks, err := h.tokens.JWKS()
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	// Verifiers re-fetch at least as often as the API itself reloads keys
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	respondWithJSON(w, http.StatusOK, jwks)
+}
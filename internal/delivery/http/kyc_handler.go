@@ -0,0 +1,209 @@
+// internal/delivery/http/kyc_handler.go
+package http
+
+import (
+	"GonPay_Backend/internal/delivery/middleware"
+	"GonPay_Backend/internal/delivery/problem"
+	"GonPay_Backend/internal/domain"
+	"GonPay_Backend/internal/usecase"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// kycFormFields maps the multipart file fields of a submission to document
+// types.
+var kycFormFields = map[string]domain.KYCDocumentType{
+	"id_front": domain.KYCDocumentIDFront,
+	"id_back":  domain.KYCDocumentIDBack,
+	"selfie":   domain.KYCDocumentSelfie,
+}
+
+type KYCHandler struct {
+	kycUseCase *usecase.KYCUseCase
+}
+
+type RejectKYCRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+func NewKYCHandler(kycUseCase *usecase.KYCUseCase) *KYCHandler {
+	return &KYCHandler{
+		kycUseCase: kycUseCase,
+	}
+}
+
+func (h *KYCHandler) GetState(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+
+	state, err := h.kycUseCase.GetState(userID)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, state)
+}
+
+// Submit takes a multipart/form-data request with a "tier" field and the
+// id_front, id_back and selfie files the tier needs.
+func (h *KYCHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+
+	maxSize := h.kycUseCase.MaxDocumentSize()
+	r.Body = http.MaxBytesReader(w, r.Body, int64(len(kycFormFields))*maxSize+1<<20)
+	if err := r.ParseMultipartForm(maxSize); err != nil {
+		problem.Write(w, r, invalidRequest("Invalid multipart form or files too large"))
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	tier, err := strconv.Atoi(r.FormValue("tier"))
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid tier"))
+		return
+	}
+
+	var uploads []domain.KYCUpload
+	for field, docType := range kycFormFields {
+		file, _, err := r.FormFile(field)
+		if err == http.ErrMissingFile {
+			continue
+		}
+		if err != nil {
+			problem.Write(w, r, invalidRequest("Invalid file "+field))
+			return
+		}
+
+		content, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+		file.Close()
+		if err != nil {
+			problem.Write(w, r, invalidRequest("Invalid file "+field))
+			return
+		}
+
+		uploads = append(uploads, domain.KYCUpload{Type: docType, Content: content})
+	}
+
+	submission, err := h.kycUseCase.Submit(userID, tier, uploads, middleware.ClientInfo(r))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, submission)
+}
+
+// GetQueue lists submissions by status, PENDING by default (Compliance)
+func (h *KYCHandler) GetQueue(w http.ResponseWriter, r *http.Request) {
+	status := domain.KYCStatus(r.URL.Query().Get("status"))
+	switch status {
+	case "":
+		status = domain.KYCStatusPending
+	case domain.KYCStatusPending, domain.KYCStatusVerified, domain.KYCStatusRejected:
+	default:
+		problem.Write(w, r, invalidRequest("Invalid status"))
+		return
+	}
+
+	page, limit := getPaginationParams(r)
+
+	submissions, err := h.kycUseCase.GetQueue(status, page, limit)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, submissions)
+}
+
+func (h *KYCHandler) GetSubmission(w http.ResponseWriter, r *http.Request) {
+	submissionID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid submission ID"))
+		return
+	}
+
+	submission, err := h.kycUseCase.GetSubmission(submissionID)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, submission)
+}
+
+// GetDocument streams an uploaded document to the reviewer.
+func (h *KYCHandler) GetDocument(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	submissionID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid submission ID"))
+		return
+	}
+	documentID, err := strconv.ParseInt(vars["documentId"], 10, 64)
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid document ID"))
+		return
+	}
+
+	doc, content, err := h.kycUseCase.OpenDocument(submissionID, documentID)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", doc.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(doc.Size, 10))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", fmt.Sprintf("%d-%s", doc.ID, doc.Type)))
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, content)
+}
+
+func (h *KYCHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	submissionID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid submission ID"))
+		return
+	}
+
+	reviewerID := r.Context().Value("user_id").(int64)
+
+	submission, err := h.kycUseCase.Approve(reviewerID, submissionID, middleware.ClientInfo(r))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, submission)
+}
+
+func (h *KYCHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	submissionID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid submission ID"))
+		return
+	}
+
+	var req RejectKYCRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	reviewerID := r.Context().Value("user_id").(int64)
+
+	submission, err := h.kycUseCase.Reject(reviewerID, submissionID, req.Reason, middleware.ClientInfo(r))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, submission)
+}
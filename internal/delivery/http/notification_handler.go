@@ -2,6 +2,7 @@
 package http
 
 import (
+	"GonPay_Backend/internal/delivery/problem"
 	"GonPay_Backend/internal/domain"
 	"GonPay_Backend/internal/usecase"
 	"net/http"
@@ -14,6 +15,11 @@ type NotificationHandler struct {
 	notificationUseCase *usecase.NotificationUseCase
 }
 
+type RegisterDeviceRequest struct {
+	Platform domain.DevicePlatform `json:"platform" validate:"required,oneof=ANDROID IOS"`
+	Token    string                `json:"token" validate:"required,max=4096"`
+}
+
 func NewNotificationHandler(notificationUseCase *usecase.NotificationUseCase) *NotificationHandler {
 	return &NotificationHandler{
 		notificationUseCase: notificationUseCase,
@@ -35,19 +41,44 @@ func (h *NotificationHandler) GetNotifications(w http.ResponseWriter, r *http.Re
 
 	notifications, err := h.notificationUseCase.GetUserNotifications(userID, page, limit)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		problem.Write(w, r, err)
 		return
 	}
 
 	respondWithJSON(w, http.StatusOK, notifications)
 }
 
+// GetNotification returns one notification with its delivery status on
+// each channel.
+func (h *NotificationHandler) GetNotification(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid notification ID"))
+		return
+	}
+
+	userID := r.Context().Value("user_id").(int64)
+
+	notification, err := h.notificationUseCase.GetNotification(id, userID)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidOperation:
+			problem.Write(w, r, domain.NewAppError(domain.CodeNotFound, "Notification not found"))
+		default:
+			problem.Write(w, r, err)
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, notification)
+}
+
 func (h *NotificationHandler) GetUnreadCount(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("user_id").(int64)
 
 	count, err := h.notificationUseCase.GetUnreadCount(userID)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -58,7 +89,7 @@ func (h *NotificationHandler) MarkAsRead(w http.ResponseWriter, r *http.Request)
 	vars := mux.Vars(r)
 	id, err := strconv.ParseInt(vars["id"], 10, 64)
 	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid notification ID")
+		problem.Write(w, r, invalidRequest("Invalid notification ID"))
 		return
 	}
 
@@ -67,23 +98,72 @@ func (h *NotificationHandler) MarkAsRead(w http.ResponseWriter, r *http.Request)
 	if err := h.notificationUseCase.MarkAsRead(id, userID); err != nil {
 		switch err {
 		case domain.ErrInvalidOperation:
-			respondWithError(w, http.StatusForbidden, "Cannot modify this notification")
+			problem.Write(w, r, domain.NewAppError(domain.CodeForbidden, "Cannot modify this notification"))
 		default:
-			respondWithError(w, http.StatusInternalServerError, err.Error())
+			problem.Write(w, r, err)
 		}
 		return
 	}
 
-	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Notification marked as read"})
+	respondWithMessage(w, r, http.StatusOK, "message.notification_read")
 }
 
 func (h *NotificationHandler) MarkAllAsRead(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("user_id").(int64)
 
 	if err := h.notificationUseCase.MarkAllAsRead(userID); err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithMessage(w, r, http.StatusOK, "message.notifications_read")
+}
+
+// RegisterDevice stores the push token of the calling app install. Apps
+// call it at every start, as the push service may issue a new token.
+func (h *NotificationHandler) RegisterDevice(w http.ResponseWriter, r *http.Request) {
+	var req RegisterDeviceRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	userID := r.Context().Value("user_id").(int64)
+
+	device, err := h.notificationUseCase.RegisterDevice(userID, req.Platform, req.Token)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, device)
+}
+
+func (h *NotificationHandler) GetDevices(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+
+	devices, err := h.notificationUseCase.GetDevices(userID)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, devices)
+}
+
+func (h *NotificationHandler) DeleteDevice(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid device ID"))
+		return
+	}
+
+	userID := r.Context().Value("user_id").(int64)
+
+	if err := h.notificationUseCase.DeleteDevice(id, userID); err != nil {
+		problem.Write(w, r, err)
 		return
 	}
 
-	respondWithJSON(w, http.StatusOK, map[string]string{"message": "All notifications marked as read"})
+	respondWithMessage(w, r, http.StatusOK, "message.device_removed")
 }
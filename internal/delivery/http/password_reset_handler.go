@@ -0,0 +1,58 @@
+// internal/delivery/http/password_reset_handler.go
+package http
+
+import (
+	"GonPay_Backend/internal/delivery/middleware"
+	"GonPay_Backend/internal/delivery/problem"
+	"GonPay_Backend/internal/usecase"
+	"net/http"
+)
+
+type PasswordResetHandler struct {
+	passwordResetUseCase *usecase.PasswordResetUseCase
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=6"`
+}
+
+func NewPasswordResetHandler(passwordResetUseCase *usecase.PasswordResetUseCase) *PasswordResetHandler {
+	return &PasswordResetHandler{
+		passwordResetUseCase: passwordResetUseCase,
+	}
+}
+
+func (h *PasswordResetHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req ForgotPasswordRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	if err := h.passwordResetUseCase.RequestReset(req.Email, middleware.ClientInfo(r)); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithMessage(w, r, http.StatusOK, "message.password_reset_sent")
+}
+
+func (h *PasswordResetHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req ResetPasswordRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	if err := h.passwordResetUseCase.ResetPassword(req.Token, req.NewPassword, middleware.ClientInfo(r)); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithMessage(w, r, http.StatusOK, "message.password_reset")
+}
@@ -2,9 +2,9 @@
 package http
 
 import (
+	"GonPay_Backend/internal/delivery/problem"
 	"GonPay_Backend/internal/domain"
 	"GonPay_Backend/internal/usecase"
-	"encoding/json"
 	"github.com/gorilla/mux"
 	"net/http"
 	"strconv"
@@ -15,16 +15,16 @@ type PaymentMethodHandler struct {
 }
 
 type CreatePaymentMethodRequest struct {
-	MethodType    domain.PaymentMethodType `json:"method_type" validate:"required"`
-	AccountNumber string                   `json:"account_number" validate:"required"`
-	BankName      string                   `json:"bank_name"`
+	MethodType    domain.PaymentMethodType `json:"method_type" validate:"required,oneof=CREDIT_CARD DEBIT_CARD E_WALLET BANK_ACCOUNT"`
+	AccountNumber string                   `json:"account_number" validate:"required,max=50"`
+	BankName      string                   `json:"bank_name" validate:"max=100"`
 	IsDefault     bool                     `json:"is_default"`
 }
 
 type UpdatePaymentMethodRequest struct {
-	MethodType    domain.PaymentMethodType `json:"method_type" validate:"required"`
-	AccountNumber string                   `json:"account_number" validate:"required"`
-	BankName      string                   `json:"bank_name"`
+	MethodType    domain.PaymentMethodType `json:"method_type" validate:"required,oneof=CREDIT_CARD DEBIT_CARD E_WALLET BANK_ACCOUNT"`
+	AccountNumber string                   `json:"account_number" validate:"required,max=50"`
+	BankName      string                   `json:"bank_name" validate:"max=100"`
 	IsDefault     bool                     `json:"is_default"`
 }
 
@@ -36,8 +36,8 @@ func NewPaymentMethodHandler(paymentMethodUseCase *usecase.PaymentMethodUseCase)
 
 func (h *PaymentMethodHandler) CreatePaymentMethod(w http.ResponseWriter, r *http.Request) {
 	var req CreatePaymentMethodRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -51,7 +51,7 @@ func (h *PaymentMethodHandler) CreatePaymentMethod(w http.ResponseWriter, r *htt
 		req.IsDefault,
 	)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -64,13 +64,13 @@ func (h *PaymentMethodHandler) UpdatePaymentMethod(w http.ResponseWriter, r *htt
 	vars := mux.Vars(r)
 	id, err := strconv.ParseInt(vars["id"], 10, 64)
 	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid payment method ID")
+		problem.Write(w, r, invalidRequest("Invalid payment method ID"))
 		return
 	}
 
 	var req UpdatePaymentMethodRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -87,9 +87,9 @@ func (h *PaymentMethodHandler) UpdatePaymentMethod(w http.ResponseWriter, r *htt
 	if err != nil {
 		switch err {
 		case domain.ErrInvalidOperation:
-			respondWithError(w, http.StatusForbidden, "Cannot modify this payment method")
+			problem.Write(w, r, domain.NewAppError(domain.CodeForbidden, "Cannot modify this payment method"))
 		default:
-			respondWithError(w, http.StatusInternalServerError, err.Error())
+			problem.Write(w, r, err)
 		}
 		return
 	}
@@ -101,7 +101,7 @@ func (h *PaymentMethodHandler) DeletePaymentMethod(w http.ResponseWriter, r *htt
 	vars := mux.Vars(r)
 	id, err := strconv.ParseInt(vars["id"], 10, 64)
 	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid payment method ID")
+		problem.Write(w, r, invalidRequest("Invalid payment method ID"))
 		return
 	}
 
@@ -110,21 +110,21 @@ func (h *PaymentMethodHandler) DeletePaymentMethod(w http.ResponseWriter, r *htt
 	if err := h.paymentMethodUseCase.DeletePaymentMethod(id, userID); err != nil {
 		switch err {
 		case domain.ErrInvalidOperation:
-			respondWithError(w, http.StatusForbidden, "Cannot delete this payment method")
+			problem.Write(w, r, domain.NewAppError(domain.CodeForbidden, "Cannot delete this payment method"))
 		default:
-			respondWithError(w, http.StatusInternalServerError, err.Error())
+			problem.Write(w, r, err)
 		}
 		return
 	}
 
-	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Payment method deleted successfully"})
+	respondWithMessage(w, r, http.StatusOK, "message.payment_deleted")
 }
 
 func (h *PaymentMethodHandler) GetPaymentMethod(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.ParseInt(vars["id"], 10, 64)
 	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid payment method ID")
+		problem.Write(w, r, invalidRequest("Invalid payment method ID"))
 		return
 	}
 
@@ -134,9 +134,9 @@ func (h *PaymentMethodHandler) GetPaymentMethod(w http.ResponseWriter, r *http.R
 	if err != nil {
 		switch err {
 		case domain.ErrInvalidOperation:
-			respondWithError(w, http.StatusNotFound, "Payment method not found")
+			problem.Write(w, r, domain.NewAppError(domain.CodeNotFound, "Payment method not found"))
 		default:
-			respondWithError(w, http.StatusInternalServerError, err.Error())
+			problem.Write(w, r, err)
 		}
 		return
 	}
@@ -149,7 +149,7 @@ func (h *PaymentMethodHandler) GetUserPaymentMethods(w http.ResponseWriter, r *h
 
 	paymentMethods, err := h.paymentMethodUseCase.GetUserPaymentMethods(userID)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -160,7 +160,7 @@ func (h *PaymentMethodHandler) SetDefaultPaymentMethod(w http.ResponseWriter, r
 	vars := mux.Vars(r)
 	id, err := strconv.ParseInt(vars["id"], 10, 64)
 	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid payment method ID")
+		problem.Write(w, r, invalidRequest("Invalid payment method ID"))
 		return
 	}
 
@@ -169,12 +169,12 @@ func (h *PaymentMethodHandler) SetDefaultPaymentMethod(w http.ResponseWriter, r
 	if err := h.paymentMethodUseCase.SetDefaultPaymentMethod(id, userID); err != nil {
 		switch err {
 		case domain.ErrInvalidOperation:
-			respondWithError(w, http.StatusForbidden, "Cannot set this payment method as default")
+			problem.Write(w, r, domain.NewAppError(domain.CodeForbidden, "Cannot set this payment method as default"))
 		default:
-			respondWithError(w, http.StatusInternalServerError, err.Error())
+			problem.Write(w, r, err)
 		}
 		return
 	}
 
-	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Payment method set as default successfully"})
+	respondWithMessage(w, r, http.StatusOK, "message.payment_default")
 }
@@ -0,0 +1,69 @@
+// internal/delivery/http/preferences_handler.go
+package http
+
+import (
+	"GonPay_Backend/internal/delivery/middleware"
+	"GonPay_Backend/internal/delivery/problem"
+	"GonPay_Backend/internal/domain"
+	"GonPay_Backend/internal/usecase"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// maxPreferencesPatchBytes is far more than any valid patch needs
+const maxPreferencesPatchBytes = 16 << 10
+
+type PreferencesHandler struct {
+	preferencesUseCase *usecase.PreferencesUseCase
+}
+
+func NewPreferencesHandler(preferencesUseCase *usecase.PreferencesUseCase) *PreferencesHandler {
+	return &PreferencesHandler{
+		preferencesUseCase: preferencesUseCase,
+	}
+}
+
+func (h *PreferencesHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+
+	preferences, err := h.preferencesUseCase.GetPreferences(userID)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, preferences)
+}
+
+// UpdatePreferences takes a JSON Merge Patch. Both
+// application/merge-patch+json and application/json are accepted.
+func (h *PreferencesHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || (mediaType != "application/merge-patch+json" && mediaType != "application/json") {
+			problem.Write(w, r, domain.NewAppError(domain.CodeUnsupportedMediaType, "Content-Type must be application/merge-patch+json"))
+			return
+		}
+	}
+
+	patch, err := io.ReadAll(io.LimitReader(r.Body, maxPreferencesPatchBytes+1))
+	if err != nil {
+		problem.Write(w, r, errInvalidPayload)
+		return
+	}
+	if len(patch) > maxPreferencesPatchBytes {
+		problem.Write(w, r, domain.NewAppError(domain.CodePayloadTooLarge, "Patch is too large"))
+		return
+	}
+
+	userID := r.Context().Value("user_id").(int64)
+
+	preferences, err := h.preferencesUseCase.UpdatePreferences(userID, patch, middleware.ClientInfo(r))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, preferences)
+}
@@ -0,0 +1,107 @@
+// internal/delivery/http/privacy_handler.go
+package http
+
+import (
+	"GonPay_Backend/internal/delivery/middleware"
+	"GonPay_Backend/internal/delivery/problem"
+	"GonPay_Backend/internal/domain"
+	"GonPay_Backend/internal/usecase"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type PrivacyHandler struct {
+	privacyUseCase *usecase.PrivacyUseCase
+}
+
+type CloseAccountRequest struct {
+	Password string `json:"password" validate:"required"`
+}
+
+func NewPrivacyHandler(privacyUseCase *usecase.PrivacyUseCase) *PrivacyHandler {
+	return &PrivacyHandler{
+		privacyUseCase: privacyUseCase,
+	}
+}
+
+// RequestExport starts preparing a copy of the user's data. Poll GetExport
+// or wait for the notification, then download it.
+func (h *PrivacyHandler) RequestExport(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+
+	export, err := h.privacyUseCase.RequestExport(userID, middleware.ClientInfo(r))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusAccepted, export)
+}
+
+func (h *PrivacyHandler) GetExport(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+
+	export, err := h.privacyUseCase.GetLatestExport(userID)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+	if export == nil {
+		problem.Write(w, r, domain.ErrDataExportNotFound)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, export)
+}
+
+// DownloadExport streams the ZIP archive. Support staff impersonating the
+// user cannot download it.
+func (h *PrivacyHandler) DownloadExport(w http.ResponseWriter, r *http.Request) {
+	if principal, ok := r.Context().Value("principal").(*domain.Principal); ok && principal.Impersonated() {
+		problem.Write(w, r, domain.NewAppError(domain.CodeForbidden, "Data exports cannot be downloaded while impersonating"))
+		return
+	}
+
+	userID := r.Context().Value("user_id").(int64)
+
+	exportID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid export ID"))
+		return
+	}
+
+	content, export, err := h.privacyUseCase.OpenExport(userID, exportID, middleware.ClientInfo(r))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Length", strconv.FormatInt(export.SizeBytes, 10))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("gonpay-data-%d.zip", export.ID)))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, content)
+}
+
+func (h *PrivacyHandler) CloseAccount(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+
+	var req CloseAccountRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	if err := h.privacyUseCase.CloseAccount(userID, req.Password, middleware.ClientInfo(r)); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithMessage(w, r, http.StatusOK, "message.account_closed")
+}
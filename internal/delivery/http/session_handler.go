@@ -0,0 +1,89 @@
+// internal/delivery/http/session_handler.go
+package http
+
+import (
+	"GonPay_Backend/internal/delivery/middleware"
+	"GonPay_Backend/internal/delivery/problem"
+	"GonPay_Backend/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type SessionHandler struct {
+	sessionUseCase *usecase.SessionUseCase
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+func NewSessionHandler(sessionUseCase *usecase.SessionUseCase) *SessionHandler {
+	return &SessionHandler{
+		sessionUseCase: sessionUseCase,
+	}
+}
+
+func (h *SessionHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req RefreshTokenRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	response, err := h.sessionUseCase.Refresh(req.RefreshToken, middleware.ClientInfo(r))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *SessionHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+	sessionID, ok := r.Context().Value("session_id").(int64)
+	if !ok {
+		problem.Write(w, r, invalidRequest("Token is not bound to a session"))
+		return
+	}
+
+	if err := h.sessionUseCase.Logout(userID, sessionID, middleware.ClientInfo(r)); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithMessage(w, r, http.StatusOK, "message.logged_out")
+}
+
+func (h *SessionHandler) GetSessions(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+	sessionID, _ := r.Context().Value("session_id").(int64)
+
+	sessions, err := h.sessionUseCase.GetSessions(userID, sessionID)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, sessions)
+}
+
+func (h *SessionHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid session ID"))
+		return
+	}
+
+	userID := r.Context().Value("user_id").(int64)
+
+	if err := h.sessionUseCase.RevokeSession(userID, id, middleware.ClientInfo(r)); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithMessage(w, r, http.StatusOK, "message.session_revoked")
+}
@@ -2,6 +2,7 @@
 package http
 
 import (
+	"GonPay_Backend/internal/delivery/problem"
 	"GonPay_Backend/internal/usecase"
 	"net/http"
 	"strconv"
@@ -32,7 +33,7 @@ func (h *TransactionHandler) GetUserTransactions(w http.ResponseWriter, r *http.
 
 	transactions, err := h.transactionUseCase.GetUserTransactions(userID, page, limit)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -2,20 +2,56 @@
 package http
 
 import (
+	"GonPay_Backend/internal/delivery/middleware"
+	"GonPay_Backend/internal/delivery/problem"
 	"GonPay_Backend/internal/domain"
 	"GonPay_Backend/internal/usecase"
-	"encoding/json"
 	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
 )
 
 type TransactionLimitHandler struct {
 	limitUseCase *usecase.TransactionLimitUseCase
 }
 
+type LimitValuesRequest struct {
+	DailyLimit             float64                `json:"daily_limit" validate:"required,gt=0"`
+	MonthlyLimit           float64                `json:"monthly_limit" validate:"required,gt=0"`
+	MaxSingleAmount        float64                `json:"max_single_amount" validate:"min=0"`
+	MinAmount              float64                `json:"min_amount" validate:"min=0"`
+	HourlyCountLimit       int                    `json:"hourly_count_limit" validate:"min=0"`
+	DailyCountLimit        int                    `json:"daily_count_limit" validate:"min=0"`
+	NewRecipientMaxAmount  float64                `json:"new_recipient_max_amount" validate:"min=0"`
+	NewRecipientDailyLimit float64                `json:"new_recipient_daily_limit" validate:"min=0"`
+	WindowMode             domain.LimitWindowMode `json:"window_mode" validate:"omitempty,oneof=CALENDAR ROLLING"`
+}
+
 type SetLimitRequest struct {
-	TransactionType domain.TransactionType `json:"transaction_type" validate:"required"`
-	DailyLimit      float64                `json:"daily_limit" validate:"required,gt=0"`
-	MonthlyLimit    float64                `json:"monthly_limit" validate:"required,gt=0"`
+	TransactionType domain.TransactionType `json:"transaction_type" validate:"required,oneof=DEPOSIT WITHDRAW TRANSFER"`
+	LimitValuesRequest
+}
+
+type CreateLimitPolicyRequest struct {
+	Scope           domain.LimitPolicyScope `json:"scope" validate:"required,oneof=DEFAULT ROLE KYC_TIER USER"`
+	ScopeValue      string                  `json:"scope_value"`
+	TransactionType domain.TransactionType  `json:"transaction_type" validate:"required,oneof=DEPOSIT WITHDRAW TRANSFER"`
+	LimitValuesRequest
+}
+
+func (r LimitValuesRequest) toDomain() domain.LimitValues {
+	return domain.LimitValues{
+		DailyLimit:             r.DailyLimit,
+		MonthlyLimit:           r.MonthlyLimit,
+		MaxSingleAmount:        r.MaxSingleAmount,
+		MinAmount:              r.MinAmount,
+		HourlyCountLimit:       r.HourlyCountLimit,
+		DailyCountLimit:        r.DailyCountLimit,
+		NewRecipientMaxAmount:  r.NewRecipientMaxAmount,
+		NewRecipientDailyLimit: r.NewRecipientDailyLimit,
+		WindowMode:             r.WindowMode,
+	}
 }
 
 func NewTransactionLimitHandler(limitUseCase *usecase.TransactionLimitUseCase) *TransactionLimitHandler {
@@ -26,21 +62,19 @@ func NewTransactionLimitHandler(limitUseCase *usecase.TransactionLimitUseCase) *
 
 func (h *TransactionLimitHandler) SetLimit(w http.ResponseWriter, r *http.Request) {
 	var req SetLimitRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
 		return
 	}
 
 	userID := r.Context().Value("user_id").(int64)
 
-	limit, err := h.limitUseCase.SetTransactionLimit(
-		userID,
-		req.TransactionType,
-		req.DailyLimit,
-		req.MonthlyLimit,
-	)
+	limit, err := h.limitUseCase.SetTransactionLimit(userID, &domain.TransactionLimit{
+		TransactionType: req.TransactionType,
+		LimitValues:     req.toDomain(),
+	}, middleware.ClientInfo(r))
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -52,9 +86,152 @@ func (h *TransactionLimitHandler) GetLimits(w http.ResponseWriter, r *http.Reque
 
 	limits, err := h.limitUseCase.GetUserLimits(userID)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		problem.Write(w, r, err)
 		return
 	}
 
 	respondWithJSON(w, http.StatusOK, limits)
 }
+
+// GetUsage returns consumed and remaining amounts per limit window
+func (h *TransactionLimitHandler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+
+	usage, err := h.limitUseCase.GetLimitUsage(userID)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, usage)
+}
+
+// GetPolicies returns every limit policy (Admin only)
+func (h *TransactionLimitHandler) GetPolicies(w http.ResponseWriter, r *http.Request) {
+	policies, err := h.limitUseCase.GetPolicies()
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, policies)
+}
+
+// CreatePolicy creates a DEFAULT, ROLE, KYC_TIER or USER scoped policy (Admin only)
+func (h *TransactionLimitHandler) CreatePolicy(w http.ResponseWriter, r *http.Request) {
+	var req CreateLimitPolicyRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	adminID := r.Context().Value("user_id").(int64)
+
+	policy, err := h.limitUseCase.CreatePolicy(adminID, &domain.LimitPolicy{
+		Scope:           req.Scope,
+		ScopeValue:      req.ScopeValue,
+		TransactionType: req.TransactionType,
+		LimitValues:     req.toDomain(),
+	}, middleware.ClientInfo(r))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, policy)
+}
+
+// UpdatePolicy replaces the limit values of a policy (Admin only)
+func (h *TransactionLimitHandler) UpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid policy ID"))
+		return
+	}
+
+	var req LimitValuesRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	adminID := r.Context().Value("user_id").(int64)
+
+	policy, err := h.limitUseCase.UpdatePolicy(adminID, id, req.toDomain(), middleware.ClientInfo(r))
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidOperation:
+			problem.Write(w, r, domain.NewAppError(domain.CodeNotFound, "Policy not found"))
+		default:
+			problem.Write(w, r, err)
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, policy)
+}
+
+// DeletePolicy removes a policy (Admin only)
+func (h *TransactionLimitHandler) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid policy ID"))
+		return
+	}
+
+	adminID := r.Context().Value("user_id").(int64)
+
+	if err := h.limitUseCase.DeletePolicy(adminID, id, middleware.ClientInfo(r)); err != nil {
+		switch err {
+		case domain.ErrInvalidOperation:
+			problem.Write(w, r, domain.NewAppError(domain.CodeNotFound, "Policy not found"))
+		default:
+			problem.Write(w, r, err)
+		}
+		return
+	}
+
+	respondWithMessage(w, r, http.StatusOK, "message.policy_deleted")
+}
+
+// GetUserLimits returns the effective limits of any user (Admin only)
+func (h *TransactionLimitHandler) GetUserLimits(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid user ID"))
+		return
+	}
+
+	limits, err := h.limitUseCase.GetUserLimits(userID)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, limits)
+}
+
+// SetUserOverride sets a per-user limit that replaces the policy ceiling (Admin only)
+func (h *TransactionLimitHandler) SetUserOverride(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid user ID"))
+		return
+	}
+
+	var req SetLimitRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	adminID := r.Context().Value("user_id").(int64)
+
+	policy, err := h.limitUseCase.SetUserOverride(adminID, userID, req.TransactionType, req.toDomain(), middleware.ClientInfo(r))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, policy)
+}
@@ -0,0 +1,75 @@
+// internal/delivery/http/transaction_pin_handler.go
+package http
+
+import (
+	"GonPay_Backend/internal/delivery/middleware"
+	"GonPay_Backend/internal/delivery/problem"
+	"GonPay_Backend/internal/usecase"
+	"net/http"
+)
+
+type TransactionPINHandler struct {
+	pinUseCase *usecase.TransactionPINUseCase
+}
+
+type SetPINRequest struct {
+	Password string `json:"password" validate:"required"`
+	PIN      string `json:"pin" validate:"required"`
+}
+
+type ChangePINRequest struct {
+	CurrentPIN string `json:"current_pin" validate:"required"`
+	NewPIN     string `json:"new_pin" validate:"required"`
+}
+
+func NewTransactionPINHandler(pinUseCase *usecase.TransactionPINUseCase) *TransactionPINHandler {
+	return &TransactionPINHandler{
+		pinUseCase: pinUseCase,
+	}
+}
+
+func (h *TransactionPINHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+
+	status, err := h.pinUseCase.GetStatus(userID)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, status)
+}
+
+func (h *TransactionPINHandler) SetPIN(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+
+	var req SetPINRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	if err := h.pinUseCase.SetPIN(userID, req.Password, req.PIN, middleware.ClientInfo(r)); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithMessage(w, r, http.StatusCreated, "message.pin_set")
+}
+
+func (h *TransactionPINHandler) ChangePIN(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+
+	var req ChangePINRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	if err := h.pinUseCase.ChangePIN(userID, req.CurrentPIN, req.NewPIN, middleware.ClientInfo(r)); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithMessage(w, r, http.StatusOK, "message.pin_changed")
+}
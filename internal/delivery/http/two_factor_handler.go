@@ -0,0 +1,126 @@
+// internal/delivery/http/two_factor_handler.go
+package http
+
+import (
+	"GonPay_Backend/internal/delivery/middleware"
+	"GonPay_Backend/internal/delivery/problem"
+	"GonPay_Backend/internal/usecase"
+	"net/http"
+)
+
+type TwoFactorHandler struct {
+	twoFactorUseCase *usecase.TwoFactorUseCase
+}
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type TwoFactorReauthRequest struct {
+	Password string `json:"password" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+}
+
+func NewTwoFactorHandler(twoFactorUseCase *usecase.TwoFactorUseCase) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		twoFactorUseCase: twoFactorUseCase,
+	}
+}
+
+func (h *TwoFactorHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req TwoFactorLoginRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	response, err := h.twoFactorUseCase.CompleteLogin(req.ChallengeToken, req.Code, middleware.ClientInfo(r))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *TwoFactorHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+
+	status, err := h.twoFactorUseCase.GetStatus(userID)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, status)
+}
+
+func (h *TwoFactorHandler) Setup(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+
+	setup, err := h.twoFactorUseCase.Setup(userID)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, setup)
+}
+
+func (h *TwoFactorHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+
+	var req TwoFactorCodeRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	codes, err := h.twoFactorUseCase.Confirm(userID, req.Code, middleware.ClientInfo(r))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string][]string{"recovery_codes": codes})
+}
+
+func (h *TwoFactorHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+
+	var req TwoFactorReauthRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	if err := h.twoFactorUseCase.Disable(userID, req.Password, req.Code, middleware.ClientInfo(r)); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithMessage(w, r, http.StatusOK, "message.2fa_disabled")
+}
+
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+
+	var req TwoFactorReauthRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	codes, err := h.twoFactorUseCase.RegenerateRecoveryCodes(userID, req.Password, req.Code, middleware.ClientInfo(r))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string][]string{"recovery_codes": codes})
+}
@@ -2,9 +2,9 @@
 package http
 
 import (
-	_ "GonPay_Backend/internal/domain"
+	"GonPay_Backend/internal/delivery/middleware"
+	"GonPay_Backend/internal/delivery/problem"
 	"GonPay_Backend/internal/usecase"
-	"encoding/json"
 	"net/http"
 )
 
@@ -14,8 +14,8 @@ type UserHandler struct {
 
 type RegisterRequest struct {
 	Username    string `json:"username" validate:"required,min=3,max=50"`
-	Email       string `json:"email" validate:"required,email"`
-	PhoneNumber string `json:"phone_number" validate:"required"`
+	Email       string `json:"email" validate:"required,email,max=100"`
+	PhoneNumber string `json:"phone_number" validate:"required,max=20"`
 	Password    string `json:"password" validate:"required,min=6"`
 }
 
@@ -25,9 +25,9 @@ type LoginRequest struct {
 }
 
 type UpdateProfileRequest struct {
-	Username    string `json:"username"`
-	Email       string `json:"email"`
-	PhoneNumber string `json:"phone_number"`
+	Username    string `json:"username" validate:"omitempty,min=3,max=50"`
+	Email       string `json:"email" validate:"omitempty,email,max=100"`
+	PhoneNumber string `json:"phone_number" validate:"max=20"`
 }
 
 type ChangePasswordRequest struct {
@@ -43,14 +43,14 @@ func NewUserHandler(userUseCase *usecase.UserUseCase) *UserHandler {
 
 func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
 	var req RegisterRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
 		return
 	}
 
-	response, err := h.userUseCase.Register(req.Username, req.Email, req.PhoneNumber, req.Password)
+	response, err := h.userUseCase.Register(req.Username, req.Email, req.PhoneNumber, req.Password, middleware.ClientInfo(r))
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -59,14 +59,14 @@ func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
 
 func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req LoginRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
 		return
 	}
 
-	response, err := h.userUseCase.Login(req.Email, req.Password)
+	response, err := h.userUseCase.Login(req.Email, req.Password, middleware.ClientInfo(r))
 	if err != nil {
-		respondWithError(w, http.StatusUnauthorized, err.Error())
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -78,7 +78,7 @@ func (h *UserHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 
 	user, err := h.userUseCase.GetUserByID(userID)
 	if err != nil {
-		respondWithError(w, http.StatusNotFound, err.Error())
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -89,14 +89,14 @@ func (h *UserHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("user_id").(int64)
 
 	var req UpdateProfileRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
 		return
 	}
 
 	user, err := h.userUseCase.GetUserByID(userID)
 	if err != nil {
-		respondWithError(w, http.StatusNotFound, err.Error())
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -111,7 +111,7 @@ func (h *UserHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.userUseCase.UpdateUser(user); err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -122,15 +122,15 @@ func (h *UserHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("user_id").(int64)
 
 	var req ChangePasswordRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
 		return
 	}
 
-	if err := h.userUseCase.ChangePassword(userID, req.OldPassword, req.NewPassword); err != nil {
-		respondWithError(w, http.StatusBadRequest, err.Error())
+	if err := h.userUseCase.ChangePassword(userID, req.OldPassword, req.NewPassword, middleware.ClientInfo(r)); err != nil {
+		problem.Write(w, r, err)
 		return
 	}
 
-	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Password changed successfully"})
+	respondWithMessage(w, r, http.StatusOK, "message.password_changed")
 }
@@ -0,0 +1,57 @@
+// internal/delivery/http/verification_handler.go
+package http
+
+import (
+	"GonPay_Backend/internal/delivery/middleware"
+	"GonPay_Backend/internal/delivery/problem"
+	"GonPay_Backend/internal/domain"
+	"GonPay_Backend/internal/usecase"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+type VerificationHandler struct {
+	verificationUseCase *usecase.VerificationUseCase
+}
+
+type VerifyCodeRequest struct {
+	Code string `json:"code" validate:"required,len=6"`
+}
+
+func NewVerificationHandler(verificationUseCase *usecase.VerificationUseCase) *VerificationHandler {
+	return &VerificationHandler{
+		verificationUseCase: verificationUseCase,
+	}
+}
+
+func (h *VerificationHandler) SendCode(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+	channel := domain.VerificationChannel(strings.ToUpper(mux.Vars(r)["channel"]))
+
+	if err := h.verificationUseCase.SendCode(userID, channel); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithMessage(w, r, http.StatusOK, "message.code_sent")
+}
+
+func (h *VerificationHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+	channel := domain.VerificationChannel(strings.ToUpper(mux.Vars(r)["channel"]))
+
+	var req VerifyCodeRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	if err := h.verificationUseCase.Verify(userID, channel, req.Code, middleware.ClientInfo(r)); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithMessage(w, r, http.StatusOK, "message.verified")
+}
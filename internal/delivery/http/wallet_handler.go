@@ -2,9 +2,9 @@
 package http
 
 import (
+	"GonPay_Backend/internal/delivery/problem"
 	"GonPay_Backend/internal/domain"
 	"GonPay_Backend/internal/usecase"
-	"encoding/json"
 	"github.com/gorilla/mux"
 	"net/http"
 	"strconv"
@@ -19,6 +19,14 @@ type TransferRequest struct {
 	DestinationWalletID int64   `json:"destination_wallet_id" validate:"required"`
 	Amount              float64 `json:"amount" validate:"required,gt=0"`
 	Description         string  `json:"description"`
+	StepUpRequest
+}
+
+type BeneficiaryTransferRequest struct {
+	SourceWalletID int64   `json:"source_wallet_id" validate:"required"`
+	Amount         float64 `json:"amount" validate:"required,gt=0"`
+	Description    string  `json:"description"`
+	StepUpRequest
 }
 
 type MoneyRequest struct {
@@ -26,6 +34,22 @@ type MoneyRequest struct {
 	Description string  `json:"description"`
 }
 
+type WithdrawRequest struct {
+	MoneyRequest
+	StepUpRequest
+}
+
+// StepUpRequest carries the transaction PIN or a TOTP code for payments that
+// need step-up. Only one of them is required.
+type StepUpRequest struct {
+	PIN     string `json:"pin"`
+	OTPCode string `json:"otp_code"`
+}
+
+func (r StepUpRequest) credentials() domain.StepUpCredentials {
+	return domain.StepUpCredentials{PIN: r.PIN, TOTPCode: r.OTPCode}
+}
+
 func NewWalletHandler(walletUseCase *usecase.WalletUseCase) *WalletHandler {
 	return &WalletHandler{
 		walletUseCase: walletUseCase,
@@ -37,7 +61,7 @@ func (h *WalletHandler) CreateWallet(w http.ResponseWriter, r *http.Request) {
 
 	wallet, err := h.walletUseCase.CreateWallet(userID)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -49,7 +73,7 @@ func (h *WalletHandler) GetUserWallets(w http.ResponseWriter, r *http.Request) {
 
 	wallets, err := h.walletUseCase.GetUserWallets(userID)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -60,18 +84,13 @@ func (h *WalletHandler) GetWallet(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	walletID, err := strconv.ParseInt(vars["id"], 10, 64)
 	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid wallet ID")
+		problem.Write(w, r, invalidRequest("Invalid wallet ID"))
 		return
 	}
 
 	wallet, err := h.walletUseCase.GetWallet(walletID)
 	if err != nil {
-		switch err {
-		case domain.ErrWalletNotFound:
-			respondWithError(w, http.StatusNotFound, err.Error())
-		default:
-			respondWithError(w, http.StatusInternalServerError, err.Error())
-		}
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -82,40 +101,30 @@ func (h *WalletHandler) DeactivateWallet(w http.ResponseWriter, r *http.Request)
 	vars := mux.Vars(r)
 	walletID, err := strconv.ParseInt(vars["id"], 10, 64)
 	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid wallet ID")
+		problem.Write(w, r, invalidRequest("Invalid wallet ID"))
 		return
 	}
 
 	if err := h.walletUseCase.DeactivateWallet(walletID); err != nil {
-		switch err {
-		case domain.ErrWalletNotFound:
-			respondWithError(w, http.StatusNotFound, err.Error())
-		default:
-			respondWithError(w, http.StatusInternalServerError, err.Error())
-		}
+		problem.Write(w, r, err)
 		return
 	}
 
-	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Wallet deactivated successfully"})
+	respondWithMessage(w, r, http.StatusOK, "message.wallet_deactivated")
 }
 
 func (h *WalletHandler) Transfer(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+
 	var req TransferRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
 		return
 	}
 
-	tx, err := h.walletUseCase.Transfer(req.SourceWalletID, req.DestinationWalletID, req.Amount)
+	tx, err := h.walletUseCase.Transfer(userID, req.SourceWalletID, req.DestinationWalletID, req.Amount, req.credentials())
 	if err != nil {
-		switch err {
-		case domain.ErrInsufficientFunds:
-			respondWithError(w, http.StatusBadRequest, err.Error())
-		case domain.ErrWalletNotFound:
-			respondWithError(w, http.StatusNotFound, err.Error())
-		default:
-			respondWithError(w, http.StatusInternalServerError, err.Error())
-		}
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -123,27 +132,23 @@ func (h *WalletHandler) Transfer(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *WalletHandler) Deposit(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
 	vars := mux.Vars(r)
 	walletID, err := strconv.ParseInt(vars["id"], 10, 64)
 	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid wallet ID")
+		problem.Write(w, r, invalidRequest("Invalid wallet ID"))
 		return
 	}
 
 	var req MoneyRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
 		return
 	}
 
-	tx, err := h.walletUseCase.Deposit(walletID, req.Amount)
+	tx, err := h.walletUseCase.Deposit(userID, walletID, req.Amount)
 	if err != nil {
-		switch err {
-		case domain.ErrWalletNotFound:
-			respondWithError(w, http.StatusNotFound, err.Error())
-		default:
-			respondWithError(w, http.StatusInternalServerError, err.Error())
-		}
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -151,29 +156,47 @@ func (h *WalletHandler) Deposit(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *WalletHandler) Withdraw(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
 	vars := mux.Vars(r)
 	walletID, err := strconv.ParseInt(vars["id"], 10, 64)
 	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid wallet ID")
+		problem.Write(w, r, invalidRequest("Invalid wallet ID"))
 		return
 	}
 
-	var req MoneyRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+	var req WithdrawRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	tx, err := h.walletUseCase.Withdraw(userID, walletID, req.Amount, req.credentials())
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, tx)
+}
+
+func (h *WalletHandler) TransferToBeneficiary(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int64)
+	vars := mux.Vars(r)
+	beneficiaryID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		problem.Write(w, r, invalidRequest("Invalid beneficiary ID"))
+		return
+	}
+
+	var req BeneficiaryTransferRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		problem.Write(w, r, err)
 		return
 	}
 
-	tx, err := h.walletUseCase.Withdraw(walletID, req.Amount)
+	tx, err := h.walletUseCase.TransferToBeneficiary(userID, beneficiaryID, req.SourceWalletID, req.Amount, req.credentials())
 	if err != nil {
-		switch err {
-		case domain.ErrInsufficientFunds:
-			respondWithError(w, http.StatusBadRequest, err.Error())
-		case domain.ErrWalletNotFound:
-			respondWithError(w, http.StatusNotFound, err.Error())
-		default:
-			respondWithError(w, http.StatusInternalServerError, err.Error())
-		}
+		problem.Write(w, r, err)
 		return
 	}
 
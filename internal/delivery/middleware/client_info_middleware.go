@@ -0,0 +1,115 @@
+// internal/delivery/middleware/client_info_middleware.go
+package middleware
+
+import (
+	"GonPay_Backend/internal/domain"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ParseTrustedProxies parses the addresses of the reverse proxies in front of
+// the API. Each entry is a CIDR range or a single IP address.
+func ParseTrustedProxies(entries []string) ([]*net.IPNet, error) {
+	proxies := make([]*net.IPNet, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", entry, err)
+		}
+		proxies = append(proxies, network)
+	}
+	return proxies, nil
+}
+
+// ClientInfoMiddleware works out the caller's IP address once per request
+// and stores it for ClientInfo. X-Forwarded-For is only honoured when the
+// connection comes from a trusted proxy, so clients cannot pick the address
+// that ends up in audit logs and IP throttling.
+func (m *Middleware) ClientInfoMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		info := domain.ClientInfo{
+			IPAddress: m.clientIP(r),
+			UserAgent: r.UserAgent(),
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), "client_info", info)))
+	})
+}
+
+// ClientInfo returns the caller's IP address and user agent for audit
+// logging. Outside ClientInfoMiddleware only the connection's address is
+// used.
+func ClientInfo(r *http.Request) domain.ClientInfo {
+	if info, ok := r.Context().Value("client_info").(domain.ClientInfo); ok {
+		return info
+	}
+
+	return domain.ClientInfo{
+		IPAddress: remoteIP(r),
+		UserAgent: r.UserAgent(),
+	}
+}
+
+// clientIP walks X-Forwarded-For from the right, skipping hops added by
+// trusted proxies, and returns the first address a trusted proxy did not
+// add. Entries to the left of it were written by the client and are ignored.
+func (m *Middleware) clientIP(r *http.Request) net.IP {
+	ip := remoteIP(r)
+	if ip == nil || !m.trustedProxy(ip) {
+		return ip
+	}
+
+	var hops []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(header, ",")...)
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			// A trusted proxy would not have written this, so the last
+			// trusted hop is the best known address.
+			return ip
+		}
+		ip = hop
+		if !m.trustedProxy(ip) {
+			return ip
+		}
+	}
+	return ip
+}
+
+func (m *Middleware) trustedProxy(ip net.IP) bool {
+	for _, network := range m.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func remoteIP(r *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return net.ParseIP(host)
+}
@@ -0,0 +1,54 @@
+// internal/delivery/middleware/impersonation_middleware.go
+package middleware
+
+import (
+	"GonPay_Backend/internal/delivery/problem"
+	"GonPay_Backend/internal/domain"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// serveImpersonated handles a request made with an admin's impersonation
+// token. The session must still be active and only read-only methods reach
+// the handler, so nothing, in particular no money, can be moved. Every
+// request, refused or not, is audited under the admin.
+func (m *Middleware) serveImpersonated(w http.ResponseWriter, r *http.Request, principal *domain.Principal, next http.Handler) {
+	if err := m.impersonation.Authorize(principal); err != nil {
+		if errors.Is(err, domain.ErrInvalidToken) || errors.Is(err, domain.ErrSessionNotFound) {
+			problem.Write(w, r, fmt.Errorf("%w: impersonation session has ended", domain.ErrInvalidToken))
+			return
+		}
+		m.logger.Error("cannot verify impersonation session", "error", err)
+		problem.Write(w, r, err)
+		return
+	}
+
+	recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		next.ServeHTTP(recorder, withPrincipal(r, principal))
+	default:
+		problem.Write(recorder, r, domain.NewAppError(domain.CodeForbidden, "Impersonation tokens are read-only"))
+	}
+
+	if err := m.impersonation.LogRequest(principal, r.Method, r.URL.Path, recorder.status, ClientInfo(r)); err != nil {
+		m.logger.Error("cannot audit impersonated request",
+			"error", err,
+			"admin_id", principal.ImpersonatorID,
+			"user_id", principal.UserID,
+			"path", r.URL.Path,
+		)
+	}
+}
+
+// statusRecorder remembers the status code a handler responded with.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,39 @@
+// internal/delivery/middleware/language_middleware.go
+package middleware
+
+import (
+	"GonPay_Backend/internal/i18n"
+	"net/http"
+)
+
+// LanguageMiddleware picks the response language from the Accept-Language
+// header. It runs before authentication, so when the header names no
+// supported language the authentication middlewares fall back to the
+// signed-in user's preference, and anonymous requests get Vietnamese.
+func (m *Middleware) LanguageMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Language")
+
+		if lang := i18n.MatchAcceptLanguage(r.Header.Get("Accept-Language")); lang != "" {
+			r = r.WithContext(i18n.WithLanguage(r.Context(), lang))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withPreferredLanguage sets the request language to the user's preference
+// when the client did not ask for one.
+func (m *Middleware) withPreferredLanguage(r *http.Request, userID int64) *http.Request {
+	if i18n.HasLanguage(r.Context()) {
+		return r
+	}
+
+	preferences, err := m.preferences.GetPreferences(userID)
+	if err != nil {
+		m.logger.Error("cannot load language preference", "user_id", userID, "error", err)
+		return r
+	}
+
+	return r.WithContext(i18n.WithLanguage(r.Context(), preferences.Language))
+}
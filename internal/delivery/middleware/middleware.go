@@ -2,34 +2,70 @@
 package middleware
 
 import (
+	"GonPay_Backend/internal/auth"
+	"GonPay_Backend/internal/delivery/problem"
+	"GonPay_Backend/internal/domain"
+	"GonPay_Backend/internal/usecase"
 	"GonPay_Backend/pkg/logger"
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
 	"time"
-
-	"github.com/dgrijalva/jwt-go"
 )
 
 type Middleware struct {
-	logger    logger.Logger
-	jwtSecret []byte
+	logger         logger.Logger
+	trustedProxies []*net.IPNet
+	tokens         *auth.TokenManager
+	sessions       *usecase.SessionUseCase
+	apiKeys        *usecase.APIKeyUseCase
+	signing        *usecase.RequestSigningUseCase
+	impersonation  *usecase.ImpersonationUseCase
+	preferences    *usecase.PreferencesUseCase
 }
 
-func NewMiddleware(logger logger.Logger, jwtSecret string) *Middleware {
+func NewMiddleware(
+	logger logger.Logger,
+	tokens *auth.TokenManager,
+	sessions *usecase.SessionUseCase,
+	apiKeys *usecase.APIKeyUseCase,
+	signing *usecase.RequestSigningUseCase,
+	impersonation *usecase.ImpersonationUseCase,
+	preferences *usecase.PreferencesUseCase,
+	trustedProxies []*net.IPNet,
+) *Middleware {
 	return &Middleware{
-		logger:    logger,
-		jwtSecret: []byte(jwtSecret),
+		logger:         logger,
+		trustedProxies: trustedProxies,
+		tokens:         tokens,
+		sessions:       sessions,
+		apiKeys:        apiKeys,
+		signing:        signing,
+		impersonation:  impersonation,
+		preferences:    preferences,
 	}
 }
 
 func (m *Middleware) LoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
+		recorder := &errorRecorder{ResponseWriter: w}
 
 		// Call the next handler
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(recorder, r)
+
+		// Server errors reach the client without their cause, so log it here
+		if recorder.err != nil && recorder.err.Status() >= http.StatusInternalServerError {
+			m.logger.Error("request failed",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"code", recorder.err.Code,
+				"error", recorder.err.Cause,
+			)
+		}
 
 		// Log the request
 		m.logger.Info("request completed",
@@ -37,46 +73,150 @@ func (m *Middleware) LoggingMiddleware(next http.Handler) http.Handler {
 			"path", r.URL.Path,
 			"duration", time.Since(start),
 			"remote_addr", r.RemoteAddr,
+			"client_ip", ClientInfo(r).IPAddress,
 		)
 	})
 }
 
+// AuthMiddleware accepts only bearer JWTs issued to signed-in users, or
+// read-only impersonation tokens issued to admins.
 func (m *Middleware) AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
-			http.Error(w, "Authorization header required", http.StatusUnauthorized)
+		principal, err := m.authenticateJWT(r)
+		if err != nil {
+			problem.Write(w, r, err)
 			return
 		}
 
-		bearerToken := strings.Split(authHeader, " ")
-		if len(bearerToken) != 2 {
-			http.Error(w, "Invalid token format", http.StatusUnauthorized)
+		if principal.Impersonated() {
+			m.serveImpersonated(w, r, principal, next)
 			return
 		}
 
-		token, err := jwt.Parse(bearerToken[1], func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return m.jwtSecret, nil
-		})
+		next.ServeHTTP(w, m.withPreferredLanguage(withPrincipal(r, principal), principal.UserID))
+	})
+}
 
+// AuthOrAPIKeyMiddleware accepts either a bearer JWT or a merchant API key in
+// the X-API-Key header, and puts the same kind of principal into the context.
+// Routes behind it must check scopes with RequireScope.
+func (m *Middleware) AuthOrAPIKeyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var principal *domain.Principal
+		var err error
+		if apiKey := r.Header.Get("X-API-Key"); apiKey != "" {
+			principal, err = m.apiKeys.Authenticate(apiKey, ClientInfo(r))
+			if err != nil && !errors.Is(err, domain.ErrInvalidAPIKey) {
+				m.logger.Error("cannot verify API key", "error", err)
+				problem.Write(w, r, err)
+				return
+			}
+		} else {
+			principal, err = m.authenticateJWT(r)
+		}
 		if err != nil {
-			http.Error(w, "Invalid token", http.StatusUnauthorized)
+			problem.Write(w, r, err)
 			return
 		}
 
-		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-			userID := int64(claims["user_id"].(float64))
-			userRole := claims["role"].(string)
-
-			ctx := context.WithValue(r.Context(), "user_id", userID)
-			ctx = context.WithValue(ctx, "user_role", userRole)
-
-			next.ServeHTTP(w, r.WithContext(ctx))
-		} else {
-			http.Error(w, "Invalid token claims", http.StatusUnauthorized)
+		if principal.Impersonated() {
+			m.serveImpersonated(w, r, principal, next)
+			return
 		}
+
+		next.ServeHTTP(w, m.withPreferredLanguage(withPrincipal(r, principal), principal.UserID))
 	})
 }
+
+// RequireScope refuses API keys without the scope. Users signed in with a
+// JWT always pass.
+func (m *Middleware) RequireScope(scope domain.APIScope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := r.Context().Value("principal").(*domain.Principal)
+			if !ok || !principal.HasScope(scope) {
+				problem.Write(w, r, domain.NewAppError(domain.CodeForbidden, "API key lacks scope "+string(scope)))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (m *Middleware) authenticateJWT(r *http.Request) (*domain.Principal, error) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return nil, domain.NewAppError(domain.CodeUnauthenticated, "Authorization header required")
+	}
+
+	bearerToken := strings.Split(authHeader, " ")
+	if len(bearerToken) != 2 {
+		return nil, domain.NewAppError(domain.CodeUnauthenticated, "Invalid token format")
+	}
+
+	claims, err := m.tokens.Parse(bearerToken[1])
+	if err != nil {
+		return nil, domain.ErrInvalidToken
+	}
+
+	// Purpose-bound tokens such as 2FA login challenges are not access tokens
+	if _, ok := claims["purpose"]; ok {
+		return nil, fmt.Errorf("%w: invalid claims", domain.ErrInvalidToken)
+	}
+
+	userID, ok := claims["user_id"].(float64)
+	userRole, roleOK := claims["role"].(string)
+	if !ok || !roleOK {
+		return nil, fmt.Errorf("%w: invalid claims", domain.ErrInvalidToken)
+	}
+
+	principal := &domain.Principal{
+		UserID:     int64(userID),
+		Role:       userRole,
+		AuthMethod: domain.AuthMethodJWT,
+	}
+	sid, ok := claims["sid"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid claims", domain.ErrInvalidToken)
+	}
+	principal.SessionID = int64(sid)
+
+	// Impersonation tokens never grant staff permissions, whatever they claim.
+	// Their session is checked by serveImpersonated.
+	if impersonatorID, ok := claims["impersonator_id"].(float64); ok {
+		principal.ImpersonatorID = int64(impersonatorID)
+		return principal, nil
+	}
+
+	// Role and permissions come from the user's current role rather than
+	// from the token, so that a role change applies to tokens already issued
+	if err := m.sessions.Authorize(principal); err != nil {
+		return nil, err
+	}
+
+	return principal, nil
+}
+
+// withPrincipal stores the principal in the request context, along with the
+// individual values handlers read.
+func withPrincipal(r *http.Request, principal *domain.Principal) *http.Request {
+	ctx := context.WithValue(r.Context(), "principal", principal)
+	ctx = context.WithValue(ctx, "user_id", principal.UserID)
+	ctx = context.WithValue(ctx, "user_role", principal.Role)
+	ctx = context.WithValue(ctx, "user_permissions", principal.Permissions)
+	if principal.SessionID != 0 {
+		ctx = context.WithValue(ctx, "session_id", principal.SessionID)
+	}
+	return r.WithContext(ctx)
+}
+
+// errorRecorder keeps the error a handler responded with through
+// problem.Write.
+type errorRecorder struct {
+	http.ResponseWriter
+	err *domain.AppError
+}
+
+func (e *errorRecorder) RecordError(err *domain.AppError) {
+	e.err = err
+}
@@ -0,0 +1,37 @@
+// internal/delivery/middleware/permission_middleware.go
+package middleware
+
+import (
+	"GonPay_Backend/internal/delivery/problem"
+	"GonPay_Backend/internal/domain"
+	"net/http"
+)
+
+// StaffMiddleware guards the admin API: only roles with at least one staff
+// permission get through. Each route then checks its own permissions with
+// RequirePermission.
+func (m *Middleware) StaffMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		permissions, _ := r.Context().Value("user_permissions").([]domain.Permission)
+		if len(permissions) == 0 {
+			problem.Write(w, r, domain.NewAppError(domain.CodeForbidden, "Staff access required"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequirePermission only lets the request through if the access token grants
+// every one of the required permissions.
+func (m *Middleware) RequirePermission(required ...domain.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			permissions, _ := r.Context().Value("user_permissions").([]domain.Permission)
+			if !domain.HasPermission(permissions, required...) {
+				problem.Write(w, r, domain.NewAppError(domain.CodeForbidden, "Missing permission"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// internal/delivery/middleware/signature_middleware.go
+package middleware
+
+import (
+	"GonPay_Backend/internal/delivery/problem"
+	"GonPay_Backend/internal/domain"
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// maxSignedBodyBytes caps how much of a signed request body is read into
+// memory to verify the signature.
+const maxSignedBodyBytes = 1 << 20
+
+// SignatureMiddleware requires requests made with a merchant API key to carry
+// an HMAC signature in X-Signature, with X-Timestamp and X-Nonce. It must run
+// after AuthOrAPIKeyMiddleware; requests from signed-in users pass through.
+func (m *Middleware) SignatureMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := r.Context().Value("principal").(*domain.Principal)
+		if !ok || principal.AuthMethod != domain.AuthMethodAPIKey {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSignedBodyBytes))
+		if err != nil {
+			problem.Write(w, r, domain.NewAppError(domain.CodePayloadTooLarge, "Request body too large"))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		err = m.signing.Verify(
+			principal.UserID,
+			r.Method,
+			r.URL.RequestURI(),
+			r.Header.Get("X-Timestamp"),
+			r.Header.Get("X-Nonce"),
+			body,
+			r.Header.Get("X-Signature"),
+		)
+		switch {
+		case err == nil:
+			next.ServeHTTP(w, r)
+		case errors.Is(err, domain.ErrInvalidSignature), errors.Is(err, domain.ErrStaleRequest),
+			errors.Is(err, domain.ErrReplayedRequest), errors.Is(err, domain.ErrSigningSecretUnset):
+			problem.Write(w, r, err)
+		default:
+			m.logger.Error("cannot verify request signature", "error", err)
+			problem.Write(w, r, err)
+		}
+	})
+}
@@ -0,0 +1,70 @@
+// internal/delivery/problem/problem.go
+package problem
+
+import (
+	"GonPay_Backend/internal/domain"
+	"GonPay_Backend/internal/i18n"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// ContentType is the media type of RFC 7807 problem details.
+const ContentType = "application/problem+json"
+
+// Details is an RFC 7807 problem details object. Code is the stable
+// machine-readable error code; clients should branch on it, not on Detail.
+// Errors lists the rejected fields when Code is VALIDATION_FAILED.
+type Details struct {
+	Type     string              `json:"type"`
+	Title    string              `json:"title"`
+	Status   int                 `json:"status"`
+	Detail   string              `json:"detail"`
+	Instance string              `json:"instance,omitempty"`
+	Code     domain.ErrorCode    `json:"code"`
+	Errors   []domain.FieldError `json:"errors,omitempty"`
+}
+
+// ErrorRecorder is implemented by response writers that want the error
+// behind a problem response, such as the logging middleware's, which logs
+// the cause of server errors.
+type ErrorRecorder interface {
+	RecordError(err *domain.AppError)
+}
+
+// Write responds with the problem details for err, in the language chosen
+// for the request. Errors that are not an AppError are reported as
+// INTERNAL_ERROR without their message.
+func Write(w http.ResponseWriter, r *http.Request, err error) {
+	appErr := domain.AsAppError(err)
+	status := appErr.Status()
+
+	if recorder, ok := w.(ErrorRecorder); ok {
+		recorder.RecordError(appErr)
+	}
+
+	lang := i18n.FromContext(r.Context())
+	appErr = i18n.LocalizeError(lang, appErr)
+
+	var blocked *domain.LoginBlockedError
+	if errors.As(err, &blocked) {
+		w.Header().Set("Retry-After", strconv.FormatInt(int64(blocked.RetryAfter.Seconds())+1, 10))
+	}
+
+	details := Details{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   appErr.Message,
+		Instance: r.URL.Path,
+		Code:     appErr.Code,
+		Errors:   appErr.Fields,
+	}
+
+	response, _ := json.Marshal(details)
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("Content-Language", lang)
+	w.WriteHeader(status)
+	w.Write(response)
+}
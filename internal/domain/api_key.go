@@ -0,0 +1,69 @@
+// internal/domain/api_key.go
+package domain
+
+import "time"
+
+// APIScope limits what a merchant API key may do, written as
+// "resource:action".
+type APIScope string
+
+const (
+	APIScopePaymentsCreate APIScope = "payments:create"
+	APIScopePaymentsRead   APIScope = "payments:read"
+	APIScopeRefundsCreate  APIScope = "refunds:create"
+	APIScopeWalletsRead    APIScope = "wallets:read"
+)
+
+var APIScopes = []APIScope{
+	APIScopePaymentsCreate,
+	APIScopePaymentsRead,
+	APIScopeRefundsCreate,
+	APIScopeWalletsRead,
+}
+
+func ValidAPIScope(scope APIScope) bool {
+	for _, s := range APIScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey gives a merchant's servers access to the API on behalf of the
+// merchant account. The key is "<prefix>.<secret>"; only the prefix and a
+// hash of the secret are stored.
+type APIKey struct {
+	ID         int64      `json:"id"`
+	UserID     int64      `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	SecretHash string     `json:"-"`
+	Scopes     []APIScope `json:"scopes"`
+	UsageCount int64      `json:"usage_count"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP string     `json:"last_used_ip,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || k.ExpiresAt.After(now))
+}
+
+// CreatedAPIKey is returned once when a key is issued. Key is never shown
+// again.
+type CreatedAPIKey struct {
+	*APIKey
+	Key string `json:"key"`
+}
+
+type APIKeyRepository interface {
+	Create(key *APIKey) error
+	GetByID(id int64) (*APIKey, error)
+	GetByPrefix(prefix string) (*APIKey, error)
+	GetByUserID(userID int64) ([]*APIKey, error)
+	RecordUsage(id int64, ipAddress string, at time.Time) error
+	Revoke(id int64, at time.Time) error
+}
@@ -0,0 +1,235 @@
+// internal/domain/app_error.go
+package domain
+
+import (
+	"errors"
+	"net/http"
+)
+
+// ErrorCode is a stable, machine-readable error identifier. Clients may
+// branch on it, so a code is never renamed or reused for something else.
+type ErrorCode string
+
+const (
+	CodeInvalidRequest       ErrorCode = "INVALID_REQUEST"
+	CodeValidationFailed     ErrorCode = "VALIDATION_FAILED"
+	CodeUnauthenticated      ErrorCode = "UNAUTHENTICATED"
+	CodeForbidden            ErrorCode = "FORBIDDEN"
+	CodeNotFound             ErrorCode = "NOT_FOUND"
+	CodeConflict             ErrorCode = "CONFLICT"
+	CodeAlreadyExists        ErrorCode = "ALREADY_EXISTS"
+	CodeInvalidReference     ErrorCode = "INVALID_REFERENCE"
+	CodePayloadTooLarge      ErrorCode = "PAYLOAD_TOO_LARGE"
+	CodeUnsupportedMediaType ErrorCode = "UNSUPPORTED_MEDIA_TYPE"
+	CodeRateLimited          ErrorCode = "RATE_LIMITED"
+	CodeInternal             ErrorCode = "INTERNAL_ERROR"
+
+	CodeInvalidCredentials   ErrorCode = "INVALID_CREDENTIALS"
+	CodeUserNotFound         ErrorCode = "USER_NOT_FOUND"
+	CodeAccountInactive      ErrorCode = "ACCOUNT_INACTIVE"
+	CodeAccountClosed        ErrorCode = "ACCOUNT_CLOSED"
+	CodeAccountLocked        ErrorCode = "ACCOUNT_LOCKED"
+	CodeTooManyAttempts      ErrorCode = "TOO_MANY_ATTEMPTS"
+	CodeWalletNotFound       ErrorCode = "WALLET_NOT_FOUND"
+	CodeWalletFrozen         ErrorCode = "WALLET_FROZEN"
+	CodeWalletBlocked        ErrorCode = "WALLET_BLOCKED"
+	CodeInsufficientFunds    ErrorCode = "INSUFFICIENT_FUNDS"
+	CodeInvalidAmount        ErrorCode = "INVALID_AMOUNT"
+	CodeInvalidOperation     ErrorCode = "INVALID_OPERATION"
+	CodeLimitExceeded        ErrorCode = "LIMIT_EXCEEDED"
+	CodeLimitAbovePolicy     ErrorCode = "LIMIT_ABOVE_POLICY"
+	CodeAccountNotFound      ErrorCode = "ACCOUNT_NOT_FOUND"
+	CodeInvalidToken         ErrorCode = "INVALID_TOKEN"
+	CodeTokenReused          ErrorCode = "TOKEN_REUSED"
+	CodeSessionNotFound      ErrorCode = "SESSION_NOT_FOUND"
+	CodeInvalid2FACode       ErrorCode = "INVALID_2FA_CODE"
+	Code2FANotEnabled        ErrorCode = "2FA_NOT_ENABLED"
+	Code2FAAlreadyEnabled    ErrorCode = "2FA_ALREADY_ENABLED"
+	CodeInvalidOTP           ErrorCode = "INVALID_OTP"
+	CodeOTPResendTooSoon     ErrorCode = "OTP_RESEND_TOO_SOON"
+	CodeAlreadyVerified      ErrorCode = "ALREADY_VERIFIED"
+	CodeVerificationRequired ErrorCode = "VERIFICATION_REQUIRED"
+	CodePINNotSet            ErrorCode = "PIN_NOT_SET"
+	CodePINAlreadySet        ErrorCode = "PIN_ALREADY_SET"
+	CodeInvalidPIN           ErrorCode = "INVALID_PIN"
+	CodeWeakPIN              ErrorCode = "WEAK_PIN"
+	CodePINLocked            ErrorCode = "PIN_LOCKED"
+	CodeStepUpRequired       ErrorCode = "STEP_UP_REQUIRED"
+	CodeInvalidRole          ErrorCode = "INVALID_ROLE"
+	CodeAPIKeyNotFound       ErrorCode = "API_KEY_NOT_FOUND"
+	CodeInvalidAPIKey        ErrorCode = "INVALID_API_KEY"
+	CodeInvalidScope         ErrorCode = "INVALID_SCOPE"
+	CodeMerchantOnly         ErrorCode = "MERCHANT_ONLY"
+	CodeInvalidSignature     ErrorCode = "INVALID_SIGNATURE"
+	CodeStaleRequest         ErrorCode = "STALE_REQUEST"
+	CodeReplayedRequest      ErrorCode = "REPLAYED_REQUEST"
+	CodeSigningSecretUnset   ErrorCode = "SIGNING_SECRET_UNSET"
+	CodeKYCNotFound          ErrorCode = "KYC_NOT_FOUND"
+	CodeKYCPending           ErrorCode = "KYC_PENDING"
+	CodeKYCReviewed          ErrorCode = "KYC_REVIEWED"
+	CodeInvalidKYCTier       ErrorCode = "INVALID_KYC_TIER"
+	CodeInvalidKYCDocument   ErrorCode = "INVALID_KYC_DOCUMENT"
+	CodeBalanceCapExceeded   ErrorCode = "BALANCE_CAP_EXCEEDED"
+	CodeAdjustmentNotFound   ErrorCode = "ADJUSTMENT_NOT_FOUND"
+	CodeAdjustmentReviewed   ErrorCode = "ADJUSTMENT_REVIEWED"
+	CodeNonZeroBalance       ErrorCode = "NON_ZERO_BALANCE"
+	CodeDataExportNotFound   ErrorCode = "DATA_EXPORT_NOT_FOUND"
+	CodeDataExportPending    ErrorCode = "DATA_EXPORT_PENDING"
+	CodeDataExportNotReady   ErrorCode = "DATA_EXPORT_NOT_READY"
+	CodeInvalidPreferences   ErrorCode = "INVALID_PREFERENCES"
+	CodeDeliveryNotFound     ErrorCode = "DELIVERY_NOT_FOUND"
+	CodeDeliveryNotDead      ErrorCode = "DELIVERY_NOT_DEAD"
+	CodeNoDeliveryAddress    ErrorCode = "NO_DELIVERY_ADDRESS"
+	CodePushDeviceNotFound   ErrorCode = "PUSH_DEVICE_NOT_FOUND"
+	CodePushTokenInvalid     ErrorCode = "PUSH_TOKEN_INVALID"
+)
+
+// codeStatus maps each code to the HTTP status it is returned with. Codes
+// missing here are returned as 500.
+var codeStatus = map[ErrorCode]int{
+	CodeInvalidRequest:       http.StatusBadRequest,
+	CodeValidationFailed:     http.StatusBadRequest,
+	CodeUnauthenticated:      http.StatusUnauthorized,
+	CodeForbidden:            http.StatusForbidden,
+	CodeNotFound:             http.StatusNotFound,
+	CodeConflict:             http.StatusConflict,
+	CodeAlreadyExists:        http.StatusConflict,
+	CodeInvalidReference:     http.StatusBadRequest,
+	CodePayloadTooLarge:      http.StatusRequestEntityTooLarge,
+	CodeUnsupportedMediaType: http.StatusUnsupportedMediaType,
+	CodeRateLimited:          http.StatusTooManyRequests,
+	CodeInternal:             http.StatusInternalServerError,
+
+	CodeInvalidCredentials:   http.StatusUnauthorized,
+	CodeUserNotFound:         http.StatusNotFound,
+	CodeAccountInactive:      http.StatusForbidden,
+	CodeAccountClosed:        http.StatusConflict,
+	CodeAccountLocked:        http.StatusLocked,
+	CodeTooManyAttempts:      http.StatusTooManyRequests,
+	CodeWalletNotFound:       http.StatusNotFound,
+	CodeWalletFrozen:         http.StatusForbidden,
+	CodeWalletBlocked:        http.StatusForbidden,
+	CodeInsufficientFunds:    http.StatusBadRequest,
+	CodeInvalidAmount:        http.StatusBadRequest,
+	CodeInvalidOperation:     http.StatusBadRequest,
+	CodeLimitExceeded:        http.StatusBadRequest,
+	CodeLimitAbovePolicy:     http.StatusForbidden,
+	CodeAccountNotFound:      http.StatusNotFound,
+	CodeInvalidToken:         http.StatusUnauthorized,
+	CodeTokenReused:          http.StatusUnauthorized,
+	CodeSessionNotFound:      http.StatusNotFound,
+	CodeInvalid2FACode:       http.StatusUnauthorized,
+	Code2FANotEnabled:        http.StatusBadRequest,
+	Code2FAAlreadyEnabled:    http.StatusConflict,
+	CodeInvalidOTP:           http.StatusBadRequest,
+	CodeOTPResendTooSoon:     http.StatusTooManyRequests,
+	CodeAlreadyVerified:      http.StatusConflict,
+	CodeVerificationRequired: http.StatusForbidden,
+	CodePINNotSet:            http.StatusConflict,
+	CodePINAlreadySet:        http.StatusConflict,
+	CodeInvalidPIN:           http.StatusUnauthorized,
+	CodeWeakPIN:              http.StatusBadRequest,
+	CodePINLocked:            http.StatusLocked,
+	CodeStepUpRequired:       http.StatusForbidden,
+	CodeInvalidRole:          http.StatusBadRequest,
+	CodeAPIKeyNotFound:       http.StatusNotFound,
+	CodeInvalidAPIKey:        http.StatusUnauthorized,
+	CodeInvalidScope:         http.StatusBadRequest,
+	CodeMerchantOnly:         http.StatusForbidden,
+	CodeInvalidSignature:     http.StatusUnauthorized,
+	CodeStaleRequest:         http.StatusUnauthorized,
+	CodeReplayedRequest:      http.StatusUnauthorized,
+	CodeSigningSecretUnset:   http.StatusUnauthorized,
+	CodeKYCNotFound:          http.StatusNotFound,
+	CodeKYCPending:           http.StatusConflict,
+	CodeKYCReviewed:          http.StatusConflict,
+	CodeInvalidKYCTier:       http.StatusBadRequest,
+	CodeInvalidKYCDocument:   http.StatusBadRequest,
+	CodeBalanceCapExceeded:   http.StatusBadRequest,
+	CodeAdjustmentNotFound:   http.StatusNotFound,
+	CodeAdjustmentReviewed:   http.StatusConflict,
+	CodeNonZeroBalance:       http.StatusBadRequest,
+	CodeDataExportNotFound:   http.StatusNotFound,
+	CodeDataExportPending:    http.StatusConflict,
+	CodeDataExportNotReady:   http.StatusConflict,
+	CodeInvalidPreferences:   http.StatusBadRequest,
+	CodeDeliveryNotFound:     http.StatusNotFound,
+	CodeDeliveryNotDead:      http.StatusConflict,
+	CodeNoDeliveryAddress:    http.StatusBadRequest,
+	CodePushDeviceNotFound:   http.StatusNotFound,
+	CodePushTokenInvalid:     http.StatusBadRequest,
+}
+
+// Status returns the HTTP status for the code.
+func (c ErrorCode) Status() int {
+	if status, ok := codeStatus[c]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// AppError is an error that is safe to show to the client. Message is
+// written for the client; Cause is the underlying error, kept for logs and
+// errors.Is but never sent. Fields lists the rejected request fields of a
+// VALIDATION_FAILED error.
+type AppError struct {
+	Code    ErrorCode
+	Message string
+	Cause   error
+	Fields  []FieldError
+}
+
+// FieldError describes why one field of a request was rejected. Rule is the
+// validation rule that failed, such as "required" or "max". Param and Unit
+// are the rule's argument and what it counts, such as "6" and "characters"
+// for a minimum length; they are only used to translate Message.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	Param   string `json:"-"`
+	Unit    string `json:"-"`
+}
+
+func NewAppError(code ErrorCode, message string) *AppError {
+	return &AppError{Code: code, Message: message}
+}
+
+// WrapAppError returns an AppError that hides cause behind message.
+func WrapAppError(code ErrorCode, message string, cause error) *AppError {
+	return &AppError{Code: code, Message: message, Cause: cause}
+}
+
+// NewValidationError reports the request fields that failed validation.
+func NewValidationError(fields []FieldError) *AppError {
+	return &AppError{Code: CodeValidationFailed, Message: "request validation failed", Fields: fields}
+}
+
+// Error returns only the client message, so wrapping an AppError with
+// fmt.Errorf never pulls the cause into a response.
+func (e *AppError) Error() string {
+	return e.Message
+}
+
+func (e *AppError) Unwrap() error {
+	return e.Cause
+}
+
+func (e *AppError) Status() int {
+	return e.Code.Status()
+}
+
+// AsAppError finds the AppError in err's chain. When it was wrapped with
+// fmt.Errorf("%w: ...") the result keeps its code but uses the full wrapped
+// message, which only ever adds detail written for the client. Any other
+// error becomes an INTERNAL_ERROR with a generic message and err as cause.
+func AsAppError(err error) *AppError {
+	var appErr *AppError
+	if !errors.As(err, &appErr) {
+		return WrapAppError(CodeInternal, "internal server error", err)
+	}
+	if appErr == err {
+		return appErr
+	}
+	return &AppError{Code: appErr.Code, Message: err.Error(), Cause: err, Fields: appErr.Fields}
+}
@@ -19,6 +19,33 @@ const (
 	AuditActionFailedLogin      AuditAction = "FAILED_LOGIN"
 	AuditActionAddPaymentMethod AuditAction = "ADD_PAYMENT_METHOD"
 	AuditActionUpdateLimits     AuditAction = "UPDATE_LIMITS"
+	AuditActionRegenerate2FA    AuditAction = "REGENERATE_2FA_RECOVERY_CODES"
+	AuditActionAccountLocked    AuditAction = "ACCOUNT_LOCKED"
+	AuditActionUnlockAccount    AuditAction = "UNLOCK_ACCOUNT"
+	AuditActionResetPassword    AuditAction = "RESET_PASSWORD"
+	AuditActionVerifyContact    AuditAction = "VERIFY_CONTACT"
+	AuditActionSetPIN           AuditAction = "SET_TRANSACTION_PIN"
+	AuditActionCreateAPIKey     AuditAction = "CREATE_API_KEY"
+	AuditActionRevokeAPIKey     AuditAction = "REVOKE_API_KEY"
+	AuditActionRotateSecret     AuditAction = "ROTATE_SIGNING_SECRET"
+	AuditActionSubmitKYC        AuditAction = "SUBMIT_KYC"
+	AuditActionReviewKYC        AuditAction = "REVIEW_KYC"
+	AuditActionAssignRole       AuditAction = "ASSIGN_ROLE"
+	AuditActionSuspendUser      AuditAction = "SUSPEND_USER"
+	AuditActionReactivateUser   AuditAction = "REACTIVATE_USER"
+	AuditActionForceLogout      AuditAction = "FORCE_LOGOUT"
+	AuditActionReset2FA         AuditAction = "RESET_2FA"
+	AuditActionWalletStatus     AuditAction = "CHANGE_WALLET_STATUS"
+	AuditActionProposeAdjust    AuditAction = "PROPOSE_ADJUSTMENT"
+	AuditActionReviewAdjust     AuditAction = "REVIEW_ADJUSTMENT"
+	AuditActionImpersonate      AuditAction = "IMPERSONATE_USER"
+	AuditActionEndImpersonation AuditAction = "END_IMPERSONATION"
+	AuditActionImpersonatedCall AuditAction = "IMPERSONATED_REQUEST"
+	AuditActionRequestExport    AuditAction = "REQUEST_DATA_EXPORT"
+	AuditActionDownloadExport   AuditAction = "DOWNLOAD_DATA_EXPORT"
+	AuditActionCloseAccount     AuditAction = "CLOSE_ACCOUNT"
+	AuditActionUpdatePrefs      AuditAction = "UPDATE_PREFERENCES"
+	AuditActionRetryDelivery    AuditAction = "RETRY_NOTIFICATION_DELIVERY"
 )
 
 type AuditLog struct {
@@ -34,6 +61,13 @@ type AuditLog struct {
 	CreatedAt  time.Time   `json:"created_at"`
 }
 
+// ClientInfo identifies where a request came from so use cases can record it
+// in the audit log without depending on the transport.
+type ClientInfo struct {
+	IPAddress net.IP
+	UserAgent string
+}
+
 type AuditRepository interface {
 	Create(log *AuditLog) error
 	GetByUserID(userID int64, limit, offset int) ([]*AuditLog, error)
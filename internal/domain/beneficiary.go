@@ -12,14 +12,32 @@ const (
 	AccountTypeBankAccount AccountType = "BANK_ACCOUNT"
 )
 
+// NameMatchStatus is the result of comparing the beneficiary name entered by
+// the user with the real account holder name.
+type NameMatchStatus string
+
+const (
+	NameMatchMatched    NameMatchStatus = "MATCHED"
+	NameMatchMismatch   NameMatchStatus = "MISMATCH"
+	NameMatchUnverified NameMatchStatus = "UNVERIFIED"
+)
+
 type Beneficiary struct {
-	ID                int64       `json:"id"`
-	UserID            int64       `json:"user_id"`
-	BeneficiaryName   string      `json:"beneficiary_name"`
-	AccountIdentifier string      `json:"account_identifier"`
-	AccountType       AccountType `json:"account_type"`
-	BankName          string      `json:"bank_name,omitempty"`
-	CreatedAt         time.Time   `json:"created_at"`
+	ID                int64           `json:"id"`
+	UserID            int64           `json:"user_id"`
+	BeneficiaryName   string          `json:"beneficiary_name"`
+	AccountIdentifier string          `json:"account_identifier"`
+	AccountType       AccountType     `json:"account_type"`
+	BankName          string          `json:"bank_name,omitempty"`
+	VerifiedName      string          `json:"verified_name,omitempty"`
+	NameMatchStatus   NameMatchStatus `json:"name_match_status"`
+	CoolingOffUntil   *time.Time      `json:"cooling_off_until,omitempty"`
+	CreatedAt         time.Time       `json:"created_at"`
+}
+
+// InCoolingOff reports whether transfers to the beneficiary are still capped.
+func (b *Beneficiary) InCoolingOff(now time.Time) bool {
+	return b.CoolingOffUntil != nil && now.Before(*b.CoolingOffUntil)
 }
 
 type BeneficiaryRepository interface {
@@ -29,4 +47,12 @@ type BeneficiaryRepository interface {
 	GetByID(id int64) (*Beneficiary, error)
 	GetByUserID(userID int64) ([]*Beneficiary, error)
 	GetByAccountIdentifier(accountIdentifier string, accountType AccountType) (*Beneficiary, error)
+	GetByUserAndAccount(userID int64, accountIdentifier string, accountType AccountType) (*Beneficiary, error)
+	LiftCoolingOff(id int64, userID int64) error
+}
+
+// BankAccountLookup resolves the holder name of an account at another bank.
+// It returns ErrAccountNotFound if the bank does not know the account.
+type BankAccountLookup interface {
+	LookupAccountName(bankName string, accountNumber string) (string, error)
 }
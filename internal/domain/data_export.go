@@ -0,0 +1,46 @@
+// internal/domain/data_export.go
+package domain
+
+import (
+	"time"
+)
+
+type DataExportStatus string
+
+const (
+	DataExportStatusPending DataExportStatus = "PENDING"
+	DataExportStatusReady   DataExportStatus = "READY"
+	DataExportStatusFailed  DataExportStatus = "FAILED"
+)
+
+// DataExport is a user's request for a copy of their personal data. The
+// archive is assembled in the background and kept in the blob store until
+// ExpiresAt.
+type DataExport struct {
+	ID          int64            `json:"id"`
+	UserID      int64            `json:"user_id"`
+	Status      DataExportStatus `json:"status"`
+	StorageKey  string           `json:"-"`
+	SizeBytes   int64            `json:"size_bytes,omitempty"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time       `json:"expires_at,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+// Expired reports whether the archive can no longer be downloaded.
+func (e *DataExport) Expired(now time.Time) bool {
+	return e.ExpiresAt != nil && !now.Before(*e.ExpiresAt)
+}
+
+type DataExportRepository interface {
+	Create(export *DataExport) error
+	GetByID(id int64) (*DataExport, error)
+	// GetLatest returns the user's most recent export, or nil if there is none.
+	GetLatest(userID int64) (*DataExport, error)
+	GetByUserID(userID int64) ([]*DataExport, error)
+	Complete(export *DataExport) error
+	Fail(id int64) error
+	// Purge marks an archive whose file was deleted as expired.
+	Purge(id int64) error
+	DeleteByUserID(userID int64) error
+}
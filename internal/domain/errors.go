@@ -1,13 +1,66 @@
 // internal/domain/errors.go
 package domain
 
-import "errors"
-
+// Every sentinel error is an AppError with its own code, so wrapping one
+// with fmt.Errorf("%w: ...") keeps the code and HTTP status.
 var (
-	ErrInvalidCredentials = errors.New("invalid credentials")
-	ErrUserNotFound       = errors.New("user not found")
-	ErrWalletNotFound     = errors.New("wallet not found")
-	ErrInsufficientFunds  = errors.New("insufficient funds")
-	ErrInvalidAmount      = errors.New("invalid amount")
-	ErrInvalidOperation   = errors.New("invalid operation")
+	ErrInvalidCredentials = NewAppError(CodeInvalidCredentials, "invalid credentials")
+	ErrUserNotFound       = NewAppError(CodeUserNotFound, "user not found")
+	ErrWalletNotFound     = NewAppError(CodeWalletNotFound, "wallet not found")
+	ErrWalletFrozen       = NewAppError(CodeWalletFrozen, "wallet is frozen, it can only receive money")
+	ErrWalletBlocked      = NewAppError(CodeWalletBlocked, "wallet is blocked")
+	ErrInsufficientFunds  = NewAppError(CodeInsufficientFunds, "insufficient funds")
+	ErrInvalidAmount      = NewAppError(CodeInvalidAmount, "invalid amount")
+	ErrInvalidOperation   = NewAppError(CodeInvalidOperation, "invalid operation")
+	ErrLimitExceeded      = NewAppError(CodeLimitExceeded, "transaction limit exceeded")
+	ErrLimitAbovePolicy   = NewAppError(CodeLimitAbovePolicy, "limit is less strict than the limit policy allows")
+	ErrAccountNotFound    = NewAppError(CodeAccountNotFound, "account not found")
+	ErrInvalidToken       = NewAppError(CodeInvalidToken, "invalid or expired token")
+	ErrTokenReused        = NewAppError(CodeTokenReused, "refresh token reuse detected, session revoked")
+	ErrSessionNotFound    = NewAppError(CodeSessionNotFound, "session not found")
+	ErrInvalid2FACode     = NewAppError(CodeInvalid2FACode, "invalid two-factor code")
+	Err2FANotEnabled      = NewAppError(Code2FANotEnabled, "two-factor authentication is not enabled")
+	Err2FAAlreadyEnabled  = NewAppError(Code2FAAlreadyEnabled, "two-factor authentication is already enabled")
+	ErrAccountLocked      = NewAppError(CodeAccountLocked, "account is temporarily locked")
+	ErrTooManyAttempts    = NewAppError(CodeTooManyAttempts, "too many failed login attempts")
+	ErrRateLimited        = NewAppError(CodeRateLimited, "too many requests, please try again later")
+	ErrInvalidOTP         = NewAppError(CodeInvalidOTP, "invalid or expired verification code")
+	ErrOTPResendTooSoon   = NewAppError(CodeOTPResendTooSoon, "a verification code was sent recently, please wait before requesting another")
+	ErrAlreadyVerified    = NewAppError(CodeAlreadyVerified, "already verified")
+	ErrNotVerified        = NewAppError(CodeVerificationRequired, "account verification required")
+	ErrPINNotSet          = NewAppError(CodePINNotSet, "transaction PIN is not set")
+	ErrPINAlreadySet      = NewAppError(CodePINAlreadySet, "transaction PIN is already set")
+	ErrInvalidPIN         = NewAppError(CodeInvalidPIN, "invalid transaction PIN")
+	ErrWeakPIN            = NewAppError(CodeWeakPIN, "transaction PIN must be 6 digits and not easy to guess")
+	ErrPINLocked          = NewAppError(CodePINLocked, "payment confirmation is temporarily locked after too many wrong PINs or codes")
+	ErrInvalidRole        = NewAppError(CodeInvalidRole, "invalid role")
+	ErrAPIKeyNotFound     = NewAppError(CodeAPIKeyNotFound, "API key not found")
+	ErrInvalidAPIKey      = NewAppError(CodeInvalidAPIKey, "invalid, expired or revoked API key")
+	ErrInvalidScope       = NewAppError(CodeInvalidScope, "invalid API key scope")
+	ErrMerchantOnly       = NewAppError(CodeMerchantOnly, "only merchant accounts can use API keys")
+	ErrInvalidSignature   = NewAppError(CodeInvalidSignature, "invalid request signature")
+	ErrStaleRequest       = NewAppError(CodeStaleRequest, "request timestamp is too old or too far in the future")
+	ErrReplayedRequest    = NewAppError(CodeReplayedRequest, "request nonce was already used")
+	ErrSigningSecretUnset = NewAppError(CodeSigningSecretUnset, "request signing secret is not set")
+	ErrKYCNotFound        = NewAppError(CodeKYCNotFound, "KYC submission not found")
+	ErrKYCPending         = NewAppError(CodeKYCPending, "a KYC submission is already under review")
+	ErrKYCReviewed        = NewAppError(CodeKYCReviewed, "KYC submission was already reviewed")
+	ErrInvalidKYCTier     = NewAppError(CodeInvalidKYCTier, "invalid KYC tier")
+	ErrInvalidKYCDocument = NewAppError(CodeInvalidKYCDocument, "invalid KYC document")
+	ErrBalanceCapExceeded = NewAppError(CodeBalanceCapExceeded, "balance would exceed the maximum allowed for the KYC tier")
+	ErrAdjustmentNotFound = NewAppError(CodeAdjustmentNotFound, "wallet adjustment not found")
+	ErrAdjustmentReviewed = NewAppError(CodeAdjustmentReviewed, "wallet adjustment was already reviewed")
+	ErrNonZeroBalance     = NewAppError(CodeNonZeroBalance, "all wallets must have a zero balance")
+	ErrAccountInactive    = NewAppError(CodeAccountInactive, "account is inactive")
+	ErrAccountClosed      = NewAppError(CodeAccountClosed, "account is closed")
+	ErrDataExportNotFound = NewAppError(CodeDataExportNotFound, "data export not found")
+	ErrDataExportPending  = NewAppError(CodeDataExportPending, "a data export is already being prepared")
+	ErrDataExportNotReady = NewAppError(CodeDataExportNotReady, "data export is not ready or has expired")
+	ErrInvalidPreferences = NewAppError(CodeInvalidPreferences, "invalid preferences")
+	ErrStepUpRequired     = NewAppError(CodeStepUpRequired, "this payment must be confirmed with your transaction PIN or a two-factor code")
+	ErrDeliveryNotFound   = NewAppError(CodeDeliveryNotFound, "notification delivery not found")
+	ErrDeliveryNotDead    = NewAppError(CodeDeliveryNotDead, "only dead notification deliveries can be retried")
+	ErrNoDeliveryAddress  = NewAppError(CodeNoDeliveryAddress, "user has no address on this channel")
+	ErrPushDeviceNotFound = NewAppError(CodePushDeviceNotFound, "push device not found")
+	ErrPushTokenInvalid   = NewAppError(CodePushTokenInvalid, "push token is no longer valid")
 )
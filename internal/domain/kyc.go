@@ -0,0 +1,99 @@
+// internal/domain/kyc.go
+package domain
+
+import (
+	"io"
+	"time"
+)
+
+type KYCStatus string
+
+const (
+	KYCStatusNone     KYCStatus = "NONE"
+	KYCStatusPending  KYCStatus = "PENDING"
+	KYCStatusVerified KYCStatus = "VERIFIED"
+	KYCStatusRejected KYCStatus = "REJECTED"
+)
+
+type KYCDocumentType string
+
+const (
+	KYCDocumentIDFront KYCDocumentType = "ID_FRONT"
+	KYCDocumentIDBack  KYCDocumentType = "ID_BACK"
+	KYCDocumentSelfie  KYCDocumentType = "SELFIE"
+)
+
+// MaxKYCTier is the highest tier a user can be verified to. Tier 0 is an
+// unverified account.
+const MaxKYCTier = 2
+
+// KYCTierDocuments lists the documents a submission must include to reach
+// each tier: tier 1 needs both sides of an ID card, tier 2 also a selfie.
+var KYCTierDocuments = map[int][]KYCDocumentType{
+	1: {KYCDocumentIDFront, KYCDocumentIDBack},
+	2: {KYCDocumentIDFront, KYCDocumentIDBack, KYCDocumentSelfie},
+}
+
+// KYCSubmission is a request to verify the user's identity up to
+// RequestedTier. Compliance staff approve or reject it from the review queue.
+type KYCSubmission struct {
+	ID              int64          `json:"id"`
+	UserID          int64          `json:"user_id"`
+	RequestedTier   int            `json:"requested_tier"`
+	Status          KYCStatus      `json:"status"`
+	RejectionReason string         `json:"rejection_reason,omitempty"`
+	ReviewedBy      *int64         `json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time     `json:"reviewed_at,omitempty"`
+	Documents       []*KYCDocument `json:"documents,omitempty"`
+	CreatedAt       time.Time      `json:"created_at"`
+}
+
+// KYCDocument is one uploaded file. StorageKey locates it in the BlobStore.
+type KYCDocument struct {
+	ID           int64           `json:"id"`
+	SubmissionID int64           `json:"submission_id"`
+	Type         KYCDocumentType `json:"type"`
+	StorageKey   string          `json:"-"`
+	ContentType  string          `json:"content_type"`
+	Size         int64           `json:"size"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// KYCUpload is a document file as received from the user.
+type KYCUpload struct {
+	Type    KYCDocumentType
+	Content []byte
+}
+
+// KYCState is the user's verification state: the tier reached and the
+// outcome of the latest submission.
+type KYCState struct {
+	Tier            int            `json:"tier"`
+	MaxBalance      float64        `json:"max_balance"`
+	Status          KYCStatus      `json:"status"`
+	RejectionReason string         `json:"rejection_reason,omitempty"`
+	Submission      *KYCSubmission `json:"submission,omitempty"`
+}
+
+type KYCRepository interface {
+	// CreateSubmission stores the submission with its documents.
+	CreateSubmission(submission *KYCSubmission) error
+	GetSubmission(id int64) (*KYCSubmission, error)
+	// GetLatest returns the user's most recent submission, or nil if none.
+	GetLatest(userID int64) (*KYCSubmission, error)
+	// GetByStatus lists submissions oldest first, so the review queue is
+	// worked in order.
+	GetByStatus(status KYCStatus, limit, offset int) ([]*KYCSubmission, error)
+	GetDocument(submissionID, documentID int64) (*KYCDocument, error)
+	// Review records the decision on a pending submission and, when it is
+	// approved, raises the user's KYC tier. The notification telling the
+	// user is stored in the same transaction.
+	Review(submission *KYCSubmission, notification *Notification) error
+}
+
+// BlobStore keeps uploaded files such as KYC documents outside the database.
+type BlobStore interface {
+	Put(key string, content []byte) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
@@ -0,0 +1,41 @@
+// internal/domain/limit_policy.go
+package domain
+
+import (
+	"time"
+)
+
+type LimitPolicyScope string
+
+// Policies are resolved from the most to the least specific scope:
+// USER, KYC_TIER, ROLE and finally DEFAULT.
+const (
+	LimitPolicyScopeDefault LimitPolicyScope = "DEFAULT"
+	LimitPolicyScopeRole    LimitPolicyScope = "ROLE"
+	LimitPolicyScopeKYCTier LimitPolicyScope = "KYC_TIER"
+	LimitPolicyScopeUser    LimitPolicyScope = "USER"
+)
+
+// LimitPolicy is an admin-defined ceiling for a TransactionType. ScopeValue is
+// the role name, KYC tier or user ID the policy applies to, and is empty for
+// the DEFAULT scope. USER scoped policies are per-user overrides.
+type LimitPolicy struct {
+	ID              int64            `json:"id"`
+	Scope           LimitPolicyScope `json:"scope"`
+	ScopeValue      string           `json:"scope_value"`
+	TransactionType TransactionType  `json:"transaction_type"`
+	LimitValues
+	UpdatedBy int64     `json:"updated_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type LimitPolicyRepository interface {
+	Create(policy *LimitPolicy) error
+	Update(policy *LimitPolicy) error
+	Delete(id int64) error
+	GetByID(id int64) (*LimitPolicy, error)
+	GetAll() ([]*LimitPolicy, error)
+	GetByScope(scope LimitPolicyScope, scopeValue string, transactionType TransactionType) (*LimitPolicy, error)
+	GetApplicable(user *User, transactionType TransactionType) (*LimitPolicy, error)
+}
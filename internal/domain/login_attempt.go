@@ -0,0 +1,41 @@
+// internal/domain/login_attempt.go
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// LoginAttempt records every login try, including those for unknown emails,
+// so failures can be counted per IP address.
+type LoginAttempt struct {
+	ID        int64     `json:"id"`
+	UserID    *int64    `json:"user_id,omitempty"`
+	Email     string    `json:"email"`
+	IPAddress string    `json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+	Success   bool      `json:"success"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// LoginBlockedError is returned when a login is refused before the
+// credentials are checked. Err is ErrAccountLocked or ErrTooManyAttempts, or
+// ErrRateLimited for throttled password reset requests.
+type LoginBlockedError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *LoginBlockedError) Error() string {
+	return fmt.Sprintf("%s, try again in %s", e.Err, e.RetryAfter.Round(time.Second))
+}
+
+func (e *LoginBlockedError) Unwrap() error {
+	return e.Err
+}
+
+type LoginAttemptRepository interface {
+	Create(attempt *LoginAttempt) error
+	CountFailuresByIP(ipAddress string, since time.Time) (int, error)
+}
@@ -0,0 +1,7 @@
+// internal/domain/mail.go
+package domain
+
+// MailSender delivers a plain-text email.
+type MailSender interface {
+	Send(to, subject, body string) error
+}
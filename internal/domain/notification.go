@@ -24,9 +24,16 @@ type Notification struct {
 	NotificationType NotificationType `json:"notification_type"`
 	IsRead           bool             `json:"is_read"`
 	CreatedAt        time.Time        `json:"created_at"`
+	// Channels are the channels the notification is sent on besides the
+	// app. A delivery is queued for each when the notification is created.
+	Channels []NotificationChannel `json:"-"`
+	// Deliveries is the status on each channel, filled in on request
+	Deliveries []*NotificationDelivery `json:"deliveries,omitempty"`
 }
 
 type NotificationRepository interface {
+	// Create stores the notification and queues a delivery for each of its
+	// channels in one transaction.
 	Create(notification *Notification) error
 	GetByID(id int64) (*Notification, error)
 	GetByUserID(userID int64, limit, offset int) ([]*Notification, error)
@@ -0,0 +1,113 @@
+// internal/domain/notification_delivery.go
+package domain
+
+import (
+	"time"
+)
+
+// NotificationChannel is a way a notification reaches the user outside the
+// app. Every notification is also listed in the app.
+type NotificationChannel string
+
+const (
+	NotificationChannelEmail NotificationChannel = "EMAIL"
+	NotificationChannelSMS   NotificationChannel = "SMS"
+	NotificationChannelPush  NotificationChannel = "PUSH"
+)
+
+// DeliveryStatus is the state of one notification on one channel.
+type DeliveryStatus string
+
+const (
+	// DeliveryStatusPending is waiting for its first or next attempt
+	DeliveryStatusPending DeliveryStatus = "PENDING"
+	DeliveryStatusSent    DeliveryStatus = "SENT"
+	// DeliveryStatusSkipped means the user cannot be reached on the channel,
+	// for example a text message to a user without a phone number
+	DeliveryStatusSkipped DeliveryStatus = "SKIPPED"
+	// DeliveryStatusDead failed on every attempt and waits for an admin
+	DeliveryStatusDead DeliveryStatus = "DEAD"
+)
+
+// NotificationDelivery is the outbox row for one notification on one
+// channel. It is written in the same transaction as the notification, and
+// the dispatcher picks it up once NextAttemptAt has passed.
+type NotificationDelivery struct {
+	ID             int64               `json:"id"`
+	NotificationID int64               `json:"notification_id"`
+	UserID         int64               `json:"user_id"`
+	Channel        NotificationChannel `json:"channel"`
+	Status         DeliveryStatus      `json:"status"`
+	Attempts       int                 `json:"attempts"`
+	NextAttemptAt  time.Time           `json:"next_attempt_at"`
+	LastError      string              `json:"last_error,omitempty"`
+	SentAt         *time.Time          `json:"sent_at,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
+
+// NotificationSender delivers a notification to a user on one channel. It
+// returns ErrNoDeliveryAddress when the user has no address on the channel,
+// and the delivery is then skipped instead of retried.
+type NotificationSender interface {
+	Send(user *User, notification *Notification) error
+}
+
+type NotificationDeliveryRepository interface {
+	// ClaimDue returns up to limit pending deliveries that are due and
+	// moves their next attempt lease into the future, so that a second
+	// dispatcher does not pick them up while they are being sent.
+	ClaimDue(limit int, lease time.Duration) ([]*NotificationDelivery, error)
+	// SetOutcome stores the status, attempts, error and next attempt time
+	// of a delivery after an attempt.
+	SetOutcome(delivery *NotificationDelivery) error
+	GetByID(id int64) (*NotificationDelivery, error)
+	GetByNotificationID(notificationID int64) ([]*NotificationDelivery, error)
+	GetDead(channel NotificationChannel, limit, offset int) ([]*NotificationDelivery, error)
+	// Requeue puts a dead delivery back in the outbox with a fresh set of
+	// attempts.
+	Requeue(id int64) error
+}
+
+// DevicePlatform is the push service a device is registered with.
+type DevicePlatform string
+
+const (
+	DevicePlatformAndroid DevicePlatform = "ANDROID"
+	DevicePlatformIOS     DevicePlatform = "IOS"
+)
+
+// PushDevice is an app install that can receive push messages. Token is the
+// registration token issued by FCM or the device token issued by APNs.
+type PushDevice struct {
+	ID         int64          `json:"id"`
+	UserID     int64          `json:"user_id"`
+	Platform   DevicePlatform `json:"platform"`
+	Token      string         `json:"-"`
+	CreatedAt  time.Time      `json:"created_at"`
+	LastSeenAt time.Time      `json:"last_seen_at"`
+}
+
+type PushDeviceRepository interface {
+	// Register stores the device, or moves an already known token to the
+	// user and refreshes it.
+	Register(device *PushDevice) error
+	GetByUserID(userID int64) ([]*PushDevice, error)
+	Delete(id int64, userID int64) error
+	DeleteByToken(token string) error
+}
+
+// PushMessage is the payload of a push message. Data is passed to the app
+// untouched.
+type PushMessage struct {
+	Title string
+	Body  string
+	Data  map[string]string
+}
+
+// PushProvider sends a push message to one device, in the way FCM and APNs
+// do. It returns ErrPushTokenInvalid when the push service no longer accepts
+// the device token, for example after the app was removed.
+type PushProvider interface {
+	Push(device *PushDevice, message *PushMessage) error
+}
@@ -0,0 +1,30 @@
+// internal/domain/password_reset.go
+package domain
+
+import (
+	"time"
+)
+
+// PasswordResetToken is a single-use token emailed to the user. Only its
+// hash is stored.
+type PasswordResetToken struct {
+	ID        int64      `json:"id"`
+	UserID    int64      `json:"user_id"`
+	TokenHash string     `json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type PasswordResetRepository interface {
+	Create(token *PasswordResetToken) error
+	GetByHash(tokenHash string) (*PasswordResetToken, error)
+	// MarkUsed returns false if the token had already been used.
+	MarkUsed(id int64) (bool, error)
+	// InvalidateByUserID marks all of the user's unused tokens as used.
+	InvalidateByUserID(userID int64) error
+	// GetLatest returns the user's most recent token, or nil if there is none.
+	GetLatest(userID int64) (*PasswordResetToken, error)
+	RecordRequest(ipAddress string) error
+	CountRequestsByIP(ipAddress string, since time.Time) (int, error)
+}
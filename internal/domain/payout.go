@@ -0,0 +1,33 @@
+// internal/domain/payout.go
+package domain
+
+import (
+	"time"
+)
+
+// Payout is an outbound transfer from a wallet to a bank account. It is
+// backed by a WITHDRAW transaction on the source wallet.
+type Payout struct {
+	ID                int64             `json:"id"`
+	TransactionID     int64             `json:"transaction_id"`
+	BeneficiaryID     int64             `json:"beneficiary_id"`
+	BankName          string            `json:"bank_name"`
+	AccountNumber     string            `json:"account_number"`
+	AccountName       string            `json:"account_name"`
+	Amount            float64           `json:"amount"`
+	Status            TransactionStatus `json:"status"`
+	ProviderReference string            `json:"provider_reference,omitempty"`
+	CreatedAt         time.Time         `json:"created_at"`
+}
+
+type PayoutRepository interface {
+	Create(payout *Payout) error
+	UpdateStatus(id int64, status TransactionStatus, providerReference string) error
+	HasCompletedPayout(beneficiaryID int64) (bool, error)
+}
+
+// PayoutProvider sends money to a bank account and returns the provider's
+// reference for the payout.
+type PayoutProvider interface {
+	SendPayout(payout *Payout) (string, error)
+}
@@ -0,0 +1,121 @@
+// internal/domain/permission.go
+package domain
+
+import "sort"
+
+// Permission is a single action a staff role may perform, written as
+// "resource:action".
+type Permission string
+
+const (
+	PermissionAuditRead          Permission = "audit:read"
+	PermissionUsersRead          Permission = "users:read"
+	PermissionUsersUnlock        Permission = "users:unlock"
+	PermissionUsersSuspend       Permission = "users:suspend"
+	PermissionUsersLogout        Permission = "users:logout"
+	PermissionUsersReset2FA      Permission = "users:reset_2fa"
+	PermissionUsersImpersonate   Permission = "users:impersonate"
+	PermissionRolesWrite         Permission = "roles:write"
+	PermissionLimitsRead         Permission = "limits:read"
+	PermissionLimitsWrite        Permission = "limits:write"
+	PermissionWalletRead         Permission = "wallet:read"
+	PermissionWalletFreeze       Permission = "wallet:freeze"
+	PermissionWalletAdjust       Permission = "wallet:adjust"
+	PermissionAPIKeysRevoke      Permission = "api_keys:revoke"
+	PermissionKYCReview          Permission = "kyc:review"
+	PermissionNotificationsRead  Permission = "notifications:read"
+	PermissionNotificationsRetry Permission = "notifications:retry"
+)
+
+// RolePermissions maps every role to the permissions it grants. USER and
+// MERCHANT have no staff permissions; ADMIN has all of them.
+var RolePermissions = map[string][]Permission{
+	RoleUser:     {},
+	RoleMerchant: {},
+	RoleSupport: {
+		PermissionUsersRead,
+		PermissionUsersUnlock,
+		PermissionUsersLogout,
+		PermissionUsersReset2FA,
+		PermissionUsersImpersonate,
+		PermissionWalletRead,
+		PermissionWalletFreeze,
+		PermissionNotificationsRead,
+	},
+	RoleCompliance: {
+		PermissionAuditRead,
+		PermissionUsersRead,
+		PermissionUsersSuspend,
+		PermissionUsersLogout,
+		PermissionWalletRead,
+		PermissionWalletFreeze,
+		PermissionWalletAdjust,
+		PermissionLimitsRead,
+		PermissionAPIKeysRevoke,
+		PermissionKYCReview,
+	},
+	RoleFinance: {
+		PermissionAuditRead,
+		PermissionWalletRead,
+		PermissionWalletAdjust,
+		PermissionLimitsRead,
+		PermissionLimitsWrite,
+	},
+	RoleAdmin: {
+		PermissionAuditRead,
+		PermissionUsersRead,
+		PermissionUsersUnlock,
+		PermissionUsersSuspend,
+		PermissionUsersLogout,
+		PermissionUsersReset2FA,
+		PermissionUsersImpersonate,
+		PermissionRolesWrite,
+		PermissionLimitsRead,
+		PermissionLimitsWrite,
+		PermissionWalletRead,
+		PermissionWalletFreeze,
+		PermissionWalletAdjust,
+		PermissionAPIKeysRevoke,
+		PermissionKYCReview,
+		PermissionNotificationsRead,
+		PermissionNotificationsRetry,
+	},
+}
+
+// RoleInfo describes a role and its permissions for the admin API.
+type RoleInfo struct {
+	Role        string       `json:"role"`
+	Permissions []Permission `json:"permissions"`
+}
+
+func ValidRole(role string) bool {
+	_, ok := RolePermissions[role]
+	return ok
+}
+
+// HasPermission reports whether permissions contains every one of required.
+func HasPermission(permissions []Permission, required ...Permission) bool {
+	for _, r := range required {
+		found := false
+		for _, p := range permissions {
+			if p == r {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Roles lists all roles in alphabetical order.
+func Roles() []*RoleInfo {
+	roles := make([]*RoleInfo, 0, len(RolePermissions))
+	for role, permissions := range RolePermissions {
+		roles = append(roles, &RoleInfo{Role: role, Permissions: permissions})
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Role < roles[j].Role })
+	return roles
+}
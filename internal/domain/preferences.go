@@ -0,0 +1,157 @@
+// internal/domain/preferences.go
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// PreferencesVersion is the schema version of the preferences document
+// written by this build. Stored documents with an older version are
+// upgraded by ParsePreferences.
+const PreferencesVersion = 1
+
+const (
+	LanguageVietnamese = "vi"
+	LanguageEnglish    = "en"
+)
+
+// CurrencyDisplay controls how amounts are shown in the apps: "₫", "VND" or
+// "Vietnamese dong".
+type CurrencyDisplay string
+
+const (
+	CurrencyDisplaySymbol CurrencyDisplay = "SYMBOL"
+	CurrencyDisplayCode   CurrencyDisplay = "CODE"
+	CurrencyDisplayName   CurrencyDisplay = "NAME"
+)
+
+// Preferences are the user's settings, stored as a JSONB document on the
+// user row.
+type Preferences struct {
+	Version         int                     `json:"version"`
+	Language        string                  `json:"language"`
+	Timezone        string                  `json:"timezone"`
+	CurrencyDisplay CurrencyDisplay         `json:"currency_display"`
+	Notifications   NotificationPreferences `json:"notifications"`
+	DefaultWalletID *int64                  `json:"default_wallet_id"`
+	Privacy         PrivacyPreferences      `json:"privacy"`
+}
+
+// NotificationPreferences are the channels the user wants notifications on,
+// besides the in-app list. Security notifications are sent on every
+// available channel regardless.
+type NotificationPreferences struct {
+	Email bool `json:"email"`
+	SMS   bool `json:"sms"`
+	Push  bool `json:"push"`
+}
+
+type PrivacyPreferences struct {
+	// HideBalance asks the apps to mask balances until the user taps them
+	HideBalance bool `json:"hide_balance"`
+	// MarketingConsent allows promotional notifications
+	MarketingConsent bool `json:"marketing_consent"`
+}
+
+// DefaultPreferences returns the settings of a user who has not changed
+// anything.
+func DefaultPreferences() *Preferences {
+	return &Preferences{
+		Version:         PreferencesVersion,
+		Language:        LanguageVietnamese,
+		Timezone:        "Asia/Ho_Chi_Minh",
+		CurrencyDisplay: CurrencyDisplaySymbol,
+		Notifications: NotificationPreferences{
+			Email: true,
+			Push:  true,
+		},
+	}
+}
+
+// Validate checks the fields that have a fixed set of values. The default
+// wallet is checked against the user's wallets by the use case.
+func (p *Preferences) Validate() error {
+	switch p.Language {
+	case LanguageVietnamese, LanguageEnglish:
+	default:
+		return fmt.Errorf("%w: language must be %q or %q", ErrInvalidPreferences, LanguageVietnamese, LanguageEnglish)
+	}
+
+	if p.Timezone == "" {
+		return fmt.Errorf("%w: timezone is required", ErrInvalidPreferences)
+	}
+	if _, err := time.LoadLocation(p.Timezone); err != nil {
+		return fmt.Errorf("%w: unknown timezone %q", ErrInvalidPreferences, p.Timezone)
+	}
+
+	switch p.CurrencyDisplay {
+	case CurrencyDisplaySymbol, CurrencyDisplayCode, CurrencyDisplayName:
+	default:
+		return fmt.Errorf("%w: currency_display must be SYMBOL, CODE or NAME", ErrInvalidPreferences)
+	}
+
+	return nil
+}
+
+// preferenceMigrations upgrade a stored document one version at a time:
+// preferenceMigrations[n] turns version n into version n+1. Append a step
+// and bump PreferencesVersion when the schema changes.
+var preferenceMigrations = []func(document map[string]interface{}){
+	// Version 0 is the free-form document written before preferences had a
+	// schema. Only language and timezone were ever set by the apps; anything
+	// else is dropped.
+	func(document map[string]interface{}) {
+		for key := range document {
+			if key != "language" && key != "timezone" {
+				delete(document, key)
+			}
+		}
+	},
+}
+
+// ParsePreferences reads the stored document, upgrading it to the current
+// version. Missing fields take their default, and so do fields an older
+// version stored with a value that is no longer valid.
+func ParsePreferences(raw string) (*Preferences, error) {
+	document := map[string]interface{}{}
+	if raw != "" {
+		if err := json.Unmarshal([]byte(raw), &document); err != nil {
+			return nil, fmt.Errorf("stored preferences are not a JSON object: %v", err)
+		}
+	}
+
+	version := 0
+	if v, ok := document["version"].(float64); ok {
+		version = int(v)
+	}
+	if version > PreferencesVersion {
+		return nil, fmt.Errorf("stored preferences have version %d, newer than %d", version, PreferencesVersion)
+	}
+
+	for ; version < PreferencesVersion; version++ {
+		preferenceMigrations[version](document)
+	}
+	document["version"] = PreferencesVersion
+
+	upgraded, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := DefaultPreferences()
+	if err := json.Unmarshal(upgraded, preferences); err != nil {
+		return nil, fmt.Errorf("stored preferences do not match version %d: %v", PreferencesVersion, err)
+	}
+
+	defaults := DefaultPreferences()
+	if _, err := time.LoadLocation(preferences.Timezone); err != nil || preferences.Timezone == "" {
+		preferences.Timezone = defaults.Timezone
+	}
+	if preferences.Language != LanguageVietnamese && preferences.Language != LanguageEnglish {
+		preferences.Language = defaults.Language
+	}
+
+	return preferences, nil
+}
@@ -0,0 +1,92 @@
+// internal/domain/preferences_test.go
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePreferences(t *testing.T) {
+	walletID := int64(7)
+
+	tests := []struct {
+		name    string
+		raw     string
+		want    func(p *Preferences)
+		wantErr bool
+	}{
+		{
+			name: "empty document gets the defaults",
+			raw:  "",
+			want: func(p *Preferences) {},
+		},
+		{
+			name: "v0 keeps language and timezone and drops everything else",
+			raw:  `{"language":"en","timezone":"Asia/Bangkok","theme":"dark","currency_display":"CODE","notifications":{"email":false}}`,
+			want: func(p *Preferences) {
+				p.Language = LanguageEnglish
+				p.Timezone = "Asia/Bangkok"
+			},
+		},
+		{
+			name: "v0 with values that are no longer valid falls back to the defaults",
+			raw:  `{"language":"fr","timezone":"Mars/Olympus_Mons"}`,
+			want: func(p *Preferences) {},
+		},
+		{
+			name: "v1 is read as stored",
+			raw:  `{"version":1,"language":"en","timezone":"UTC","currency_display":"NAME","notifications":{"email":false,"sms":true,"push":false},"default_wallet_id":7,"privacy":{"hide_balance":true,"marketing_consent":true}}`,
+			want: func(p *Preferences) {
+				p.Language = LanguageEnglish
+				p.Timezone = "UTC"
+				p.CurrencyDisplay = CurrencyDisplayName
+				p.Notifications = NotificationPreferences{SMS: true}
+				p.DefaultWalletID = &walletID
+				p.Privacy = PrivacyPreferences{HideBalance: true, MarketingConsent: true}
+			},
+		},
+		{
+			name: "v1 missing fields take their default",
+			raw:  `{"version":1,"currency_display":"CODE"}`,
+			want: func(p *Preferences) {
+				p.CurrencyDisplay = CurrencyDisplayCode
+			},
+		},
+		{
+			name:    "newer version is refused",
+			raw:     `{"version":2}`,
+			wantErr: true,
+		},
+		{
+			name:    "not an object",
+			raw:     `["en"]`,
+			wantErr: true,
+		},
+		{
+			name:    "v1 field of the wrong type",
+			raw:     `{"version":1,"notifications":{"email":"yes"}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePreferences(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParsePreferences() = %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePreferences(): %v", err)
+			}
+
+			want := DefaultPreferences()
+			tt.want(want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ParsePreferences() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// internal/domain/principal.go
+package domain
+
+const (
+	AuthMethodJWT    = "JWT"
+	AuthMethodAPIKey = "API_KEY"
+)
+
+// Principal is the caller of a request, whether a user signed in with a JWT
+// or a merchant server using an API key. API keys never carry staff
+// permissions and are limited to their scopes.
+//
+// ImpersonatorID is set when an admin acts as the user with a read-only
+// impersonation token. Such principals never carry staff permissions either.
+type Principal struct {
+	UserID         int64        `json:"user_id"`
+	Role           string       `json:"role"`
+	AuthMethod     string       `json:"auth_method"`
+	Permissions    []Permission `json:"permissions,omitempty"`
+	SessionID      int64        `json:"session_id,omitempty"`
+	APIKeyID       int64        `json:"api_key_id,omitempty"`
+	Scopes         []APIScope   `json:"scopes,omitempty"`
+	ImpersonatorID int64        `json:"impersonator_id,omitempty"`
+}
+
+// HasScope reports whether the principal may use an endpoint guarded by
+// scope. Users signed in with a JWT act on their own account and have every
+// scope.
+func (p *Principal) HasScope(scope APIScope) bool {
+	if p.AuthMethod != AuthMethodAPIKey {
+		return true
+	}
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Impersonated reports whether an admin is acting as the user.
+func (p *Principal) Impersonated() bool {
+	return p.ImpersonatorID != 0
+}
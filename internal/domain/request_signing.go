@@ -0,0 +1,25 @@
+// internal/domain/request_signing.go
+package domain
+
+import "time"
+
+// SigningSecret is the shared secret a merchant signs its server-to-server
+// requests with. Unlike API key secrets it has to be stored as is, because
+// the signature is recomputed from it.
+type SigningSecret struct {
+	UserID    int64     `json:"user_id"`
+	Secret    string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type SigningSecretRepository interface {
+	// Save creates or replaces the merchant's secret.
+	Save(secret *SigningSecret) error
+	GetByUserID(userID int64) (*SigningSecret, error)
+}
+
+type RequestNonceRepository interface {
+	// Store records a nonce and reports false if the merchant already used it.
+	Store(userID int64, nonce string, at time.Time) (bool, error)
+	DeleteOlderThan(before time.Time) (int64, error)
+}
@@ -0,0 +1,55 @@
+// internal/domain/session.go
+package domain
+
+import (
+	"time"
+)
+
+// Session is a signed-in device. It lives until it expires or is revoked and
+// is kept alive by rotating refresh tokens, all of which belong to the session.
+//
+// A session with ImpersonatedBy set was opened by that admin to view the
+// account as the user sees it. It has no refresh tokens and is listed with
+// the user's other sessions so they can see and revoke it.
+type Session struct {
+	ID             int64      `json:"id"`
+	UserID         int64      `json:"user_id"`
+	UserAgent      string     `json:"user_agent"`
+	IPAddress      string     `json:"ip_address"`
+	Current        bool       `json:"current"`
+	ImpersonatedBy *int64     `json:"impersonated_by,omitempty"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	LastUsedAt     time.Time  `json:"last_used_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// Active reports whether the session can still be refreshed.
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// RefreshToken is one link in the rotation chain of a session. Only the hash
+// of the token is stored. A token is used exactly once; presenting a used
+// token again means it was stolen.
+type RefreshToken struct {
+	TokenHash string     `json:"-"`
+	SessionID int64      `json:"session_id"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type SessionRepository interface {
+	Create(session *Session) error
+	GetByID(id int64) (*Session, error)
+	GetActiveByUserID(userID int64) ([]*Session, error)
+	Touch(id int64, ipAddress, userAgent string) error
+	Revoke(id int64) error
+	// RevokeAllByUserID revokes every session of the user and stores the
+	// notification, if any, in the same transaction.
+	RevokeAllByUserID(userID int64, notification *Notification) error
+	CreateRefreshToken(token *RefreshToken) error
+	GetRefreshToken(tokenHash string) (*RefreshToken, error)
+	// MarkRefreshTokenUsed returns false if the token had already been used.
+	MarkRefreshTokenUsed(tokenHash string) (bool, error)
+}
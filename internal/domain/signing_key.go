@@ -0,0 +1,49 @@
+// internal/domain/signing_key.go
+package domain
+
+import "time"
+
+// SigningKey is a key pair used to sign JWTs. Only the newest key that has
+// not been retired signs new tokens; retired keys keep verifying tokens until
+// ExpiresAt, after which every token they signed has expired.
+//
+// The private key is only stored encrypted, and is dropped when the key is
+// retired since a retired key never signs again.
+type SigningKey struct {
+	KID                 string     `json:"kid"`
+	Algorithm           string     `json:"algorithm"`
+	EncryptedPrivateKey []byte     `json:"-"`
+	PublicKeyPEM        string     `json:"public_key"`
+	CreatedAt           time.Time  `json:"created_at"`
+	RetiredAt           *time.Time `json:"retired_at,omitempty"`
+	ExpiresAt           *time.Time `json:"expires_at,omitempty"`
+}
+
+func (k *SigningKey) Active() bool {
+	return k.RetiredAt == nil
+}
+
+// JWK is the public part of a signing key as published in the JWKS document
+// (RFC 7517).
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KID       string `json:"kid"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+type SigningKeyRepository interface {
+	Create(key *SigningKey) error
+	// GetVerifiable returns every key that has not expired yet, newest first.
+	GetVerifiable(now time.Time) ([]*SigningKey, error)
+	// RetireAllExcept retires every active key other than kid and deletes
+	// their private keys.
+	RetireAllExcept(kid string, retiredAt, expiresAt time.Time) (int64, error)
+	DeleteExpired(now time.Time) (int64, error)
+}
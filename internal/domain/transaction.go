@@ -12,12 +12,25 @@ const (
 	TransactionTypeDeposit  TransactionType = "DEPOSIT"
 	TransactionTypeWithdraw TransactionType = "WITHDRAW"
 	TransactionTypeTransfer TransactionType = "TRANSFER"
+	// Manual balance corrections posted by admins. They are not customer
+	// payments, so no limits apply to them.
+	TransactionTypeAdjustmentCredit TransactionType = "ADJUSTMENT_CREDIT"
+	TransactionTypeAdjustmentDebit  TransactionType = "ADJUSTMENT_DEBIT"
 
 	TransactionStatusPending   TransactionStatus = "PENDING"
 	TransactionStatusCompleted TransactionStatus = "COMPLETED"
 	TransactionStatusFailed    TransactionStatus = "FAILED"
+	TransactionStatusReversed  TransactionStatus = "REVERSED"
 )
 
+// TransactionTypes lists the customer TransactionTypes that limits apply
+// to, e.g. for reporting per type.
+var TransactionTypes = []TransactionType{
+	TransactionTypeDeposit,
+	TransactionTypeWithdraw,
+	TransactionTypeTransfer,
+}
+
 type Transaction struct {
 	ID                  int64             `json:"id"`
 	SourceWalletID      int64             `json:"source_wallet_id"`
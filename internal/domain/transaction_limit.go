@@ -5,13 +5,146 @@ import (
 	"time"
 )
 
+type LimitWindowMode string
+
+// CALENDAR windows reset at the start of the hour, day and month in the
+// user's timezone. ROLLING windows cover the last 1 hour, 24 hours and 30 days.
+const (
+	LimitWindowCalendar LimitWindowMode = "CALENDAR"
+	LimitWindowRolling  LimitWindowMode = "ROLLING"
+)
+
+// LimitValues are the limit dimensions shared by user-set limits and admin
+// limit policies. A zero value in any of the optional dimensions (everything
+// except DailyLimit and MonthlyLimit) means "no limit".
+type LimitValues struct {
+	DailyLimit             float64         `json:"daily_limit"`
+	MonthlyLimit           float64         `json:"monthly_limit"`
+	MaxSingleAmount        float64         `json:"max_single_amount"`
+	MinAmount              float64         `json:"min_amount"`
+	HourlyCountLimit       int             `json:"hourly_count_limit"`
+	DailyCountLimit        int             `json:"daily_count_limit"`
+	NewRecipientMaxAmount  float64         `json:"new_recipient_max_amount"`
+	NewRecipientDailyLimit float64         `json:"new_recipient_daily_limit"`
+	WindowMode             LimitWindowMode `json:"window_mode"`
+}
+
+// Within reports whether every dimension of v is at least as strict as the
+// corresponding dimension of ceiling. Limits counted over different windows
+// cannot be compared, so the window modes must match.
+func (v LimitValues) Within(ceiling LimitValues) bool {
+	return v.WindowMode == ceiling.WindowMode &&
+		v.DailyLimit <= ceiling.DailyLimit &&
+		v.MonthlyLimit <= ceiling.MonthlyLimit &&
+		v.MinAmount >= ceiling.MinAmount &&
+		withinOptional(v.MaxSingleAmount, ceiling.MaxSingleAmount) &&
+		withinOptional(float64(v.HourlyCountLimit), float64(ceiling.HourlyCountLimit)) &&
+		withinOptional(float64(v.DailyCountLimit), float64(ceiling.DailyCountLimit)) &&
+		withinOptional(v.NewRecipientMaxAmount, ceiling.NewRecipientMaxAmount) &&
+		withinOptional(v.NewRecipientDailyLimit, ceiling.NewRecipientDailyLimit)
+}
+
+// Stricter returns the strictest combination of v and policy, dimension by
+// dimension. The policy's window mode is kept, so a user limit set before the
+// policy changed mode cannot count usage over a more lenient window.
+func (v LimitValues) Stricter(policy LimitValues) LimitValues {
+	return LimitValues{
+		WindowMode:             policy.WindowMode,
+		DailyLimit:             min(v.DailyLimit, policy.DailyLimit),
+		MonthlyLimit:           min(v.MonthlyLimit, policy.MonthlyLimit),
+		MaxSingleAmount:        stricterOptional(v.MaxSingleAmount, policy.MaxSingleAmount),
+		MinAmount:              max(v.MinAmount, policy.MinAmount),
+		HourlyCountLimit:       int(stricterOptional(float64(v.HourlyCountLimit), float64(policy.HourlyCountLimit))),
+		DailyCountLimit:        int(stricterOptional(float64(v.DailyCountLimit), float64(policy.DailyCountLimit))),
+		NewRecipientMaxAmount:  stricterOptional(v.NewRecipientMaxAmount, policy.NewRecipientMaxAmount),
+		NewRecipientDailyLimit: stricterOptional(v.NewRecipientDailyLimit, policy.NewRecipientDailyLimit),
+	}
+}
+
+func withinOptional(value, ceiling float64) bool {
+	if ceiling == 0 {
+		return true
+	}
+	return value > 0 && value <= ceiling
+}
+
+func stricterOptional(a, b float64) float64 {
+	if a == 0 {
+		return b
+	}
+	if b == 0 {
+		return a
+	}
+	return min(a, b)
+}
+
+// TransactionLimit holds the limits a user has configured for one TransactionType.
 type TransactionLimit struct {
 	ID              int64           `json:"id"`
 	UserID          int64           `json:"user_id"`
 	TransactionType TransactionType `json:"transaction_type"`
-	DailyLimit      float64         `json:"daily_limit"`
-	MonthlyLimit    float64         `json:"monthly_limit"`
-	CreatedAt       time.Time       `json:"created_at"`
+	LimitValues
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// LimitWindows are the start times of the hour, day and month windows usage
+// is counted from.
+type LimitWindows struct {
+	HourStart  time.Time
+	DayStart   time.Time
+	MonthStart time.Time
+}
+
+// LimitUsage is what the user has already consumed in the current windows.
+type LimitUsage struct {
+	DailyAmount             float64 `json:"daily_amount"`
+	MonthlyAmount           float64 `json:"monthly_amount"`
+	HourlyCount             int     `json:"hourly_count"`
+	DailyCount              int     `json:"daily_count"`
+	NewRecipientDailyAmount float64 `json:"new_recipient_daily_amount"`
+}
+
+// LimitRemaining is the headroom left in each window. Nil fields mean the
+// dimension is not limited.
+type LimitRemaining struct {
+	DailyAmount             float64  `json:"daily_amount"`
+	MonthlyAmount           float64  `json:"monthly_amount"`
+	HourlyCount             *int     `json:"hourly_count,omitempty"`
+	DailyCount              *int     `json:"daily_count,omitempty"`
+	NewRecipientDailyAmount *float64 `json:"new_recipient_daily_amount,omitempty"`
+}
+
+// LimitStatus reports the effective limit for one TransactionType, i.e. the
+// stricter of the user's own limit and the applicable LimitPolicy.
+type LimitStatus struct {
+	TransactionType TransactionType   `json:"transaction_type"`
+	Limit           *LimitValues      `json:"limit"`
+	UserLimit       *TransactionLimit `json:"user_limit,omitempty"`
+	Policy          *LimitPolicy      `json:"policy,omitempty"`
+	Usage           *LimitUsage       `json:"usage"`
+	Remaining       *LimitRemaining   `json:"remaining"`
+}
+
+// LimitWindowUsage is the consumption and headroom of one window. For
+// CALENDAR windows End is when the window resets; for ROLLING windows it is
+// the time the usage was computed. Nil limits mean the dimension is not limited.
+type LimitWindowUsage struct {
+	Window          string    `json:"window"`
+	Start           time.Time `json:"start"`
+	End             time.Time `json:"end"`
+	Amount          float64   `json:"amount"`
+	Count           int       `json:"count"`
+	AmountLimit     *float64  `json:"amount_limit,omitempty"`
+	RemainingAmount *float64  `json:"remaining_amount,omitempty"`
+	CountLimit      *int      `json:"count_limit,omitempty"`
+	RemainingCount  *int      `json:"remaining_count,omitempty"`
+}
+
+type LimitUsageReport struct {
+	TransactionType TransactionType     `json:"transaction_type"`
+	WindowMode      LimitWindowMode     `json:"window_mode"`
+	Timezone        string              `json:"timezone"`
+	Windows         []*LimitWindowUsage `json:"windows"`
 }
 
 type TransactionLimitRepository interface {
@@ -19,5 +152,11 @@ type TransactionLimitRepository interface {
 	Update(limit *TransactionLimit) error
 	GetByUserAndType(userID int64, transactionType TransactionType) (*TransactionLimit, error)
 	GetByUserID(userID int64) ([]*TransactionLimit, error)
-	CheckLimit(userID int64, transactionType TransactionType, amount float64) error
+	GetUsage(userID int64, transactionType TransactionType, windows LimitWindows) (*LimitUsage, error)
+	// CreateWithinLimit inserts transaction after check has accepted the
+	// user's usage, read in the same database transaction. Calls for the same
+	// user are serialized, so parallel payments cannot pass on the same usage.
+	// A nil check skips the usage query.
+	CreateWithinLimit(userID int64, transaction *Transaction, windows LimitWindows, check func(usage *LimitUsage) error) error
+	HasPaidRecipient(userID int64, destinationWalletID int64) (bool, error)
 }
@@ -0,0 +1,127 @@
+// internal/domain/transaction_limit_test.go
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func policyValues() LimitValues {
+	return LimitValues{
+		DailyLimit:             10000000,
+		MonthlyLimit:           100000000,
+		MaxSingleAmount:        5000000,
+		MinAmount:              10000,
+		HourlyCountLimit:       10,
+		DailyCountLimit:        50,
+		NewRecipientMaxAmount:  2000000,
+		NewRecipientDailyLimit: 3000000,
+		WindowMode:             LimitWindowCalendar,
+	}
+}
+
+func TestLimitValuesWithin(t *testing.T) {
+	tests := []struct {
+		name    string
+		ceiling func(c *LimitValues)
+		value   func(v *LimitValues)
+		want    bool
+	}{
+		{"equal", func(c *LimitValues) {}, func(v *LimitValues) {}, true},
+		{"stricter everywhere", func(c *LimitValues) {}, func(v *LimitValues) {
+			*v = LimitValues{DailyLimit: 1, MonthlyLimit: 1, MaxSingleAmount: 1, MinAmount: 20000, HourlyCountLimit: 1, DailyCountLimit: 1, NewRecipientMaxAmount: 1, NewRecipientDailyLimit: 1, WindowMode: LimitWindowCalendar}
+		}, true},
+		{"higher daily limit", func(c *LimitValues) {}, func(v *LimitValues) { v.DailyLimit++ }, false},
+		{"higher monthly limit", func(c *LimitValues) {}, func(v *LimitValues) { v.MonthlyLimit++ }, false},
+		{"lower minimum amount", func(c *LimitValues) {}, func(v *LimitValues) { v.MinAmount-- }, false},
+
+		// A zero optional dimension means no limit, so it is only within a
+		// ceiling that does not limit that dimension either
+		{"max single amount above ceiling", func(c *LimitValues) {}, func(v *LimitValues) { v.MaxSingleAmount++ }, false},
+		{"max single amount unlimited under a ceiling", func(c *LimitValues) {}, func(v *LimitValues) { v.MaxSingleAmount = 0 }, false},
+		{"max single amount under an unlimited ceiling", func(c *LimitValues) { c.MaxSingleAmount = 0 }, func(v *LimitValues) { v.MaxSingleAmount = 1e12 }, true},
+		{"both max single amounts unlimited", func(c *LimitValues) { c.MaxSingleAmount = 0 }, func(v *LimitValues) { v.MaxSingleAmount = 0 }, true},
+		{"hourly count above ceiling", func(c *LimitValues) {}, func(v *LimitValues) { v.HourlyCountLimit++ }, false},
+		{"hourly count unlimited under a ceiling", func(c *LimitValues) {}, func(v *LimitValues) { v.HourlyCountLimit = 0 }, false},
+		{"hourly count under an unlimited ceiling", func(c *LimitValues) { c.HourlyCountLimit = 0 }, func(v *LimitValues) { v.HourlyCountLimit = 1000 }, true},
+		{"daily count above ceiling", func(c *LimitValues) {}, func(v *LimitValues) { v.DailyCountLimit++ }, false},
+		{"daily count unlimited under a ceiling", func(c *LimitValues) {}, func(v *LimitValues) { v.DailyCountLimit = 0 }, false},
+		{"daily count under an unlimited ceiling", func(c *LimitValues) { c.DailyCountLimit = 0 }, func(v *LimitValues) { v.DailyCountLimit = 1000 }, true},
+		{"new recipient amount above ceiling", func(c *LimitValues) {}, func(v *LimitValues) { v.NewRecipientMaxAmount++ }, false},
+		{"new recipient amount unlimited under a ceiling", func(c *LimitValues) {}, func(v *LimitValues) { v.NewRecipientMaxAmount = 0 }, false},
+		{"new recipient amount under an unlimited ceiling", func(c *LimitValues) { c.NewRecipientMaxAmount = 0 }, func(v *LimitValues) { v.NewRecipientMaxAmount = 1e12 }, true},
+		{"new recipient daily above ceiling", func(c *LimitValues) {}, func(v *LimitValues) { v.NewRecipientDailyLimit++ }, false},
+		{"new recipient daily unlimited under a ceiling", func(c *LimitValues) {}, func(v *LimitValues) { v.NewRecipientDailyLimit = 0 }, false},
+		{"new recipient daily under an unlimited ceiling", func(c *LimitValues) { c.NewRecipientDailyLimit = 0 }, func(v *LimitValues) { v.NewRecipientDailyLimit = 1e12 }, true},
+
+		{"window mode differs", func(c *LimitValues) {}, func(v *LimitValues) { v.WindowMode = LimitWindowRolling }, false},
+		{"both rolling", func(c *LimitValues) { c.WindowMode = LimitWindowRolling }, func(v *LimitValues) { v.WindowMode = LimitWindowRolling }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ceiling, value := policyValues(), policyValues()
+			tt.ceiling(&ceiling)
+			tt.value(&value)
+			if got := value.Within(ceiling); got != tt.want {
+				t.Errorf("Within() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimitValuesStricter(t *testing.T) {
+	tests := []struct {
+		name   string
+		user   LimitValues
+		policy LimitValues
+		want   LimitValues
+	}{
+		{
+			name: "smaller limits and larger minimum win",
+			user: LimitValues{
+				DailyLimit: 5000000, MonthlyLimit: 200000000, MaxSingleAmount: 6000000, MinAmount: 50000,
+				HourlyCountLimit: 5, DailyCountLimit: 60, NewRecipientMaxAmount: 1000000, NewRecipientDailyLimit: 4000000,
+				WindowMode: LimitWindowCalendar,
+			},
+			policy: policyValues(),
+			want: LimitValues{
+				DailyLimit: 5000000, MonthlyLimit: 100000000, MaxSingleAmount: 5000000, MinAmount: 50000,
+				HourlyCountLimit: 5, DailyCountLimit: 50, NewRecipientMaxAmount: 1000000, NewRecipientDailyLimit: 3000000,
+				WindowMode: LimitWindowCalendar,
+			},
+		},
+		{
+			name:   "zero optional dimensions take the other side's limit",
+			user:   LimitValues{DailyLimit: 1000, MonthlyLimit: 2000, WindowMode: LimitWindowCalendar},
+			policy: LimitValues{DailyLimit: 3000, MonthlyLimit: 4000, MaxSingleAmount: 500, HourlyCountLimit: 2, DailyCountLimit: 3, NewRecipientMaxAmount: 100, NewRecipientDailyLimit: 200, WindowMode: LimitWindowCalendar},
+			want:   LimitValues{DailyLimit: 1000, MonthlyLimit: 2000, MaxSingleAmount: 500, HourlyCountLimit: 2, DailyCountLimit: 3, NewRecipientMaxAmount: 100, NewRecipientDailyLimit: 200, WindowMode: LimitWindowCalendar},
+		},
+		{
+			name:   "both unlimited stays unlimited",
+			user:   LimitValues{DailyLimit: 1000, MonthlyLimit: 2000, WindowMode: LimitWindowCalendar},
+			policy: LimitValues{DailyLimit: 3000, MonthlyLimit: 4000, WindowMode: LimitWindowCalendar},
+			want:   LimitValues{DailyLimit: 1000, MonthlyLimit: 2000, WindowMode: LimitWindowCalendar},
+		},
+		{
+			name:   "policy window mode is kept",
+			user:   LimitValues{DailyLimit: 1000, MonthlyLimit: 2000, WindowMode: LimitWindowCalendar},
+			policy: LimitValues{DailyLimit: 3000, MonthlyLimit: 4000, WindowMode: LimitWindowRolling},
+			want:   LimitValues{DailyLimit: 1000, MonthlyLimit: 2000, WindowMode: LimitWindowRolling},
+		},
+		{
+			name:   "policy window mode is kept the other way round",
+			user:   LimitValues{DailyLimit: 1000, MonthlyLimit: 2000, WindowMode: LimitWindowRolling},
+			policy: LimitValues{DailyLimit: 3000, MonthlyLimit: 4000, WindowMode: LimitWindowCalendar},
+			want:   LimitValues{DailyLimit: 1000, MonthlyLimit: 2000, WindowMode: LimitWindowCalendar},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.user.Stricter(tt.policy); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Stricter() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
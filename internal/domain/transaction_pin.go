@@ -0,0 +1,45 @@
+// internal/domain/transaction_pin.go
+package domain
+
+import "time"
+
+// TransactionPIN is the 6-digit PIN a user confirms payments with. It is kept
+// apart from the login password so a stolen access token alone cannot move
+// money above the step-up threshold.
+type TransactionPIN struct {
+	UserID    int64     `json:"user_id"`
+	PINHash   string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TransactionPINStatus tells the client whether a PIN has been set.
+type TransactionPINStatus struct {
+	Set         bool       `json:"set"`
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+}
+
+// StepUpCredentials is what the client presents to confirm a payment that
+// needs step-up: either the transaction PIN or a TOTP code.
+type StepUpCredentials struct {
+	PIN      string
+	TOTPCode string
+}
+
+type TransactionPINRepository interface {
+	Save(pin *TransactionPIN) error
+	GetByUserID(userID int64) (*TransactionPIN, error)
+	// ClaimAttempt counts a step-up attempt before the credential is
+	// checked, so parallel requests cannot make more than max guesses. The
+	// PIN and TOTP codes share the counter. It returns the attempt number,
+	// or false when the user is locked or max attempts are already counted.
+	// A max of zero or less disables the cap.
+	ClaimAttempt(userID int64, max int) (int, bool, error)
+	// Lock starts a step-up lock and stores the notification, if any, in the
+	// same transaction.
+	Lock(userID int64, until time.Time, notification *Notification) error
+	// LockedUntil returns the end of the step-up lock, or nil when the user
+	// is not locked.
+	LockedUntil(userID int64) (*time.Time, error)
+	ResetFailures(userID int64) error
+}
@@ -0,0 +1,46 @@
+// internal/domain/two_factor.go
+package domain
+
+import (
+	"time"
+)
+
+// TwoFactor is a user's TOTP enrollment. It is created disabled when the user
+// starts setup and enabled once they confirm a first code from their app.
+type TwoFactor struct {
+	UserID       int64      `json:"user_id"`
+	Secret       string     `json:"-"`
+	Enabled      bool       `json:"enabled"`
+	LastUsedStep int64      `json:"-"`
+	ConfirmedAt  *time.Time `json:"confirmed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type TwoFactorStatus struct {
+	Enabled                bool       `json:"enabled"`
+	ConfirmedAt            *time.Time `json:"confirmed_at,omitempty"`
+	RecoveryCodesRemaining int        `json:"recovery_codes_remaining"`
+}
+
+type TwoFactorSetup struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type TwoFactorRepository interface {
+	// Save creates or replaces the user's enrollment.
+	Save(twoFactor *TwoFactor) error
+	GetByUserID(userID int64) (*TwoFactor, error)
+	Enable(userID int64) error
+	// Delete removes the enrollment and recovery codes and stores the
+	// notification, if any, in the same transaction.
+	Delete(userID int64, notification *Notification) error
+	// UseStep records step as the last one used and returns false if a code
+	// from that step or a later one was already accepted.
+	UseStep(userID int64, step int64) (bool, error)
+	ReplaceRecoveryCodes(userID int64, codeHashes []string) error
+	// UseRecoveryCode marks the code as used and returns false if it does not
+	// exist or was already used.
+	UseRecoveryCode(userID int64, codeHash string) (bool, error)
+	CountRecoveryCodes(userID int64) (int, error)
+}
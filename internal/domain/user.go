@@ -10,11 +10,19 @@ type UserStatus string
 const (
 	UserStatusActive   UserStatus = "ACTIVE"
 	UserStatusInactive UserStatus = "INACTIVE"
+	// A CLOSED account was closed by its owner. Its personal data has been
+	// replaced with placeholders; financial records are kept until
+	// RetainUntil.
+	UserStatusClosed UserStatus = "CLOSED"
 )
 
 const (
-	RoleUser  = "USER"
-	RoleAdmin = "ADMIN"
+	RoleUser       = "USER"
+	RoleSupport    = "SUPPORT"
+	RoleCompliance = "COMPLIANCE"
+	RoleFinance    = "FINANCE"
+	RoleAdmin      = "ADMIN"
+	RoleMerchant   = "MERCHANT"
 )
 
 type User struct {
@@ -24,10 +32,36 @@ type User struct {
 	PhoneNumber  string     `json:"phone_number"`
 	PasswordHash string     `json:"-"`
 	Status       UserStatus `json:"status"`
-	Preferences  string     `json:"preferences"`
+	Preferences  string     `json:"-"`
 	Role         string     `json:"role"`
-	CreatedAt    time.Time  `json:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at"`
+	KYCTier      int        `json:"kyc_tier"`
+
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	PhoneVerifiedAt *time.Time `json:"phone_verified_at"`
+
+	FailedLoginAttempts int        `json:"-"`
+	LastFailedLoginAt   *time.Time `json:"-"`
+	LockedUntil         *time.Time `json:"locked_until,omitempty"`
+
+	ClosedAt    *time.Time `json:"closed_at,omitempty"`
+	RetainUntil *time.Time `json:"retain_until,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Locked reports whether the account is temporarily locked after too many
+// failed logins.
+func (u *User) Locked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
+}
+
+// AdminUserView is what staff see about a user in the admin API.
+type AdminUserView struct {
+	*User
+	Locked           bool `json:"locked"`
+	TwoFactorEnabled bool `json:"two_factor_enabled"`
+	ActiveSessions   int  `json:"active_sessions"`
 }
 
 type UserRepository interface {
@@ -35,5 +69,34 @@ type UserRepository interface {
 	GetByID(id int64) (*User, error)
 	GetByEmail(email string) (*User, error)
 	Update(user *User) error
-	Delete(id int64) error
+	UpdatePassword(id int64, passwordHash string) error
+	// ResetPassword replaces the password after a reset, clears the failed
+	// login counter and any lock, revokes every session and stores the
+	// notification in one transaction.
+	ResetPassword(id int64, passwordHash string, notification *Notification) error
+	SetVerified(id int64, channel VerificationChannel, at time.Time) error
+	// UpdatePreferences replaces the stored preferences document. Update
+	// does not write preferences, so a profile edit cannot undo a
+	// concurrent preferences change.
+	UpdatePreferences(id int64, preferences string) error
+	// Close closes the account in one transaction: every wallet must be
+	// ACTIVE with a zero balance and is closed, the user's personal data is
+	// replaced with placeholders, and sessions, credentials and other data
+	// that are not financial records are revoked or deleted. Transactions,
+	// payouts, KYC records and audit logs are kept until retainUntil.
+	Close(id int64, closedAt, retainUntil time.Time) error
+	// SetStatus changes the account status and stores the notification
+	// telling the user in the same transaction. Suspending an account also
+	// revokes its sessions there.
+	SetStatus(id int64, status UserStatus, notification *Notification) error
+	// Search matches query against username, email and phone number, newest
+	// users first. An empty query lists all users.
+	Search(query string, limit, offset int) ([]*User, error)
+	// RecordFailedLogin increments the failed login counter and returns it.
+	RecordFailedLogin(id int64) (int, error)
+	// Lock locks the account and stores the notification in the same
+	// transaction.
+	Lock(id int64, until time.Time, notification *Notification) error
+	// ResetFailedLogins clears the failed login counter and any lock.
+	ResetFailedLogins(id int64) error
 }
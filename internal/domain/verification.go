@@ -0,0 +1,43 @@
+// internal/domain/verification.go
+package domain
+
+import (
+	"time"
+)
+
+type VerificationChannel string
+
+const (
+	VerificationChannelEmail VerificationChannel = "EMAIL"
+	VerificationChannelPhone VerificationChannel = "PHONE"
+)
+
+// VerificationCode is a one-time code sent to the user's email or phone.
+// Destination is the address the code was sent to, so a code stops working
+// if the user changes that address in the meantime.
+type VerificationCode struct {
+	ID          int64               `json:"id"`
+	UserID      int64               `json:"user_id"`
+	Channel     VerificationChannel `json:"channel"`
+	Destination string              `json:"destination"`
+	CodeHash    string              `json:"-"`
+	Attempts    int                 `json:"attempts"`
+	ExpiresAt   time.Time           `json:"expires_at"`
+	VerifiedAt  *time.Time          `json:"verified_at,omitempty"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+type VerificationCodeRepository interface {
+	Create(code *VerificationCode) error
+	// GetLatest returns the most recent code for the channel, or nil if none was sent.
+	GetLatest(userID int64, channel VerificationChannel) (*VerificationCode, error)
+	// ClaimAttempt uses up one try of an unverified, unexpired code. It
+	// returns false when the code has no tries left.
+	ClaimAttempt(id int64, max int) (bool, error)
+	MarkVerified(id int64) error
+}
+
+// SMSSender delivers a text message to a phone number.
+type SMSSender interface {
+	SendSMS(phoneNumber, message string) error
+}
@@ -5,19 +5,112 @@ import (
 	"time"
 )
 
+type WalletStatus string
+
+const (
+	WalletStatusActive WalletStatus = "ACTIVE"
+	// A FROZEN wallet can receive money but nothing can leave it.
+	WalletStatusFrozen WalletStatus = "FROZEN"
+	// A BLOCKED wallet can neither send nor receive money.
+	WalletStatusBlocked WalletStatus = "BLOCKED"
+	// A CLOSED wallet was deactivated by its owner.
+	WalletStatusClosed WalletStatus = "CLOSED"
+)
+
 type Wallet struct {
-	ID           int64      `json:"id"`
-	UserID       int64      `json:"user_id"`
-	WalletNumber string     `json:"wallet_number"`
-	Balance      float64    `json:"balance"`
-	Status       UserStatus `json:"status"`
-	CreatedAt    time.Time  `json:"created_at"`
+	ID           int64        `json:"id"`
+	UserID       int64        `json:"user_id"`
+	WalletNumber string       `json:"wallet_number"`
+	Balance      float64      `json:"balance"`
+	Status       WalletStatus `json:"status"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+// CheckDebit reports whether money may leave a wallet with this status.
+func (s WalletStatus) CheckDebit() error {
+	switch s {
+	case WalletStatusActive:
+		return nil
+	case WalletStatusFrozen:
+		return ErrWalletFrozen
+	case WalletStatusBlocked:
+		return ErrWalletBlocked
+	default:
+		return ErrWalletNotFound
+	}
+}
+
+// CheckCredit reports whether money may be paid into a wallet with this
+// status.
+func (s WalletStatus) CheckCredit() error {
+	switch s {
+	case WalletStatusActive, WalletStatusFrozen:
+		return nil
+	case WalletStatusBlocked:
+		return ErrWalletBlocked
+	default:
+		return ErrWalletNotFound
+	}
 }
 
 type WalletRepository interface {
 	Create(wallet *Wallet) error
 	GetByID(id int64) (*Wallet, error)
+	GetByWalletNumber(walletNumber string) (*Wallet, error)
 	GetByUserID(userID int64) ([]*Wallet, error)
+	// UpdateBalance adds amount, which is negative for a debit, to the
+	// balance while holding a row lock. Debits need an ACTIVE wallet,
+	// credits an ACTIVE or FROZEN one.
 	UpdateBalance(id int64, amount float64) error
+	// AdjustBalance is UpdateBalance without the freeze checks, for
+	// returning funds held by a payment that failed.
+	// Closed wallets are still refused.
+	AdjustBalance(id int64, amount float64) error
+	// SetStatus changes the status of a wallet that is not closed and
+	// stores the notification telling the owner in the same transaction.
+	SetStatus(id int64, status WalletStatus, notification *Notification) error
 	Delete(id int64) error
 }
+
+type AdjustmentStatus string
+
+const (
+	AdjustmentStatusPending  AdjustmentStatus = "PENDING"
+	AdjustmentStatusApproved AdjustmentStatus = "APPROVED"
+	AdjustmentStatusRejected AdjustmentStatus = "REJECTED"
+	// FAILED means the adjustment was approved but could not be posted,
+	// for example because the wallet no longer had enough funds.
+	AdjustmentStatusFailed AdjustmentStatus = "FAILED"
+)
+
+// WalletAdjustment is a manual balance correction. Amount is positive for a
+// credit and negative for a debit. One admin proposes it and a different
+// admin must approve it before it is posted.
+type WalletAdjustment struct {
+	ID            int64            `json:"id"`
+	WalletID      int64            `json:"wallet_id"`
+	Amount        float64          `json:"amount"`
+	Reason        string           `json:"reason"`
+	Status        AdjustmentStatus `json:"status"`
+	ProposedBy    int64            `json:"proposed_by"`
+	ReviewedBy    *int64           `json:"reviewed_by,omitempty"`
+	ReviewNote    string           `json:"review_note,omitempty"`
+	ReviewedAt    *time.Time       `json:"reviewed_at,omitempty"`
+	TransactionID *int64           `json:"transaction_id,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
+
+type WalletAdjustmentRepository interface {
+	Create(adjustment *WalletAdjustment) error
+	GetByID(id int64) (*WalletAdjustment, error)
+	GetByStatus(status AdjustmentStatus, limit, offset int) ([]*WalletAdjustment, error)
+	// Review records the decision on a pending adjustment and fails with
+	// ErrAdjustmentReviewed if someone else decided first.
+	Review(adjustment *WalletAdjustment) error
+	// Approve marks a pending adjustment APPROVED and posts it as the given
+	// transaction in one database transaction, locking the wallet row. It
+	// fails with ErrInsufficientFunds if a debit would take the balance below
+	// zero, and leaves the adjustment pending on any error. The notification
+	// telling the owner is stored in the same transaction.
+	Approve(adjustment *WalletAdjustment, transaction *Transaction, notification *Notification) error
+}
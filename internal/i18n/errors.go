@@ -0,0 +1,69 @@
+// internal/i18n/errors.go
+package i18n
+
+import (
+	"GonPay_Backend/internal/domain"
+	"strings"
+)
+
+// rulesWithParam are the validation rules whose message includes the
+// rule's argument.
+var rulesWithParam = map[string]bool{
+	"min":   true,
+	"max":   true,
+	"len":   true,
+	"gt":    true,
+	"oneof": true,
+}
+
+// LocalizeError returns a copy of err with its message and the messages of
+// its rejected fields in lang. Errors are written in English, often with
+// more detail than the catalog has, so English errors are returned as they
+// are. In other languages the message comes from the "error.<CODE>" entry,
+// or stays English when the code has none.
+func LocalizeError(lang string, err *domain.AppError) *domain.AppError {
+	if lang == domain.LanguageEnglish || !Supported(lang) {
+		return err
+	}
+
+	localized := *err
+	if message, ok := catalogs[lang]["error."+string(err.Code)]; ok {
+		localized.Message = message
+	}
+
+	if len(err.Fields) > 0 {
+		localized.Fields = make([]domain.FieldError, len(err.Fields))
+		for i, field := range err.Fields {
+			field.Message = fieldMessage(lang, field)
+			localized.Fields[i] = field
+		}
+	}
+
+	return &localized
+}
+
+// fieldMessage translates a validation message. The catalog key is
+// "validation.<rule>", followed by the unit for length rules and by the
+// expected type for type errors.
+func fieldMessage(lang string, field domain.FieldError) string {
+	key := "validation." + field.Rule
+	switch {
+	case field.Unit != "":
+		key += "." + field.Unit
+	case field.Rule == "type":
+		key += "." + field.Param
+	}
+
+	if _, ok := catalogs[lang][key]; !ok {
+		return field.Message
+	}
+	if !rulesWithParam[field.Rule] {
+		return T(lang, key)
+	}
+
+	param := field.Param
+	if field.Rule == "oneof" {
+		param = strings.Join(strings.Fields(param), ", ")
+	}
+	return T(lang, key, param)
+}
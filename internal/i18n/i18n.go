@@ -0,0 +1,112 @@
+// internal/i18n/i18n.go
+package i18n
+
+import (
+	"GonPay_Backend/internal/domain"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLanguage is used when neither the request nor the user picks a
+// supported language. Most of our users are Vietnamese.
+const DefaultLanguage = domain.LanguageVietnamese
+
+// catalogs holds the messages of every supported language, by key. English
+// is the source language: every key exists in it, and a key missing from
+// another catalog falls back to English.
+var catalogs = map[string]map[string]string{
+	domain.LanguageEnglish:    messagesEN,
+	domain.LanguageVietnamese: messagesVI,
+}
+
+// Supported reports whether there is a catalog for lang.
+func Supported(lang string) bool {
+	_, ok := catalogs[lang]
+	return ok
+}
+
+// T returns the message for key in lang, formatted with args as by
+// fmt.Sprintf. Prefer Locale.T, which also formats dates and amounts.
+func T(lang, key string, args ...interface{}) string {
+	message, ok := lookup(lang, key)
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+func lookup(lang, key string) (string, bool) {
+	if message, ok := catalogs[lang][key]; ok {
+		return message, true
+	}
+	message, ok := messagesEN[key]
+	return message, ok
+}
+
+// MatchAcceptLanguage returns the supported language the Accept-Language
+// header prefers most, or "" when it names none. Region subtags are ignored,
+// so "en-US" selects English.
+func MatchAcceptLanguage(header string) string {
+	type candidate struct {
+		lang    string
+		quality float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					quality = q
+				}
+			}
+		}
+
+		lang := strings.SplitN(tag, "-", 2)[0]
+		if quality > 0 && Supported(lang) {
+			candidates = append(candidates, candidate{lang: lang, quality: quality})
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	// Stable, so equal weights keep the order the client listed them in
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	return candidates[0].lang
+}
+
+// WithLanguage returns a copy of ctx that carries lang.
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, "language", lang)
+}
+
+// FromContext returns the language chosen for a request, or DefaultLanguage.
+func FromContext(ctx context.Context) string {
+	if lang, ok := ctx.Value("language").(string); ok && Supported(lang) {
+		return lang
+	}
+	return DefaultLanguage
+}
+
+// HasLanguage reports whether a language was chosen for the request.
+func HasLanguage(ctx context.Context) bool {
+	_, ok := ctx.Value("language").(string)
+	return ok
+}
@@ -0,0 +1,201 @@
+// internal/i18n/locale.go
+package i18n
+
+import (
+	"GonPay_Backend/internal/domain"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Currencies amounts can be formatted in. Wallets hold VND.
+const (
+	CurrencyVND = "VND"
+	CurrencyUSD = "USD"
+)
+
+// Money is an amount in a currency. Passed to Locale.T it is formatted for
+// the locale.
+type Money struct {
+	Amount   float64
+	Currency string
+}
+
+// VND returns amount as Money in Vietnamese dong.
+func VND(amount float64) Money {
+	return Money{Amount: amount, Currency: CurrencyVND}
+}
+
+type currencyFormat struct {
+	decimals int
+	symbol   string
+	names    map[string]string
+}
+
+var currencies = map[string]currencyFormat{
+	CurrencyVND: {
+		decimals: 0,
+		symbol:   "₫",
+		names:    map[string]string{domain.LanguageVietnamese: "đồng", domain.LanguageEnglish: "Vietnamese dong"},
+	},
+	CurrencyUSD: {
+		decimals: 2,
+		symbol:   "$",
+		names:    map[string]string{domain.LanguageVietnamese: "đô la Mỹ", domain.LanguageEnglish: "US dollars"},
+	},
+}
+
+// Locale is how text for one reader is written: the language, the time zone
+// dates are shown in and how currencies are labelled.
+type Locale struct {
+	Language        string
+	Location        *time.Location
+	CurrencyDisplay domain.CurrencyDisplay
+}
+
+// DefaultLocale is the locale of a reader with default preferences.
+func DefaultLocale() Locale {
+	return LocaleFor(domain.DefaultPreferences())
+}
+
+// LocaleFor returns the locale described by a user's preferences.
+func LocaleFor(preferences *domain.Preferences) Locale {
+	locale := Locale{
+		Language:        preferences.Language,
+		Location:        time.UTC,
+		CurrencyDisplay: preferences.CurrencyDisplay,
+	}
+	if !Supported(locale.Language) {
+		locale.Language = DefaultLanguage
+	}
+	if location, err := time.LoadLocation(preferences.Timezone); err == nil {
+		locale.Location = location
+	}
+	return locale
+}
+
+// UserLocale returns the locale of user, or the default locale when their
+// stored preferences cannot be read.
+func UserLocale(user *domain.User) Locale {
+	preferences, err := domain.ParsePreferences(user.Preferences)
+	if err != nil {
+		return DefaultLocale()
+	}
+	return LocaleFor(preferences)
+}
+
+// T returns the message for key in the locale's language. time.Time and
+// Money arguments are formatted for the locale first.
+func (l Locale) T(key string, args ...interface{}) string {
+	formatted := make([]interface{}, len(args))
+	for i, arg := range args {
+		switch value := arg.(type) {
+		case time.Time:
+			formatted[i] = l.DateTime(value)
+		case Money:
+			formatted[i] = l.Money(value)
+		default:
+			formatted[i] = arg
+		}
+	}
+	return T(l.Language, key, formatted...)
+}
+
+// Number formats value with the given number of decimals, grouping
+// thousands as the language does: 1.234.567,5 in Vietnamese and
+// 1,234,567.5 in English.
+func (l Locale) Number(value float64, decimals int) string {
+	groupSeparator, decimalSeparator := ".", ","
+	if l.Language == domain.LanguageEnglish {
+		groupSeparator, decimalSeparator = ",", "."
+	}
+
+	digits := strconv.FormatFloat(math.Abs(value), 'f', decimals, 64)
+	integer, fraction := digits, ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		integer, fraction = digits[:i], digits[i+1:]
+	}
+
+	var b strings.Builder
+	if value < 0 && strings.Trim(digits, "0.") != "" {
+		b.WriteByte('-')
+	}
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(groupSeparator)
+		}
+		b.WriteRune(digit)
+	}
+	if fraction != "" {
+		b.WriteString(decimalSeparator)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
+
+// Money formats an amount with its currency, following the user's
+// currency_display preference. In Vietnamese the currency follows the
+// amount (1.500.000 ₫); in English a symbol or code comes first
+// (₫1,500,000).
+func (l Locale) Money(money Money) string {
+	format, ok := currencies[money.Currency]
+	if !ok {
+		format = currencyFormat{decimals: 2, symbol: money.Currency}
+	}
+	amount := l.Number(money.Amount, format.decimals)
+
+	switch l.CurrencyDisplay {
+	case domain.CurrencyDisplayCode:
+		if l.Language == domain.LanguageEnglish {
+			return money.Currency + " " + amount
+		}
+		return amount + " " + money.Currency
+	case domain.CurrencyDisplayName:
+		name, ok := format.names[l.Language]
+		if !ok {
+			name = money.Currency
+		}
+		return amount + " " + name
+	default:
+		if l.Language == domain.LanguageEnglish {
+			if strings.HasPrefix(amount, "-") {
+				return "-" + format.symbol + amount[1:]
+			}
+			return format.symbol + amount
+		}
+		symbol := format.symbol
+		if money.Currency == CurrencyUSD {
+			symbol = "US$"
+		}
+		return amount + " " + symbol
+	}
+}
+
+// DateTime formats t in the locale's time zone, with the UTC offset so the
+// reader can tell which zone is meant.
+func (l Locale) DateTime(t time.Time) string {
+	t = t.In(l.location())
+	offset := "UTC" + t.Format("-07:00")
+	if l.Language == domain.LanguageEnglish {
+		return fmt.Sprintf("%s (%s)", t.Format("Jan 2, 2006 3:04 PM"), offset)
+	}
+	return fmt.Sprintf("%s (%s)", t.Format("15:04 02/01/2006"), offset)
+}
+
+// Date formats the calendar date of t in the locale's time zone.
+func (l Locale) Date(t time.Time) string {
+	t = t.In(l.location())
+	if l.Language == domain.LanguageEnglish {
+		return t.Format("Jan 2, 2006")
+	}
+	return t.Format("02/01/2006")
+}
+
+func (l Locale) location() *time.Location {
+	if l.Location == nil {
+		return time.UTC
+	}
+	return l.Location
+}
@@ -0,0 +1,120 @@
+// internal/i18n/locale_test.go
+package i18n
+
+import (
+	"GonPay_Backend/internal/domain"
+	"context"
+	"math"
+	"testing"
+)
+
+func TestNumber(t *testing.T) {
+	tests := []struct {
+		language string
+		value    float64
+		decimals int
+		want     string
+	}{
+		{domain.LanguageVietnamese, 1234567.5, 1, "1.234.567,5"},
+		{domain.LanguageEnglish, 1234567.5, 1, "1,234,567.5"},
+		{domain.LanguageEnglish, 123456789, 0, "123,456,789"},
+		{domain.LanguageVietnamese, 100000, 0, "100.000"},
+		{domain.LanguageVietnamese, 1000, 0, "1.000"},
+		{domain.LanguageVietnamese, 999, 0, "999"},
+		{domain.LanguageVietnamese, 0, 0, "0"},
+		{domain.LanguageEnglish, -1234, 0, "-1,234"},
+		{domain.LanguageEnglish, 999.6, 0, "1,000"},
+		// Values that round to zero are not shown as negative
+		{domain.LanguageEnglish, math.Copysign(0, -1), 0, "0"},
+		{domain.LanguageVietnamese, -0.001, 2, "0,00"},
+		{domain.LanguageEnglish, -0.4, 0, "0"},
+	}
+
+	for _, tt := range tests {
+		locale := Locale{Language: tt.language}
+		if got := locale.Number(tt.value, tt.decimals); got != tt.want {
+			t.Errorf("Number(%s, %v, %d) = %q, want %q", tt.language, tt.value, tt.decimals, got, tt.want)
+		}
+	}
+}
+
+func TestMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		display  domain.CurrencyDisplay
+		money    Money
+		want     string
+	}{
+		{"vi symbol VND", domain.LanguageVietnamese, domain.CurrencyDisplaySymbol, VND(1234567), "1.234.567 ₫"},
+		{"en symbol VND", domain.LanguageEnglish, domain.CurrencyDisplaySymbol, VND(1234567), "₫1,234,567"},
+		{"en symbol negative USD", domain.LanguageEnglish, domain.CurrencyDisplaySymbol, Money{-1234.5, CurrencyUSD}, "-$1,234.50"},
+		{"vi symbol USD", domain.LanguageVietnamese, domain.CurrencyDisplaySymbol, Money{1234.5, CurrencyUSD}, "1.234,50 US$"},
+		{"vi symbol negative VND", domain.LanguageVietnamese, domain.CurrencyDisplaySymbol, VND(-50000), "-50.000 ₫"},
+		{"VND has no decimals", domain.LanguageEnglish, domain.CurrencyDisplaySymbol, VND(1500000.4), "₫1,500,000"},
+		{"vi code", domain.LanguageVietnamese, domain.CurrencyDisplayCode, VND(1500000), "1.500.000 VND"},
+		{"en code", domain.LanguageEnglish, domain.CurrencyDisplayCode, VND(1500000), "VND 1,500,000"},
+		{"en code USD", domain.LanguageEnglish, domain.CurrencyDisplayCode, Money{2, CurrencyUSD}, "USD 2.00"},
+		{"vi name", domain.LanguageVietnamese, domain.CurrencyDisplayName, VND(1500000), "1.500.000 đồng"},
+		{"en name", domain.LanguageEnglish, domain.CurrencyDisplayName, VND(1500000), "1,500,000 Vietnamese dong"},
+		{"en name USD", domain.LanguageEnglish, domain.CurrencyDisplayName, Money{2, CurrencyUSD}, "2.00 US dollars"},
+		{"unset display uses the symbol", domain.LanguageVietnamese, "", VND(1000), "1.000 ₫"},
+		{"unknown currency uses its code", domain.LanguageVietnamese, domain.CurrencyDisplaySymbol, Money{12, "EUR"}, "12,00 EUR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locale := Locale{Language: tt.language, CurrencyDisplay: tt.display}
+			if got := locale.Money(tt.money); got != tt.want {
+				t.Errorf("Money() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"vi;q=0.5, en", domain.LanguageEnglish},
+		{"en-US,en;q=0.9,vi;q=0.8", domain.LanguageEnglish},
+		{"EN-gb", domain.LanguageEnglish},
+		{" en ; q=0.7 , vi ; q=0.8", domain.LanguageVietnamese},
+		{"fr, vi;q=0.1", domain.LanguageVietnamese},
+		// Equal weights keep the order the client listed them in
+		{"vi, en", domain.LanguageVietnamese},
+		{"en;q=0.5, vi;q=0.5", domain.LanguageEnglish},
+		// q=0 means "not acceptable"
+		{"en;q=0, vi;q=0.2", domain.LanguageVietnamese},
+		// An unparsable weight counts as 1
+		{"vi;q=0.9, en;q=high", domain.LanguageEnglish},
+		{"fr-FR, de", ""},
+		{"*", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := MatchAcceptLanguage(tt.header); got != tt.want {
+			t.Errorf("MatchAcceptLanguage(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestFromContextFallsBackToDefault(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"no language chosen", context.Background(), DefaultLanguage},
+		{"unsupported language", WithLanguage(context.Background(), "fr"), DefaultLanguage},
+		{"supported language", WithLanguage(context.Background(), domain.LanguageEnglish), domain.LanguageEnglish},
+	}
+
+	for _, tt := range tests {
+		if got := FromContext(tt.ctx); got != tt.want {
+			t.Errorf("%s: FromContext() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
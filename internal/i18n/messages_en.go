@@ -0,0 +1,108 @@
+// internal/i18n/messages_en.go
+package i18n
+
+// messagesEN is the English catalog. Keys are grouped by prefix:
+// validation.* for field errors, message.* for success responses, mail.*
+// for email and SMS text and notification.* for in-app notifications, with a
+// .title and a .body each. Error messages are written in English where the
+// error is defined, so there are no error.* entries here.
+var messagesEN = map[string]string{
+	"validation.required":         "is required",
+	"validation.min":              "must be at least %s",
+	"validation.min.characters":   "must be at least %s characters",
+	"validation.min.items":        "must have at least %s items",
+	"validation.max":              "must be at most %s",
+	"validation.max.characters":   "must be at most %s characters",
+	"validation.max.items":        "must have at most %s items",
+	"validation.len.characters":   "must be exactly %s characters",
+	"validation.len.items":        "must have exactly %s items",
+	"validation.gt":               "must be greater than %s",
+	"validation.email":            "must be a valid email address",
+	"validation.oneof":            "must be one of %s",
+	"validation.type.number":      "must be of type number",
+	"validation.type.string":      "must be of type string",
+	"validation.type.boolean":     "must be of type boolean",
+	"validation.type.array":       "must be of type array",
+	"validation.type.object":      "must be of type object",
+	"validation.unknown":          "is not a known field",
+	"message.password_changed":    "Password changed successfully",
+	"message.password_reset":      "Password reset successfully",
+	"message.password_reset_sent": "If an account exists for this email, a password reset link has been sent",
+	"message.code_sent":           "Verification code sent successfully",
+	"message.verified":            "Verified successfully",
+	"message.account_closed":      "Account closed successfully",
+	"message.wallet_deactivated":  "Wallet deactivated successfully",
+	"message.pin_set":             "Transaction PIN set successfully",
+	"message.pin_changed":         "Transaction PIN changed successfully",
+	"message.notification_read":   "Notification marked as read",
+	"message.notifications_read":  "All notifications marked as read",
+	"message.user_suspended":      "User suspended successfully",
+	"message.user_reactivated":    "User reactivated successfully",
+	"message.user_signed_out":     "User signed out successfully",
+	"message.user_2fa_reset":      "Two-factor authentication reset successfully",
+	"message.user_unlocked":       "User unlocked successfully",
+	"message.logged_out":          "Logged out successfully",
+	"message.session_revoked":     "Session revoked successfully",
+	"message.api_key_revoked":     "API key revoked successfully",
+	"message.beneficiary_deleted": "Beneficiary deleted successfully",
+	"message.impersonation_ended": "Impersonation ended successfully",
+	"message.policy_deleted":      "Policy deleted successfully",
+	"message.payment_deleted":     "Payment method deleted successfully",
+	"message.payment_default":     "Payment method set as default successfully",
+	"message.2fa_disabled":        "Two-factor authentication disabled successfully",
+	"message.device_removed":      "Device removed successfully",
+
+	"mail.verification_code.subject": "Verify your GonPay email",
+	"mail.verification_code.body":    "Your GonPay verification code is %s. It expires in %d minutes. Never share this code.",
+	"mail.password_reset.subject":    "Reset your GonPay password",
+	"mail.password_reset.body":       "Hi %s,\n\nWe received a request to reset your GonPay password. Open the link below within %d minutes to choose a new one:\n\n%s\n\nIf you did not ask for this, you can ignore this email; your password will not change.\n",
+
+	"notification.account_suspended.title":         "Account suspended",
+	"notification.account_suspended.body":          "Your account was suspended: %s. Contact support for help.",
+	"notification.account_reactivated.title":       "Account reactivated",
+	"notification.account_reactivated.body":        "Your account was reactivated. You can sign in again.",
+	"notification.signed_out_everywhere.title":     "Signed out of all devices",
+	"notification.signed_out_everywhere.body":      "An administrator signed you out of all devices. Sign in again to continue.",
+	"notification.two_factor_reset.title":          "Two-factor authentication reset",
+	"notification.two_factor_reset.body":           "An administrator turned off two-factor authentication on your account. Set it up again to keep your account protected.",
+	"notification.password_reset.title":            "Password reset",
+	"notification.password_reset.body":             "Your password was reset and all devices were signed out. If this wasn't you, contact support immediately.",
+	"notification.account_locked.title":            "Account temporarily locked",
+	"notification.account_locked.body":             "Your account was locked until %s after %d failed sign-in attempts. If this wasn't you, change your password once the lock ends.",
+	"notification.account_unlocked.title":          "Account unlocked",
+	"notification.account_unlocked.body":           "An administrator unlocked your account. You can sign in again.",
+	"notification.pin_changed.title":               "Transaction PIN changed",
+	"notification.pin_changed.body":                "Your transaction PIN was changed. If this wasn't you, contact support immediately.",
+	"notification.pin_locked.title":                "Payment confirmation locked",
+	"notification.pin_locked.body":                 "Payment confirmation with your PIN or authenticator code was locked until %s after %d wrong attempts. If this wasn't you, change your password.",
+	"notification.kyc_approved.title":              "Identity verified",
+	"notification.kyc_approved.body":               "Your identity was verified. Your account is now at KYC tier %d.",
+	"notification.kyc_rejected.title":              "Identity verification rejected",
+	"notification.kyc_rejected.body":               "Your identity verification was rejected: %s. You can submit new documents.",
+	"notification.impersonation_started.title":     "Support viewed your account",
+	"notification.impersonation_started.body":      "A GonPay support agent opened a read-only view of your account: %s. It is listed with your signed-in devices until %s and cannot move money.",
+	"notification.api_key_revoked.title":           "API key revoked",
+	"notification.api_key_revoked.body":            "An administrator revoked your API key %q (%s). Requests using it are now refused.",
+	"notification.token_reused.title":              "Device signed out",
+	"notification.token_reused.body":               "A sign-in token for one of your devices was used twice, so we signed that device out. If you did not expect this, change your password.",
+	"notification.data_export_failed.title":        "Data export failed",
+	"notification.data_export_failed.body":         "We could not prepare a copy of your data. Please request it again.",
+	"notification.data_export_ready.title":         "Your data is ready",
+	"notification.data_export_ready.body":          "A copy of your data is ready to download until %s.",
+	"notification.wallet_frozen.title":             "Wallet frozen",
+	"notification.wallet_frozen.body":              "Your wallet %s was frozen: %s. You can still receive money, but payments and withdrawals are on hold. Contact support for help.",
+	"notification.wallet_blocked.title":            "Wallet blocked",
+	"notification.wallet_blocked.body":             "Your wallet %s was blocked: %s. No money can be sent from or paid into it. Contact support for help.",
+	"notification.wallet_restored.title":           "Wallet restored",
+	"notification.wallet_restored.body":            "Your wallet %s can be used again: %s.",
+	"notification.balance_credited.title":          "Balance adjusted",
+	"notification.balance_credited.body":           "%s was credited to your wallet %s: %s",
+	"notification.balance_debited.title":           "Balance adjusted",
+	"notification.balance_debited.body":            "%s was debited from your wallet %s: %s",
+	"notification.beneficiary_added.title":         "New beneficiary added",
+	"notification.beneficiary_added.body":          "%s was added to your beneficiaries. Until %s, each transfer to them is limited to %s. If this wasn't you, secure your account now.",
+	"notification.beneficiary_unrestricted.title":  "Beneficiary restriction lifted",
+	"notification.beneficiary_unrestricted.body":   "Transfers to %s are no longer capped.",
+	"notification.beneficiary_name_mismatch.title": "Beneficiary name does not match",
+	"notification.beneficiary_name_mismatch.body":  "The account %s is held by %s, not %s. Check the details before sending money.",
+}
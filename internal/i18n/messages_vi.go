@@ -0,0 +1,177 @@
+// internal/i18n/messages_vi.go
+package i18n
+
+// messagesVI is the Vietnamese catalog. It has the same keys as messagesEN
+// plus an error.<CODE> entry for every error code. Arguments use explicit
+// indexes where Vietnamese word order differs from English.
+var messagesVI = map[string]string{
+	"error.INVALID_REQUEST":        "Yêu cầu không hợp lệ",
+	"error.VALIDATION_FAILED":      "Dữ liệu không hợp lệ",
+	"error.UNAUTHENTICATED":        "Bạn cần đăng nhập để thực hiện thao tác này",
+	"error.FORBIDDEN":              "Bạn không có quyền thực hiện thao tác này",
+	"error.NOT_FOUND":              "Không tìm thấy dữ liệu",
+	"error.CONFLICT":               "Thao tác xung đột với trạng thái hiện tại",
+	"error.ALREADY_EXISTS":         "Dữ liệu đã tồn tại",
+	"error.INVALID_REFERENCE":      "Dữ liệu được tham chiếu không tồn tại",
+	"error.PAYLOAD_TOO_LARGE":      "Dữ liệu gửi lên quá lớn",
+	"error.UNSUPPORTED_MEDIA_TYPE": "Định dạng dữ liệu không được hỗ trợ",
+	"error.RATE_LIMITED":           "Bạn gửi quá nhiều yêu cầu, vui lòng thử lại sau",
+	"error.INTERNAL_ERROR":         "Đã xảy ra lỗi hệ thống, vui lòng thử lại sau",
+	"error.INVALID_CREDENTIALS":    "Thông tin đăng nhập không đúng",
+	"error.USER_NOT_FOUND":         "Không tìm thấy người dùng",
+	"error.ACCOUNT_INACTIVE":       "Tài khoản chưa được kích hoạt hoặc đã bị tạm khóa",
+	"error.ACCOUNT_CLOSED":         "Tài khoản đã bị đóng",
+	"error.ACCOUNT_LOCKED":         "Tài khoản đang tạm thời bị khóa",
+	"error.TOO_MANY_ATTEMPTS":      "Bạn đã đăng nhập sai quá nhiều lần, vui lòng thử lại sau",
+	"error.WALLET_NOT_FOUND":       "Không tìm thấy ví",
+	"error.WALLET_FROZEN":          "Ví đang bị đóng băng, chỉ có thể nhận tiền",
+	"error.WALLET_BLOCKED":         "Ví đã bị khóa",
+	"error.INSUFFICIENT_FUNDS":     "Số dư không đủ",
+	"error.INVALID_AMOUNT":         "Số tiền không hợp lệ",
+	"error.INVALID_OPERATION":      "Thao tác không hợp lệ",
+	"error.LIMIT_EXCEEDED":         "Giao dịch vượt quá hạn mức",
+	"error.LIMIT_ABOVE_POLICY":     "Hạn mức vượt quá mức chính sách cho phép",
+	"error.ACCOUNT_NOT_FOUND":      "Không tìm thấy tài khoản",
+	"error.INVALID_TOKEN":          "Token không hợp lệ hoặc đã hết hạn",
+	"error.TOKEN_REUSED":           "Refresh token đã bị sử dụng lại, phiên đăng nhập đã bị thu hồi",
+	"error.SESSION_NOT_FOUND":      "Không tìm thấy phiên đăng nhập",
+	"error.INVALID_2FA_CODE":       "Mã xác thực hai lớp không đúng",
+	"error.2FA_NOT_ENABLED":        "Xác thực hai lớp chưa được bật",
+	"error.2FA_ALREADY_ENABLED":    "Xác thực hai lớp đã được bật",
+	"error.INVALID_OTP":            "Mã xác minh không đúng hoặc đã hết hạn",
+	"error.OTP_RESEND_TOO_SOON":    "Mã xác minh vừa được gửi, vui lòng đợi trước khi yêu cầu mã mới",
+	"error.ALREADY_VERIFIED":       "Thông tin đã được xác minh",
+	"error.VERIFICATION_REQUIRED":  "Tài khoản cần được xác minh",
+	"error.PIN_NOT_SET":            "Bạn chưa đặt mã PIN giao dịch",
+	"error.PIN_ALREADY_SET":        "Mã PIN giao dịch đã được đặt",
+	"error.INVALID_PIN":            "Mã PIN giao dịch không đúng",
+	"error.WEAK_PIN":               "Mã PIN giao dịch phải gồm 6 chữ số và không dễ đoán",
+	"error.PIN_LOCKED":             "Xác nhận thanh toán đang tạm thời bị khóa do nhập sai PIN hoặc mã quá nhiều lần",
+	"error.STEP_UP_REQUIRED":       "Giao dịch này cần được xác nhận bằng mã PIN giao dịch hoặc mã xác thực hai lớp",
+	"error.INVALID_ROLE":           "Vai trò không hợp lệ",
+	"error.API_KEY_NOT_FOUND":      "Không tìm thấy API key",
+	"error.INVALID_API_KEY":        "API key không hợp lệ, đã hết hạn hoặc đã bị thu hồi",
+	"error.INVALID_SCOPE":          "Phạm vi API key không hợp lệ",
+	"error.MERCHANT_ONLY":          "Chỉ tài khoản merchant mới được dùng API key",
+	"error.INVALID_SIGNATURE":      "Chữ ký request không hợp lệ",
+	"error.STALE_REQUEST":          "Thời điểm của request quá cũ hoặc ở tương lai",
+	"error.REPLAYED_REQUEST":       "Nonce của request đã được sử dụng",
+	"error.SIGNING_SECRET_UNSET":   "Chưa thiết lập khóa ký request",
+	"error.KYC_NOT_FOUND":          "Không tìm thấy hồ sơ KYC",
+	"error.KYC_PENDING":            "Đã có hồ sơ KYC đang chờ duyệt",
+	"error.KYC_REVIEWED":           "Hồ sơ KYC đã được duyệt",
+	"error.INVALID_KYC_TIER":       "Cấp KYC không hợp lệ",
+	"error.INVALID_KYC_DOCUMENT":   "Giấy tờ KYC không hợp lệ",
+	"error.BALANCE_CAP_EXCEEDED":   "Số dư sẽ vượt quá mức tối đa cho cấp KYC của bạn",
+	"error.ADJUSTMENT_NOT_FOUND":   "Không tìm thấy yêu cầu điều chỉnh số dư",
+	"error.ADJUSTMENT_REVIEWED":    "Yêu cầu điều chỉnh số dư đã được duyệt",
+	"error.NON_ZERO_BALANCE":       "Tất cả các ví phải có số dư bằng 0",
+	"error.DATA_EXPORT_NOT_FOUND":  "Không tìm thấy bản xuất dữ liệu",
+	"error.DATA_EXPORT_PENDING":    "Một bản xuất dữ liệu đang được chuẩn bị",
+	"error.DATA_EXPORT_NOT_READY":  "Bản xuất dữ liệu chưa sẵn sàng hoặc đã hết hạn",
+	"error.INVALID_PREFERENCES":    "Cài đặt không hợp lệ",
+	"error.DELIVERY_NOT_FOUND":     "Không tìm thấy lượt gửi thông báo",
+	"error.DELIVERY_NOT_DEAD":      "Chỉ có thể gửi lại các thông báo đã gửi thất bại",
+	"error.NO_DELIVERY_ADDRESS":    "Người dùng chưa có địa chỉ nhận trên kênh này",
+	"error.PUSH_DEVICE_NOT_FOUND":  "Không tìm thấy thiết bị nhận thông báo",
+	"error.PUSH_TOKEN_INVALID":     "Mã thiết bị nhận thông báo không còn hiệu lực",
+
+	"validation.required":         "là bắt buộc",
+	"validation.min":              "phải lớn hơn hoặc bằng %s",
+	"validation.min.characters":   "phải có ít nhất %s ký tự",
+	"validation.min.items":        "phải có ít nhất %s phần tử",
+	"validation.max":              "phải nhỏ hơn hoặc bằng %s",
+	"validation.max.characters":   "không được vượt quá %s ký tự",
+	"validation.max.items":        "không được vượt quá %s phần tử",
+	"validation.len.characters":   "phải có đúng %s ký tự",
+	"validation.len.items":        "phải có đúng %s phần tử",
+	"validation.gt":               "phải lớn hơn %s",
+	"validation.email":            "không phải địa chỉ email hợp lệ",
+	"validation.oneof":            "phải là một trong các giá trị: %s",
+	"validation.type.number":      "phải là số",
+	"validation.type.string":      "phải là chuỗi",
+	"validation.type.boolean":     "phải là true hoặc false",
+	"validation.type.array":       "phải là mảng",
+	"validation.type.object":      "phải là object",
+	"validation.unknown":          "không phải trường hợp lệ",
+	"message.password_changed":    "Đổi mật khẩu thành công",
+	"message.password_reset":      "Đặt lại mật khẩu thành công",
+	"message.password_reset_sent": "Nếu email này đã đăng ký tài khoản, liên kết đặt lại mật khẩu đã được gửi",
+	"message.code_sent":           "Đã gửi mã xác minh",
+	"message.verified":            "Xác minh thành công",
+	"message.account_closed":      "Đóng tài khoản thành công",
+	"message.wallet_deactivated":  "Đã ngừng sử dụng ví",
+	"message.pin_set":             "Đặt mã PIN giao dịch thành công",
+	"message.pin_changed":         "Đổi mã PIN giao dịch thành công",
+	"message.notification_read":   "Đã đánh dấu thông báo là đã đọc",
+	"message.notifications_read":  "Đã đánh dấu tất cả thông báo là đã đọc",
+	"message.user_suspended":      "Đã tạm khóa người dùng",
+	"message.user_reactivated":    "Đã mở lại tài khoản người dùng",
+	"message.user_signed_out":     "Đã đăng xuất người dùng khỏi mọi thiết bị",
+	"message.user_2fa_reset":      "Đã tắt xác thực hai lớp của người dùng",
+	"message.user_unlocked":       "Đã mở khóa người dùng",
+	"message.logged_out":          "Đăng xuất thành công",
+	"message.session_revoked":     "Đã thu hồi phiên đăng nhập",
+	"message.api_key_revoked":     "Đã thu hồi API key",
+	"message.beneficiary_deleted": "Đã xóa người thụ hưởng",
+	"message.impersonation_ended": "Đã kết thúc phiên xem tài khoản",
+	"message.policy_deleted":      "Đã xóa chính sách hạn mức",
+	"message.payment_deleted":     "Đã xóa phương thức thanh toán",
+	"message.payment_default":     "Đã đặt phương thức thanh toán mặc định",
+	"message.2fa_disabled":        "Đã tắt xác thực hai lớp",
+	"message.device_removed":      "Đã gỡ thiết bị",
+
+	"mail.verification_code.subject": "Xác minh email GonPay của bạn",
+	"mail.verification_code.body":    "Mã xác minh GonPay của bạn là %s. Mã hết hạn sau %d phút. Tuyệt đối không chia sẻ mã này với bất kỳ ai.",
+	"mail.password_reset.subject":    "Đặt lại mật khẩu GonPay",
+	"mail.password_reset.body":       "Xin chào %s,\n\nChúng tôi nhận được yêu cầu đặt lại mật khẩu GonPay của bạn. Hãy mở liên kết dưới đây trong vòng %d phút để chọn mật khẩu mới:\n\n%s\n\nNếu bạn không yêu cầu, hãy bỏ qua email này; mật khẩu của bạn sẽ không thay đổi.\n",
+
+	"notification.account_suspended.title":         "Tài khoản bị tạm khóa",
+	"notification.account_suspended.body":          "Tài khoản của bạn đã bị tạm khóa: %s. Vui lòng liên hệ bộ phận hỗ trợ.",
+	"notification.account_reactivated.title":       "Tài khoản đã được mở lại",
+	"notification.account_reactivated.body":        "Tài khoản của bạn đã được mở lại. Bạn có thể đăng nhập trở lại.",
+	"notification.signed_out_everywhere.title":     "Đã đăng xuất khỏi mọi thiết bị",
+	"notification.signed_out_everywhere.body":      "Quản trị viên đã đăng xuất bạn khỏi mọi thiết bị. Vui lòng đăng nhập lại để tiếp tục.",
+	"notification.two_factor_reset.title":          "Xác thực hai lớp đã bị tắt",
+	"notification.two_factor_reset.body":           "Quản trị viên đã tắt xác thực hai lớp trên tài khoản của bạn. Hãy thiết lập lại để bảo vệ tài khoản.",
+	"notification.password_reset.title":            "Mật khẩu đã được đặt lại",
+	"notification.password_reset.body":             "Mật khẩu của bạn đã được đặt lại và mọi thiết bị đã bị đăng xuất. Nếu không phải bạn, hãy liên hệ bộ phận hỗ trợ ngay.",
+	"notification.account_locked.title":            "Tài khoản tạm thời bị khóa",
+	"notification.account_locked.body":             "Tài khoản của bạn bị khóa đến %[1]s sau %[2]d lần đăng nhập thất bại. Nếu không phải bạn, hãy đổi mật khẩu khi hết thời gian khóa.",
+	"notification.account_unlocked.title":          "Tài khoản đã được mở khóa",
+	"notification.account_unlocked.body":           "Quản trị viên đã mở khóa tài khoản của bạn. Bạn có thể đăng nhập trở lại.",
+	"notification.pin_changed.title":               "Mã PIN giao dịch đã thay đổi",
+	"notification.pin_changed.body":                "Mã PIN giao dịch của bạn vừa được thay đổi. Nếu không phải bạn, hãy liên hệ bộ phận hỗ trợ ngay.",
+	"notification.pin_locked.title":                "Xác nhận thanh toán bị khóa",
+	"notification.pin_locked.body":                 "Xác nhận thanh toán bằng mã PIN hoặc mã xác thực của bạn bị khóa đến %[1]s sau %[2]d lần nhập sai. Nếu không phải bạn, hãy đổi mật khẩu.",
+	"notification.kyc_approved.title":              "Đã xác minh danh tính",
+	"notification.kyc_approved.body":               "Danh tính của bạn đã được xác minh. Tài khoản của bạn hiện ở cấp KYC %d.",
+	"notification.kyc_rejected.title":              "Xác minh danh tính bị từ chối",
+	"notification.kyc_rejected.body":               "Hồ sơ xác minh danh tính của bạn bị từ chối: %s. Bạn có thể gửi lại giấy tờ mới.",
+	"notification.impersonation_started.title":     "Bộ phận hỗ trợ đã xem tài khoản của bạn",
+	"notification.impersonation_started.body":      "Một nhân viên hỗ trợ GonPay đã mở chế độ chỉ xem tài khoản của bạn: %[1]s. Phiên này hiển thị trong danh sách thiết bị đăng nhập đến %[2]s và không thể chuyển tiền.",
+	"notification.api_key_revoked.title":           "API key đã bị thu hồi",
+	"notification.api_key_revoked.body":            "Quản trị viên đã thu hồi API key %[1]q (%[2]s). Các request dùng key này sẽ bị từ chối.",
+	"notification.token_reused.title":              "Thiết bị đã bị đăng xuất",
+	"notification.token_reused.body":               "Mã đăng nhập của một thiết bị đã bị sử dụng hai lần nên chúng tôi đã đăng xuất thiết bị đó. Nếu bạn không thực hiện việc này, hãy đổi mật khẩu.",
+	"notification.data_export_failed.title":        "Xuất dữ liệu thất bại",
+	"notification.data_export_failed.body":         "Chúng tôi không thể chuẩn bị bản sao dữ liệu của bạn. Vui lòng yêu cầu lại.",
+	"notification.data_export_ready.title":         "Dữ liệu của bạn đã sẵn sàng",
+	"notification.data_export_ready.body":          "Bản sao dữ liệu của bạn có thể tải xuống đến %s.",
+	"notification.wallet_frozen.title":             "Ví bị đóng băng",
+	"notification.wallet_frozen.body":              "Ví %[1]s của bạn đã bị đóng băng: %[2]s. Bạn vẫn có thể nhận tiền nhưng thanh toán và rút tiền bị tạm dừng. Vui lòng liên hệ bộ phận hỗ trợ.",
+	"notification.wallet_blocked.title":            "Ví bị khóa",
+	"notification.wallet_blocked.body":             "Ví %[1]s của bạn đã bị khóa: %[2]s. Không thể chuyển tiền đi hoặc nhận tiền vào ví này. Vui lòng liên hệ bộ phận hỗ trợ.",
+	"notification.wallet_restored.title":           "Ví đã được khôi phục",
+	"notification.wallet_restored.body":            "Ví %[1]s của bạn có thể sử dụng lại: %[2]s.",
+	"notification.balance_credited.title":          "Số dư đã được điều chỉnh",
+	"notification.balance_credited.body":           "Ví %[2]s của bạn được cộng %[1]s: %[3]s",
+	"notification.balance_debited.title":           "Số dư đã được điều chỉnh",
+	"notification.balance_debited.body":            "Ví %[2]s của bạn bị trừ %[1]s: %[3]s",
+	"notification.beneficiary_added.title":         "Đã thêm người thụ hưởng mới",
+	"notification.beneficiary_added.body":          "%[1]s đã được thêm vào danh sách người thụ hưởng. Đến %[2]s, mỗi lần chuyển tiền cho người này bị giới hạn ở %[3]s. Nếu không phải bạn, hãy bảo vệ tài khoản ngay.",
+	"notification.beneficiary_unrestricted.title":  "Đã gỡ giới hạn người thụ hưởng",
+	"notification.beneficiary_unrestricted.body":   "Các giao dịch chuyển tiền tới %s không còn bị giới hạn.",
+	"notification.beneficiary_name_mismatch.title": "Tên người thụ hưởng không khớp",
+	"notification.beneficiary_name_mismatch.body":  "Tài khoản %[1]s thuộc về %[2]s, không phải %[3]s. Hãy kiểm tra lại thông tin trước khi chuyển tiền.",
+}
@@ -0,0 +1,54 @@
+// internal/provider/bank.go
+package provider
+
+import (
+	"GonPay_Backend/internal/domain"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+)
+
+// LocalBankProvider is a stand-in for a real bank gateway. It knows a fixed
+// set of accounts and accepts every payout to them. Use it for development
+// and tests only.
+type LocalBankProvider struct {
+	accounts map[string]string
+}
+
+func NewLocalBankProvider() *LocalBankProvider {
+	return &LocalBankProvider{
+		accounts: map[string]string{
+			localAccountKey("Vietcombank", "9876543210"): "NGUYEN VAN BINH",
+			localAccountKey("Techcombank", "8765432109"): "TRAN THI CUC",
+			localAccountKey("BIDV", "6543210987"):        "PHAM THI EM",
+			localAccountKey("MB Bank", "4321098765"):     "DANG THI GIANG",
+			localAccountKey("VPBank", "3210987654"):      "VU VAN HUNG",
+			localAccountKey("Agribank", "1098765432"):    "NGO VAN MINH",
+			localAccountKey("Sacombank", "0987654321"):   "DO THI NAM",
+		},
+	}
+}
+
+func (p *LocalBankProvider) LookupAccountName(bankName string, accountNumber string) (string, error) {
+	name, ok := p.accounts[localAccountKey(bankName, accountNumber)]
+	if !ok {
+		return "", domain.ErrAccountNotFound
+	}
+	return name, nil
+}
+
+func (p *LocalBankProvider) SendPayout(payout *domain.Payout) (string, error) {
+	if _, err := p.LookupAccountName(payout.BankName, payout.AccountNumber); err != nil {
+		return "", err
+	}
+
+	ref := make([]byte, 8)
+	if _, err := rand.Read(ref); err != nil {
+		return "", err
+	}
+	return "LOCAL-" + strings.ToUpper(hex.EncodeToString(ref)), nil
+}
+
+func localAccountKey(bankName string, accountNumber string) string {
+	return strings.ToUpper(strings.TrimSpace(bankName)) + "/" + strings.TrimSpace(accountNumber)
+}
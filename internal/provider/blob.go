@@ -0,0 +1,60 @@
+// internal/provider/blob.go
+package provider
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalBlobStore keeps files in a directory on the local filesystem. Keys are
+// slash-separated paths relative to that directory.
+type LocalBlobStore struct {
+	dir string
+}
+
+func NewLocalBlobStore(dir string) *LocalBlobStore {
+	return &LocalBlobStore{dir: dir}
+}
+
+func (s *LocalBlobStore) Put(key string, content []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o600)
+}
+
+func (s *LocalBlobStore) Get(key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+func (s *LocalBlobStore) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// path maps a key into the store's directory, refusing keys that would
+// escape it.
+func (s *LocalBlobStore) path(key string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(s.dir, clean), nil
+}
@@ -0,0 +1,82 @@
+// internal/provider/mail.go
+package provider
+
+import (
+	"GonPay_Backend/pkg/logger"
+	"fmt"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SMTPMailSender sends mail through an SMTP server with PLAIN auth.
+type SMTPMailSender struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+func NewSMTPMailSender(host string, port int, username, password, from string) *SMTPMailSender {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPMailSender{
+		addr: fmt.Sprintf("%s:%d", host, port),
+		auth: auth,
+		from: from,
+	}
+}
+
+func (s *SMTPMailSender) Send(to, subject, body string) error {
+	return smtp.SendMail(s.addr, s.auth, s.from, []string{to}, buildMessage(s.from, to, subject, body))
+}
+
+// FileMailSender writes each message to a .eml file in a directory instead
+// of sending it. Use it for local development only.
+type FileMailSender struct {
+	dir  string
+	from string
+}
+
+func NewFileMailSender(dir, from string) *FileMailSender {
+	return &FileMailSender{dir: dir, from: from}
+}
+
+func (s *FileMailSender) Send(to, subject, body string) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), strings.NewReplacer("@", "_at_", "/", "_").Replace(to))
+	return os.WriteFile(filepath.Join(s.dir, name), buildMessage(s.from, to, subject, body), 0o600)
+}
+
+// LogMailSender writes messages to the application log instead of sending
+// them. Use it for local development only.
+type LogMailSender struct {
+	logger logger.Logger
+}
+
+func NewLogMailSender(logger logger.Logger) *LogMailSender {
+	return &LogMailSender{logger: logger}
+}
+
+func (s *LogMailSender) Send(to, subject, body string) error {
+	s.logger.Info("mail not sent (log sender)", "to", to, "subject", subject, "body", body)
+	return nil
+}
+
+func buildMessage(from, to, subject, body string) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + to + "\r\n")
+	b.WriteString("Subject: " + subject + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(body)
+	return []byte(b.String())
+}
@@ -0,0 +1,98 @@
+// internal/provider/notification_channel.go
+package provider
+
+import (
+	"GonPay_Backend/internal/domain"
+	"errors"
+	"strconv"
+)
+
+// EmailChannel sends notifications by email, with the title as the
+// subject.
+type EmailChannel struct {
+	mailSender domain.MailSender
+}
+
+func NewEmailChannel(mailSender domain.MailSender) *EmailChannel {
+	return &EmailChannel{mailSender: mailSender}
+}
+
+func (c *EmailChannel) Send(user *domain.User, notification *domain.Notification) error {
+	if user.Email == "" {
+		return domain.ErrNoDeliveryAddress
+	}
+	return c.mailSender.Send(user.Email, notification.Title, notification.Content)
+}
+
+// SMSChannel sends notifications as a text message to the user's phone
+// number.
+type SMSChannel struct {
+	smsSender domain.SMSSender
+}
+
+func NewSMSChannel(smsSender domain.SMSSender) *SMSChannel {
+	return &SMSChannel{smsSender: smsSender}
+}
+
+func (c *SMSChannel) Send(user *domain.User, notification *domain.Notification) error {
+	if user.PhoneNumber == "" {
+		return domain.ErrNoDeliveryAddress
+	}
+	return c.smsSender.SendSMS(user.PhoneNumber, notification.Title+": "+notification.Content)
+}
+
+// PushChannel sends notifications to every device the user registered.
+// Devices whose token the push service refuses are removed.
+type PushChannel struct {
+	pushProvider   domain.PushProvider
+	pushDeviceRepo domain.PushDeviceRepository
+}
+
+func NewPushChannel(pushProvider domain.PushProvider, pushDeviceRepo domain.PushDeviceRepository) *PushChannel {
+	return &PushChannel{
+		pushProvider:   pushProvider,
+		pushDeviceRepo: pushDeviceRepo,
+	}
+}
+
+// Send succeeds when at least one device received the message, so a single
+// unreachable device does not cause the others to get it twice on retry.
+func (c *PushChannel) Send(user *domain.User, notification *domain.Notification) error {
+	devices, err := c.pushDeviceRepo.GetByUserID(user.ID)
+	if err != nil {
+		return err
+	}
+
+	message := &domain.PushMessage{
+		Title: notification.Title,
+		Body:  notification.Content,
+		Data: map[string]string{
+			"notification_id":   strconv.FormatInt(notification.ID, 10),
+			"notification_type": string(notification.NotificationType),
+		},
+	}
+
+	var lastErr error
+	delivered := false
+	for _, device := range devices {
+		err := c.pushProvider.Push(device, message)
+		switch {
+		case err == nil:
+			delivered = true
+		case errors.Is(err, domain.ErrPushTokenInvalid):
+			if err := c.pushDeviceRepo.DeleteByToken(device.Token); err != nil {
+				lastErr = err
+			}
+		default:
+			lastErr = err
+		}
+	}
+
+	if delivered {
+		return nil
+	}
+	if lastErr != nil {
+		return lastErr
+	}
+	return domain.ErrNoDeliveryAddress
+}
@@ -0,0 +1,27 @@
+// internal/provider/push.go
+package provider
+
+import (
+	"GonPay_Backend/internal/domain"
+	"GonPay_Backend/pkg/logger"
+)
+
+// LocalPushProvider writes push messages to the application log instead of
+// sending them through FCM or APNs. Use it for development and tests only.
+type LocalPushProvider struct {
+	logger logger.Logger
+}
+
+func NewLocalPushProvider(logger logger.Logger) *LocalPushProvider {
+	return &LocalPushProvider{logger: logger}
+}
+
+func (p *LocalPushProvider) Push(device *domain.PushDevice, message *domain.PushMessage) error {
+	p.logger.Info("push not sent (local provider)",
+		"device_id", device.ID,
+		"platform", device.Platform,
+		"title", message.Title,
+		"body", message.Body,
+	)
+	return nil
+}
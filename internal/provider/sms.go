@@ -0,0 +1,21 @@
+// internal/provider/sms.go
+package provider
+
+import (
+	"GonPay_Backend/pkg/logger"
+)
+
+// LocalSMSSender writes text messages to the application log instead of
+// sending them. Use it for development and tests only.
+type LocalSMSSender struct {
+	logger logger.Logger
+}
+
+func NewLocalSMSSender(logger logger.Logger) *LocalSMSSender {
+	return &LocalSMSSender{logger: logger}
+}
+
+func (s *LocalSMSSender) SendSMS(phoneNumber, message string) error {
+	s.logger.Info("sms not sent (local sender)", "to", phoneNumber, "message", message)
+	return nil
+}
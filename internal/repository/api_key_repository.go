@@ -0,0 +1,153 @@
+// internal/repository/api_key_repository.go
+package repository
+
+import (
+	"GonPay_Backend/internal/domain"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type apiKeyRepository struct {
+	db *PostgresDB
+}
+
+func NewAPIKeyRepository(db *PostgresDB) domain.APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+const apiKeyColumns = `
+        api_key_id, user_id, name, prefix, secret_hash, scopes, usage_count,
+        last_used_at, COALESCE(last_used_ip::text, ''), expires_at, revoked_at, created_at`
+
+func (r *apiKeyRepository) Create(key *domain.APIKey) error {
+	query := `
+        INSERT INTO api_keys (user_id, name, prefix, secret_hash, scopes, expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING api_key_id, created_at`
+
+	err := r.db.DB.QueryRow(
+		query,
+		key.UserID,
+		key.Name,
+		key.Prefix,
+		key.SecretHash,
+		pq.Array(scopeStrings(key.Scopes)),
+		key.ExpiresAt,
+	).Scan(&key.ID, &key.CreatedAt)
+	return translateError(err)
+}
+
+func (r *apiKeyRepository) GetByID(id int64) (*domain.APIKey, error) {
+	query := `SELECT` + apiKeyColumns + `
+        FROM api_keys
+        WHERE api_key_id = $1`
+
+	key, err := scanAPIKey(r.db.DB.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	return key, err
+}
+
+func (r *apiKeyRepository) GetByPrefix(prefix string) (*domain.APIKey, error) {
+	query := `SELECT` + apiKeyColumns + `
+        FROM api_keys
+        WHERE prefix = $1`
+
+	key, err := scanAPIKey(r.db.DB.QueryRow(query, prefix))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return key, err
+}
+
+func (r *apiKeyRepository) GetByUserID(userID int64) ([]*domain.APIKey, error) {
+	query := `SELECT` + apiKeyColumns + `
+        FROM api_keys
+        WHERE user_id = $1
+        ORDER BY created_at DESC`
+
+	rows, err := r.db.DB.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*domain.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+func (r *apiKeyRepository) RecordUsage(id int64, ipAddress string, at time.Time) error {
+	query := `
+        UPDATE api_keys
+        SET usage_count = usage_count + 1, last_used_at = $1, last_used_ip = NULLIF($2, '')::inet
+        WHERE api_key_id = $3`
+
+	_, err := r.db.DB.Exec(query, at, ipAddress, id)
+	return err
+}
+
+func (r *apiKeyRepository) Revoke(id int64, at time.Time) error {
+	query := `UPDATE api_keys SET revoked_at = $1 WHERE api_key_id = $2 AND revoked_at IS NULL`
+
+	result, err := r.db.DB.Exec(query, at, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func scanAPIKey(row rowScanner) (*domain.APIKey, error) {
+	key := &domain.APIKey{}
+	var scopes []string
+	err := row.Scan(
+		&key.ID,
+		&key.UserID,
+		&key.Name,
+		&key.Prefix,
+		&key.SecretHash,
+		pq.Array(&scopes),
+		&key.UsageCount,
+		&key.LastUsedAt,
+		&key.LastUsedIP,
+		&key.ExpiresAt,
+		&key.RevokedAt,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range scopes {
+		key.Scopes = append(key.Scopes, domain.APIScope(s))
+	}
+	return key, nil
+}
+
+func scopeStrings(scopes []domain.APIScope) []string {
+	out := make([]string, len(scopes))
+	for i, s := range scopes {
+		out[i] = string(s)
+	}
+	return out
+}
@@ -16,25 +16,33 @@ func NewBeneficiaryRepository(db *PostgresDB) domain.BeneficiaryRepository {
 
 func (r *beneficiaryRepository) Create(b *domain.Beneficiary) error {
 	query := `
-        INSERT INTO beneficiaries (user_id, beneficiary_name, account_identifier, account_type, bank_name)
-        VALUES ($1, $2, $3, $4, $5)
+        INSERT INTO beneficiaries (
+            user_id, beneficiary_name, account_identifier, account_type, bank_name,
+            verified_name, name_match_status, cooling_off_until
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
         RETURNING beneficiary_id, created_at`
 
-	return r.db.DB.QueryRow(
+	err := r.db.DB.QueryRow(
 		query,
 		b.UserID,
 		b.BeneficiaryName,
 		b.AccountIdentifier,
 		b.AccountType,
 		b.BankName,
+		b.VerifiedName,
+		b.NameMatchStatus,
+		b.CoolingOffUntil,
 	).Scan(&b.ID, &b.CreatedAt)
+	return translateError(err)
 }
 
 func (r *beneficiaryRepository) Update(b *domain.Beneficiary) error {
 	query := `
         UPDATE beneficiaries 
-        SET beneficiary_name = $1, account_identifier = $2, account_type = $3, bank_name = $4
-        WHERE beneficiary_id = $5 AND user_id = $6`
+        SET beneficiary_name = $1, account_identifier = $2, account_type = $3, bank_name = $4,
+            verified_name = $5, name_match_status = $6, cooling_off_until = $7
+        WHERE beneficiary_id = $8 AND user_id = $9`
 
 	result, err := r.db.DB.Exec(
 		query,
@@ -42,11 +50,14 @@ func (r *beneficiaryRepository) Update(b *domain.Beneficiary) error {
 		b.AccountIdentifier,
 		b.AccountType,
 		b.BankName,
+		b.VerifiedName,
+		b.NameMatchStatus,
+		b.CoolingOffUntil,
 		b.ID,
 		b.UserID,
 	)
 	if err != nil {
-		return err
+		return translateError(err)
 	}
 
 	rows, err := result.RowsAffected()
@@ -84,7 +95,8 @@ func (r *beneficiaryRepository) Delete(id int64) error {
 func (r *beneficiaryRepository) GetByID(id int64) (*domain.Beneficiary, error) {
 	b := &domain.Beneficiary{}
 	query := `
-        SELECT beneficiary_id, user_id, beneficiary_name, account_identifier, account_type, bank_name, created_at
+        SELECT beneficiary_id, user_id, beneficiary_name, account_identifier, account_type, bank_name,
+               verified_name, name_match_status, cooling_off_until, created_at
         FROM beneficiaries 
         WHERE beneficiary_id = $1`
 
@@ -95,6 +107,9 @@ func (r *beneficiaryRepository) GetByID(id int64) (*domain.Beneficiary, error) {
 		&b.AccountIdentifier,
 		&b.AccountType,
 		&b.BankName,
+		&b.VerifiedName,
+		&b.NameMatchStatus,
+		&b.CoolingOffUntil,
 		&b.CreatedAt,
 	)
 
@@ -106,7 +121,8 @@ func (r *beneficiaryRepository) GetByID(id int64) (*domain.Beneficiary, error) {
 
 func (r *beneficiaryRepository) GetByUserID(userID int64) ([]*domain.Beneficiary, error) {
 	query := `
-        SELECT beneficiary_id, user_id, beneficiary_name, account_identifier, account_type, bank_name, created_at
+        SELECT beneficiary_id, user_id, beneficiary_name, account_identifier, account_type, bank_name,
+               verified_name, name_match_status, cooling_off_until, created_at
         FROM beneficiaries 
         WHERE user_id = $1
         ORDER BY created_at DESC`
@@ -127,6 +143,9 @@ func (r *beneficiaryRepository) GetByUserID(userID int64) ([]*domain.Beneficiary
 			&b.AccountIdentifier,
 			&b.AccountType,
 			&b.BankName,
+			&b.VerifiedName,
+			&b.NameMatchStatus,
+			&b.CoolingOffUntil,
 			&b.CreatedAt,
 		)
 		if err != nil {
@@ -141,7 +160,8 @@ func (r *beneficiaryRepository) GetByUserID(userID int64) ([]*domain.Beneficiary
 func (r *beneficiaryRepository) GetByAccountIdentifier(accountIdentifier string, accountType domain.AccountType) (*domain.Beneficiary, error) {
 	b := &domain.Beneficiary{}
 	query := `
-        SELECT beneficiary_id, user_id, beneficiary_name, account_identifier, account_type, bank_name, created_at
+        SELECT beneficiary_id, user_id, beneficiary_name, account_identifier, account_type, bank_name,
+               verified_name, name_match_status, cooling_off_until, created_at
         FROM beneficiaries 
         WHERE account_identifier = $1 AND account_type = $2`
 
@@ -152,6 +172,36 @@ func (r *beneficiaryRepository) GetByAccountIdentifier(accountIdentifier string,
 		&b.AccountIdentifier,
 		&b.AccountType,
 		&b.BankName,
+		&b.VerifiedName,
+		&b.NameMatchStatus,
+		&b.CoolingOffUntil,
+		&b.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return b, err
+}
+
+func (r *beneficiaryRepository) GetByUserAndAccount(userID int64, accountIdentifier string, accountType domain.AccountType) (*domain.Beneficiary, error) {
+	b := &domain.Beneficiary{}
+	query := `
+        SELECT beneficiary_id, user_id, beneficiary_name, account_identifier, account_type, bank_name,
+               verified_name, name_match_status, cooling_off_until, created_at
+        FROM beneficiaries 
+        WHERE user_id = $1 AND account_identifier = $2 AND account_type = $3`
+
+	err := r.db.DB.QueryRow(query, userID, accountIdentifier, accountType).Scan(
+		&b.ID,
+		&b.UserID,
+		&b.BeneficiaryName,
+		&b.AccountIdentifier,
+		&b.AccountType,
+		&b.BankName,
+		&b.VerifiedName,
+		&b.NameMatchStatus,
+		&b.CoolingOffUntil,
 		&b.CreatedAt,
 	)
 
@@ -160,3 +210,26 @@ func (r *beneficiaryRepository) GetByAccountIdentifier(accountIdentifier string,
 	}
 	return b, err
 }
+
+func (r *beneficiaryRepository) LiftCoolingOff(id int64, userID int64) error {
+	query := `
+        UPDATE beneficiaries
+        SET cooling_off_until = NULL
+        WHERE beneficiary_id = $1 AND user_id = $2`
+
+	result, err := r.db.DB.Exec(query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrInvalidOperation
+	}
+
+	return nil
+}
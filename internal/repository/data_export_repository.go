@@ -0,0 +1,140 @@
+// internal/repository/data_export_repository.go
+package repository
+
+import (
+	"GonPay_Backend/internal/domain"
+	"database/sql"
+)
+
+type dataExportRepository struct {
+	db *PostgresDB
+}
+
+func NewDataExportRepository(db *PostgresDB) domain.DataExportRepository {
+	return &dataExportRepository{db: db}
+}
+
+const dataExportColumns = `
+        data_export_id, user_id, status, COALESCE(storage_key, ''), size_bytes, completed_at, expires_at, created_at`
+
+func (r *dataExportRepository) Create(export *domain.DataExport) error {
+	query := `
+        INSERT INTO data_exports (user_id, status)
+        VALUES ($1, $2)
+        RETURNING data_export_id, created_at`
+
+	return r.db.DB.QueryRow(query, export.UserID, export.Status).Scan(&export.ID, &export.CreatedAt)
+}
+
+func (r *dataExportRepository) GetByID(id int64) (*domain.DataExport, error) {
+	query := `SELECT` + dataExportColumns + `
+        FROM data_exports
+        WHERE data_export_id = $1`
+
+	export, err := scanDataExport(r.db.DB.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrDataExportNotFound
+	}
+	return export, err
+}
+
+func (r *dataExportRepository) GetLatest(userID int64) (*domain.DataExport, error) {
+	query := `SELECT` + dataExportColumns + `
+        FROM data_exports
+        WHERE user_id = $1
+        ORDER BY created_at DESC
+        LIMIT 1`
+
+	export, err := scanDataExport(r.db.DB.QueryRow(query, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return export, err
+}
+
+func (r *dataExportRepository) GetByUserID(userID int64) ([]*domain.DataExport, error) {
+	query := `SELECT` + dataExportColumns + `
+        FROM data_exports
+        WHERE user_id = $1
+        ORDER BY created_at DESC`
+
+	rows, err := r.db.DB.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var exports []*domain.DataExport
+	for rows.Next() {
+		export, err := scanDataExport(rows)
+		if err != nil {
+			return nil, err
+		}
+		exports = append(exports, export)
+	}
+
+	return exports, rows.Err()
+}
+
+func (r *dataExportRepository) Complete(export *domain.DataExport) error {
+	query := `
+        UPDATE data_exports
+        SET status = $1, storage_key = $2, size_bytes = $3, completed_at = $4, expires_at = $5
+        WHERE data_export_id = $6`
+
+	_, err := r.db.DB.Exec(
+		query,
+		export.Status,
+		export.StorageKey,
+		export.SizeBytes,
+		export.CompletedAt,
+		export.ExpiresAt,
+		export.ID,
+	)
+	return err
+}
+
+func (r *dataExportRepository) Fail(id int64) error {
+	query := `
+        UPDATE data_exports
+        SET status = $1, completed_at = CURRENT_TIMESTAMP
+        WHERE data_export_id = $2`
+
+	_, err := r.db.DB.Exec(query, domain.DataExportStatusFailed, id)
+	return err
+}
+
+func (r *dataExportRepository) Purge(id int64) error {
+	query := `
+        UPDATE data_exports
+        SET storage_key = NULL, expires_at = LEAST(COALESCE(expires_at, CURRENT_TIMESTAMP), CURRENT_TIMESTAMP)
+        WHERE data_export_id = $1`
+
+	_, err := r.db.DB.Exec(query, id)
+	return err
+}
+
+func (r *dataExportRepository) DeleteByUserID(userID int64) error {
+	query := `DELETE FROM data_exports WHERE user_id = $1`
+
+	_, err := r.db.DB.Exec(query, userID)
+	return err
+}
+
+func scanDataExport(row rowScanner) (*domain.DataExport, error) {
+	export := &domain.DataExport{}
+	err := row.Scan(
+		&export.ID,
+		&export.UserID,
+		&export.Status,
+		&export.StorageKey,
+		&export.SizeBytes,
+		&export.CompletedAt,
+		&export.ExpiresAt,
+		&export.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return export, nil
+}
@@ -0,0 +1,245 @@
+// internal/repository/kyc_repository.go
+package repository
+
+import (
+	"GonPay_Backend/internal/domain"
+	"database/sql"
+)
+
+type kycRepository struct {
+	db *PostgresDB
+}
+
+func NewKYCRepository(db *PostgresDB) domain.KYCRepository {
+	return &kycRepository{db: db}
+}
+
+const kycSubmissionColumns = `
+        kyc_submission_id, user_id, requested_tier, status, COALESCE(rejection_reason, ''),
+        reviewed_by, reviewed_at, created_at`
+
+func (r *kycRepository) CreateSubmission(submission *domain.KYCSubmission) error {
+	tx, err := r.db.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	query := `
+        INSERT INTO kyc_submissions (user_id, requested_tier, status)
+        VALUES ($1, $2, $3)
+        RETURNING kyc_submission_id, created_at`
+
+	err = tx.QueryRow(query, submission.UserID, submission.RequestedTier, submission.Status).
+		Scan(&submission.ID, &submission.CreatedAt)
+	if err != nil {
+		tx.Rollback()
+		return translateError(err)
+	}
+
+	query = `
+        INSERT INTO kyc_documents (kyc_submission_id, document_type, storage_key, content_type, size_bytes)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING kyc_document_id, created_at`
+
+	for _, doc := range submission.Documents {
+		doc.SubmissionID = submission.ID
+		err := tx.QueryRow(query, doc.SubmissionID, doc.Type, doc.StorageKey, doc.ContentType, doc.Size).
+			Scan(&doc.ID, &doc.CreatedAt)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *kycRepository) GetSubmission(id int64) (*domain.KYCSubmission, error) {
+	query := `SELECT` + kycSubmissionColumns + `
+        FROM kyc_submissions
+        WHERE kyc_submission_id = $1`
+
+	submission, err := scanKYCSubmission(r.db.DB.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrKYCNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	submission.Documents, err = r.getDocuments(submission.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return submission, nil
+}
+
+func (r *kycRepository) GetLatest(userID int64) (*domain.KYCSubmission, error) {
+	query := `SELECT` + kycSubmissionColumns + `
+        FROM kyc_submissions
+        WHERE user_id = $1
+        ORDER BY created_at DESC
+        LIMIT 1`
+
+	submission, err := scanKYCSubmission(r.db.DB.QueryRow(query, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return submission, err
+}
+
+func (r *kycRepository) GetByStatus(status domain.KYCStatus, limit, offset int) ([]*domain.KYCSubmission, error) {
+	query := `SELECT` + kycSubmissionColumns + `
+        FROM kyc_submissions
+        WHERE status = $1
+        ORDER BY created_at ASC
+        LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.DB.Query(query, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var submissions []*domain.KYCSubmission
+	for rows.Next() {
+		submission, err := scanKYCSubmission(rows)
+		if err != nil {
+			return nil, err
+		}
+		submissions = append(submissions, submission)
+	}
+
+	return submissions, rows.Err()
+}
+
+func (r *kycRepository) GetDocument(submissionID, documentID int64) (*domain.KYCDocument, error) {
+	query := `
+        SELECT kyc_document_id, kyc_submission_id, document_type, storage_key, content_type, size_bytes, created_at
+        FROM kyc_documents
+        WHERE kyc_document_id = $1 AND kyc_submission_id = $2`
+
+	doc, err := scanKYCDocument(r.db.DB.QueryRow(query, documentID, submissionID))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrKYCNotFound
+	}
+	return doc, err
+}
+
+func (r *kycRepository) Review(submission *domain.KYCSubmission, notification *domain.Notification) error {
+	tx, err := r.db.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	query := `
+        UPDATE kyc_submissions
+        SET status = $1, rejection_reason = NULLIF($2, ''), reviewed_by = $3, reviewed_at = $4
+        WHERE kyc_submission_id = $5 AND status = $6`
+
+	result, err := tx.Exec(
+		query,
+		submission.Status,
+		submission.RejectionReason,
+		submission.ReviewedBy,
+		submission.ReviewedAt,
+		submission.ID,
+		domain.KYCStatusPending,
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if rows == 0 {
+		tx.Rollback()
+		return domain.ErrKYCReviewed
+	}
+
+	if submission.Status == domain.KYCStatusVerified {
+		// Never lower a tier the user already holds
+		query = `
+            UPDATE users
+            SET kyc_tier = GREATEST(kyc_tier, $1), updated_at = CURRENT_TIMESTAMP
+            WHERE user_id = $2`
+
+		if _, err := tx.Exec(query, submission.RequestedTier, submission.UserID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := insertNotification(tx, notification); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *kycRepository) getDocuments(submissionID int64) ([]*domain.KYCDocument, error) {
+	query := `
+        SELECT kyc_document_id, kyc_submission_id, document_type, storage_key, content_type, size_bytes, created_at
+        FROM kyc_documents
+        WHERE kyc_submission_id = $1
+        ORDER BY kyc_document_id`
+
+	rows, err := r.db.DB.Query(query, submissionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var docs []*domain.KYCDocument
+	for rows.Next() {
+		doc, err := scanKYCDocument(rows)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+
+	return docs, rows.Err()
+}
+
+func scanKYCSubmission(row rowScanner) (*domain.KYCSubmission, error) {
+	submission := &domain.KYCSubmission{}
+	err := row.Scan(
+		&submission.ID,
+		&submission.UserID,
+		&submission.RequestedTier,
+		&submission.Status,
+		&submission.RejectionReason,
+		&submission.ReviewedBy,
+		&submission.ReviewedAt,
+		&submission.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return submission, nil
+}
+
+func scanKYCDocument(row rowScanner) (*domain.KYCDocument, error) {
+	doc := &domain.KYCDocument{}
+	err := row.Scan(
+		&doc.ID,
+		&doc.SubmissionID,
+		&doc.Type,
+		&doc.StorageKey,
+		&doc.ContentType,
+		&doc.Size,
+		&doc.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
@@ -0,0 +1,207 @@
+// internal/repository/limit_policy_repository.go
+package repository
+
+import (
+	"GonPay_Backend/internal/domain"
+	"database/sql"
+	"strconv"
+)
+
+type limitPolicyRepository struct {
+	db *PostgresDB
+}
+
+func NewLimitPolicyRepository(db *PostgresDB) domain.LimitPolicyRepository {
+	return &limitPolicyRepository{db: db}
+}
+
+const limitPolicyColumns = `
+        policy_id, scope, scope_value, transaction_type, daily_limit, monthly_limit,
+        max_single_amount, min_amount, hourly_count_limit, daily_count_limit,
+        new_recipient_max_amount, new_recipient_daily_limit, window_mode, updated_by, created_at, updated_at`
+
+func (r *limitPolicyRepository) Create(p *domain.LimitPolicy) error {
+	query := `
+        INSERT INTO limit_policies (
+            scope, scope_value, transaction_type, daily_limit, monthly_limit,
+            max_single_amount, min_amount, hourly_count_limit, daily_count_limit,
+            new_recipient_max_amount, new_recipient_daily_limit, window_mode, updated_by
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+        RETURNING policy_id, created_at, updated_at`
+
+	err := r.db.DB.QueryRow(
+		query,
+		p.Scope,
+		p.ScopeValue,
+		p.TransactionType,
+		p.DailyLimit,
+		p.MonthlyLimit,
+		p.MaxSingleAmount,
+		p.MinAmount,
+		p.HourlyCountLimit,
+		p.DailyCountLimit,
+		p.NewRecipientMaxAmount,
+		p.NewRecipientDailyLimit,
+		p.WindowMode,
+		p.UpdatedBy,
+	).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt)
+	return translateError(err)
+}
+
+func (r *limitPolicyRepository) Update(p *domain.LimitPolicy) error {
+	query := `
+        UPDATE limit_policies
+        SET daily_limit = $1, monthly_limit = $2, max_single_amount = $3, min_amount = $4,
+            hourly_count_limit = $5, daily_count_limit = $6,
+            new_recipient_max_amount = $7, new_recipient_daily_limit = $8, window_mode = $9,
+            updated_by = $10, updated_at = CURRENT_TIMESTAMP
+        WHERE policy_id = $11
+        RETURNING updated_at`
+
+	err := r.db.DB.QueryRow(
+		query,
+		p.DailyLimit,
+		p.MonthlyLimit,
+		p.MaxSingleAmount,
+		p.MinAmount,
+		p.HourlyCountLimit,
+		p.DailyCountLimit,
+		p.NewRecipientMaxAmount,
+		p.NewRecipientDailyLimit,
+		p.WindowMode,
+		p.UpdatedBy,
+		p.ID,
+	).Scan(&p.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return domain.ErrInvalidOperation
+	}
+	return translateError(err)
+}
+
+func (r *limitPolicyRepository) Delete(id int64) error {
+	query := `DELETE FROM limit_policies WHERE policy_id = $1`
+
+	result, err := r.db.DB.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrInvalidOperation
+	}
+
+	return nil
+}
+
+func (r *limitPolicyRepository) GetByID(id int64) (*domain.LimitPolicy, error) {
+	query := `SELECT` + limitPolicyColumns + `
+        FROM limit_policies
+        WHERE policy_id = $1`
+
+	p, err := scanLimitPolicy(r.db.DB.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrInvalidOperation
+	}
+	return p, err
+}
+
+func (r *limitPolicyRepository) GetAll() ([]*domain.LimitPolicy, error) {
+	query := `SELECT` + limitPolicyColumns + `
+        FROM limit_policies
+        ORDER BY scope, scope_value, transaction_type`
+
+	rows, err := r.db.DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var policies []*domain.LimitPolicy
+	for rows.Next() {
+		p, err := scanLimitPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, p)
+	}
+
+	return policies, nil
+}
+
+func (r *limitPolicyRepository) GetByScope(scope domain.LimitPolicyScope, scopeValue string, transactionType domain.TransactionType) (*domain.LimitPolicy, error) {
+	query := `SELECT` + limitPolicyColumns + `
+        FROM limit_policies
+        WHERE scope = $1 AND scope_value = $2 AND transaction_type = $3`
+
+	p, err := scanLimitPolicy(r.db.DB.QueryRow(query, scope, scopeValue, transactionType))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return p, err
+}
+
+func (r *limitPolicyRepository) GetApplicable(user *domain.User, transactionType domain.TransactionType) (*domain.LimitPolicy, error) {
+	query := `SELECT` + limitPolicyColumns + `
+        FROM limit_policies
+        WHERE transaction_type = $1
+        AND (
+            (scope = 'USER' AND scope_value = $2)
+            OR (scope = 'KYC_TIER' AND scope_value = $3)
+            OR (scope = 'ROLE' AND scope_value = $4)
+            OR scope = 'DEFAULT'
+        )
+        ORDER BY CASE scope
+            WHEN 'USER' THEN 1
+            WHEN 'KYC_TIER' THEN 2
+            WHEN 'ROLE' THEN 3
+            ELSE 4
+        END
+        LIMIT 1`
+
+	p, err := scanLimitPolicy(r.db.DB.QueryRow(
+		query,
+		transactionType,
+		strconv.FormatInt(user.ID, 10),
+		strconv.Itoa(user.KYCTier),
+		user.Role,
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return p, err
+}
+
+func scanLimitPolicy(row rowScanner) (*domain.LimitPolicy, error) {
+	p := &domain.LimitPolicy{}
+	var updatedBy sql.NullInt64
+	err := row.Scan(
+		&p.ID,
+		&p.Scope,
+		&p.ScopeValue,
+		&p.TransactionType,
+		&p.DailyLimit,
+		&p.MonthlyLimit,
+		&p.MaxSingleAmount,
+		&p.MinAmount,
+		&p.HourlyCountLimit,
+		&p.DailyCountLimit,
+		&p.NewRecipientMaxAmount,
+		&p.NewRecipientDailyLimit,
+		&p.WindowMode,
+		&updatedBy,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	p.UpdatedBy = updatedBy.Int64
+	return p, nil
+}
@@ -0,0 +1,43 @@
+// internal/repository/login_attempt_repository.go
+package repository
+
+import (
+	"GonPay_Backend/internal/domain"
+	"time"
+)
+
+type loginAttemptRepository struct {
+	db *PostgresDB
+}
+
+func NewLoginAttemptRepository(db *PostgresDB) domain.LoginAttemptRepository {
+	return &loginAttemptRepository{db: db}
+}
+
+func (r *loginAttemptRepository) Create(a *domain.LoginAttempt) error {
+	query := `
+        INSERT INTO login_attempts (user_id, email, ip_address, user_agent, success, reason)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING attempt_id, created_at`
+
+	return r.db.DB.QueryRow(
+		query,
+		a.UserID,
+		a.Email,
+		a.IPAddress,
+		a.UserAgent,
+		a.Success,
+		a.Reason,
+	).Scan(&a.ID, &a.CreatedAt)
+}
+
+func (r *loginAttemptRepository) CountFailuresByIP(ipAddress string, since time.Time) (int, error) {
+	var count int
+	query := `
+        SELECT COUNT(*)
+        FROM login_attempts
+        WHERE ip_address = $1 AND NOT success AND created_at >= $2`
+
+	err := r.db.DB.QueryRow(query, ipAddress, since).Scan(&count)
+	return count, err
+}
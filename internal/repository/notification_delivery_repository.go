@@ -0,0 +1,167 @@
+// internal/repository/notification_delivery_repository.go
+package repository
+
+import (
+	"GonPay_Backend/internal/domain"
+	"database/sql"
+	"time"
+)
+
+type notificationDeliveryRepository struct {
+	db *PostgresDB
+}
+
+func NewNotificationDeliveryRepository(db *PostgresDB) domain.NotificationDeliveryRepository {
+	return &notificationDeliveryRepository{db: db}
+}
+
+const notificationDeliveryColumns = `
+        notification_delivery_id, notification_id, user_id, channel, status, attempts,
+        next_attempt_at, COALESCE(last_error, ''), sent_at, created_at, updated_at`
+
+func (r *notificationDeliveryRepository) ClaimDue(limit int, lease time.Duration) ([]*domain.NotificationDelivery, error) {
+	// SKIP LOCKED lets several API instances run the dispatcher without
+	// claiming the same rows. The attempt is counted when it is claimed, so
+	// a worker that dies mid-send still uses up an attempt.
+	query := `
+        UPDATE notification_deliveries
+        SET attempts = attempts + 1, next_attempt_at = $1, updated_at = CURRENT_TIMESTAMP
+        WHERE notification_delivery_id IN (
+            SELECT notification_delivery_id
+            FROM notification_deliveries
+            WHERE status = $2 AND next_attempt_at <= CURRENT_TIMESTAMP
+            ORDER BY next_attempt_at ASC
+            LIMIT $3
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING` + notificationDeliveryColumns
+
+	rows, err := r.db.DB.Query(query, time.Now().Add(lease), domain.DeliveryStatusPending, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanNotificationDeliveries(rows)
+}
+
+func (r *notificationDeliveryRepository) SetOutcome(delivery *domain.NotificationDelivery) error {
+	query := `
+        UPDATE notification_deliveries
+        SET status = $1, next_attempt_at = $2, last_error = NULLIF($3, ''), sent_at = $4, updated_at = CURRENT_TIMESTAMP
+        WHERE notification_delivery_id = $5
+        RETURNING updated_at`
+
+	err := r.db.DB.QueryRow(
+		query,
+		delivery.Status,
+		delivery.NextAttemptAt,
+		delivery.LastError,
+		delivery.SentAt,
+		delivery.ID,
+	).Scan(&delivery.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return domain.ErrDeliveryNotFound
+	}
+	return err
+}
+
+func (r *notificationDeliveryRepository) GetByID(id int64) (*domain.NotificationDelivery, error) {
+	query := `SELECT` + notificationDeliveryColumns + `
+        FROM notification_deliveries
+        WHERE notification_delivery_id = $1`
+
+	delivery, err := scanNotificationDelivery(r.db.DB.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrDeliveryNotFound
+	}
+	return delivery, err
+}
+
+func (r *notificationDeliveryRepository) GetByNotificationID(notificationID int64) ([]*domain.NotificationDelivery, error) {
+	query := `SELECT` + notificationDeliveryColumns + `
+        FROM notification_deliveries
+        WHERE notification_id = $1
+        ORDER BY notification_delivery_id ASC`
+
+	rows, err := r.db.DB.Query(query, notificationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanNotificationDeliveries(rows)
+}
+
+func (r *notificationDeliveryRepository) GetDead(channel domain.NotificationChannel, limit, offset int) ([]*domain.NotificationDelivery, error) {
+	query := `SELECT` + notificationDeliveryColumns + `
+        FROM notification_deliveries
+        WHERE status = $1 AND ($2 = '' OR channel = $2)
+        ORDER BY updated_at DESC
+        LIMIT $3 OFFSET $4`
+
+	rows, err := r.db.DB.Query(query, domain.DeliveryStatusDead, string(channel), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanNotificationDeliveries(rows)
+}
+
+func (r *notificationDeliveryRepository) Requeue(id int64) error {
+	query := `
+        UPDATE notification_deliveries
+        SET status = $1, attempts = 0, next_attempt_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+        WHERE notification_delivery_id = $2 AND status = $3`
+
+	result, err := r.db.DB.Exec(query, domain.DeliveryStatusPending, id, domain.DeliveryStatusDead)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrDeliveryNotDead
+	}
+
+	return nil
+}
+
+func scanNotificationDeliveries(rows *sql.Rows) ([]*domain.NotificationDelivery, error) {
+	var deliveries []*domain.NotificationDelivery
+	for rows.Next() {
+		delivery, err := scanNotificationDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+func scanNotificationDelivery(row rowScanner) (*domain.NotificationDelivery, error) {
+	delivery := &domain.NotificationDelivery{}
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.NotificationID,
+		&delivery.UserID,
+		&delivery.Channel,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.LastError,
+		&delivery.SentAt,
+		&delivery.CreatedAt,
+		&delivery.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
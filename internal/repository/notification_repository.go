@@ -15,12 +15,33 @@ func NewNotificationRepository(db *PostgresDB) domain.NotificationRepository {
 }
 
 func (r *notificationRepository) Create(n *domain.Notification) error {
+	tx, err := r.db.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err := insertNotification(tx, n); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insertNotification writes the notification and its outbox rows inside tx,
+// so that repositories can commit a notification together with the change
+// it reports. A nil notification is skipped.
+func insertNotification(tx *sql.Tx, n *domain.Notification) error {
+	if n == nil {
+		return nil
+	}
+
 	query := `
         INSERT INTO notifications (user_id, title, content, notification_type, is_read)
         VALUES ($1, $2, $3, $4, $5)
         RETURNING notification_id, created_at`
 
-	return r.db.DB.QueryRow(
+	err := tx.QueryRow(
 		query,
 		n.UserID,
 		n.Title,
@@ -28,6 +49,39 @@ func (r *notificationRepository) Create(n *domain.Notification) error {
 		n.NotificationType,
 		n.IsRead,
 	).Scan(&n.ID, &n.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	query = `
+        INSERT INTO notification_deliveries (notification_id, user_id, channel, status, next_attempt_at)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING notification_delivery_id, created_at, updated_at`
+
+	n.Deliveries = make([]*domain.NotificationDelivery, 0, len(n.Channels))
+	for _, channel := range n.Channels {
+		delivery := &domain.NotificationDelivery{
+			NotificationID: n.ID,
+			UserID:         n.UserID,
+			Channel:        channel,
+			Status:         domain.DeliveryStatusPending,
+			NextAttemptAt:  n.CreatedAt,
+		}
+		err := tx.QueryRow(
+			query,
+			delivery.NotificationID,
+			delivery.UserID,
+			delivery.Channel,
+			delivery.Status,
+			delivery.NextAttemptAt,
+		).Scan(&delivery.ID, &delivery.CreatedAt, &delivery.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		n.Deliveries = append(n.Deliveries, delivery)
+	}
+
+	return nil
 }
 
 func (r *notificationRepository) GetByID(id int64) (*domain.Notification, error) {
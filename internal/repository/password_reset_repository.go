@@ -0,0 +1,118 @@
+// internal/repository/password_reset_repository.go
+package repository
+
+import (
+	"GonPay_Backend/internal/domain"
+	"database/sql"
+	"time"
+)
+
+type passwordResetRepository struct {
+	db *PostgresDB
+}
+
+func NewPasswordResetRepository(db *PostgresDB) domain.PasswordResetRepository {
+	return &passwordResetRepository{db: db}
+}
+
+func (r *passwordResetRepository) Create(t *domain.PasswordResetToken) error {
+	query := `
+        INSERT INTO password_reset_tokens (user_id, token_hash, expires_at)
+        VALUES ($1, $2, $3)
+        RETURNING token_id, created_at`
+
+	return r.db.DB.QueryRow(query, t.UserID, t.TokenHash, t.ExpiresAt).Scan(&t.ID, &t.CreatedAt)
+}
+
+func (r *passwordResetRepository) GetByHash(tokenHash string) (*domain.PasswordResetToken, error) {
+	t := &domain.PasswordResetToken{}
+	query := `
+        SELECT token_id, user_id, token_hash, expires_at, used_at, created_at
+        FROM password_reset_tokens
+        WHERE token_hash = $1`
+
+	err := r.db.DB.QueryRow(query, tokenHash).Scan(
+		&t.ID,
+		&t.UserID,
+		&t.TokenHash,
+		&t.ExpiresAt,
+		&t.UsedAt,
+		&t.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrInvalidToken
+	}
+	return t, err
+}
+
+func (r *passwordResetRepository) MarkUsed(id int64) (bool, error) {
+	query := `
+        UPDATE password_reset_tokens
+        SET used_at = CURRENT_TIMESTAMP
+        WHERE token_id = $1 AND used_at IS NULL`
+
+	result, err := r.db.DB.Exec(query, id)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+func (r *passwordResetRepository) InvalidateByUserID(userID int64) error {
+	query := `
+        UPDATE password_reset_tokens
+        SET used_at = CURRENT_TIMESTAMP
+        WHERE user_id = $1 AND used_at IS NULL`
+
+	_, err := r.db.DB.Exec(query, userID)
+	return err
+}
+
+func (r *passwordResetRepository) GetLatest(userID int64) (*domain.PasswordResetToken, error) {
+	t := &domain.PasswordResetToken{}
+	query := `
+        SELECT token_id, user_id, token_hash, expires_at, used_at, created_at
+        FROM password_reset_tokens
+        WHERE user_id = $1
+        ORDER BY created_at DESC
+        LIMIT 1`
+
+	err := r.db.DB.QueryRow(query, userID).Scan(
+		&t.ID,
+		&t.UserID,
+		&t.TokenHash,
+		&t.ExpiresAt,
+		&t.UsedAt,
+		&t.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return t, err
+}
+
+func (r *passwordResetRepository) RecordRequest(ipAddress string) error {
+	query := `INSERT INTO password_reset_requests (ip_address) VALUES ($1)`
+
+	_, err := r.db.DB.Exec(query, ipAddress)
+	return err
+}
+
+func (r *passwordResetRepository) CountRequestsByIP(ipAddress string, since time.Time) (int, error) {
+	var count int
+	query := `
+        SELECT COUNT(*)
+        FROM password_reset_requests
+        WHERE ip_address = $1 AND created_at >= $2`
+
+	err := r.db.DB.QueryRow(query, ipAddress, since).Scan(&count)
+	return count, err
+}
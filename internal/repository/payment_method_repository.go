@@ -34,7 +34,7 @@ func (r *paymentMethodRepository) Create(pm *domain.PaymentMethod) error {
 		}
 	}
 
-	return r.db.DB.QueryRow(
+	err = r.db.DB.QueryRow(
 		query,
 		pm.UserID,
 		pm.MethodType,
@@ -43,6 +43,7 @@ func (r *paymentMethodRepository) Create(pm *domain.PaymentMethod) error {
 		pm.IsDefault,
 		pm.Status,
 	).Scan(&pm.ID, &pm.CreatedAt)
+	return translateError(err)
 }
 
 func (r *paymentMethodRepository) Update(pm *domain.PaymentMethod) error {
@@ -81,7 +82,7 @@ func (r *paymentMethodRepository) Update(pm *domain.PaymentMethod) error {
 	)
 	if err != nil {
 		tx.Rollback()
-		return err
+		return translateError(err)
 	}
 
 	rows, err := result.RowsAffected()
@@ -0,0 +1,66 @@
+// internal/repository/payout_repository.go
+package repository
+
+import (
+	"GonPay_Backend/internal/domain"
+)
+
+type payoutRepository struct {
+	db *PostgresDB
+}
+
+func NewPayoutRepository(db *PostgresDB) domain.PayoutRepository {
+	return &payoutRepository{db: db}
+}
+
+func (r *payoutRepository) Create(p *domain.Payout) error {
+	query := `
+        INSERT INTO payouts (transaction_id, beneficiary_id, bank_name, account_number, account_name, amount, status)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING payout_id, created_at`
+
+	return r.db.DB.QueryRow(
+		query,
+		p.TransactionID,
+		p.BeneficiaryID,
+		p.BankName,
+		p.AccountNumber,
+		p.AccountName,
+		p.Amount,
+		p.Status,
+	).Scan(&p.ID, &p.CreatedAt)
+}
+
+func (r *payoutRepository) UpdateStatus(id int64, status domain.TransactionStatus, providerReference string) error {
+	query := `
+        UPDATE payouts
+        SET status = $1, provider_reference = NULLIF($2, '')
+        WHERE payout_id = $3`
+
+	result, err := r.db.DB.Exec(query, status, providerReference, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrInvalidOperation
+	}
+
+	return nil
+}
+
+func (r *payoutRepository) HasCompletedPayout(beneficiaryID int64) (bool, error) {
+	var exists bool
+	query := `
+        SELECT EXISTS (
+            SELECT 1 FROM payouts WHERE beneficiary_id = $1 AND status = $2
+        )`
+
+	err := r.db.DB.QueryRow(query, beneficiaryID, domain.TransactionStatusCompleted).Scan(&exists)
+	return exists, err
+}
@@ -22,3 +22,15 @@ func NewPostgresDB(connStr string) (*PostgresDB, error) {
 
 	return &PostgresDB{DB: db}, nil
 }
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows so single-row and
+// multi-row queries can share one scan function.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// queryRower is satisfied by both *sql.DB and *sql.Tx so a query can run
+// on its own or inside a transaction.
+type queryRower interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
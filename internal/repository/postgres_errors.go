@@ -0,0 +1,65 @@
+// internal/repository/postgres_errors.go
+package repository
+
+import (
+	"GonPay_Backend/internal/domain"
+	"errors"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Postgres error codes the repositories translate, from the "Class 23 —
+// Integrity Constraint Violation" section of the Postgres manual.
+const (
+	pqUniqueViolation     = "23505"
+	pqForeignKeyViolation = "23503"
+	pqCheckViolation      = "23514"
+)
+
+// constraintMessages are client messages for unique constraints a user can
+// run into. Other constraints get a generic message.
+var constraintMessages = map[string]string{
+	"users_username_key":                                        "username is already taken",
+	"users_email_key":                                           "email is already registered",
+	"users_phone_number_key":                                    "phone number is already registered",
+	"payment_methods_user_id_account_number_key":                "payment method with this account number already exists",
+	"beneficiaries_user_id_account_identifier_account_type_key": "beneficiary with this account already exists",
+}
+
+// translateError turns integrity constraint violations into AppErrors, so
+// they reach the client as a 409 or 400 with a stable code instead of a raw
+// driver message. The original error stays available as the cause. Other
+// errors are returned unchanged.
+func translateError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+
+	switch pqErr.Code {
+	case pqUniqueViolation:
+		if pqErr.Constraint == "idx_kyc_submissions_one_pending" {
+			return domain.WrapAppError(domain.CodeKYCPending, domain.ErrKYCPending.Message, err)
+		}
+		return domain.WrapAppError(domain.CodeAlreadyExists, constraintMessage(pqErr, "a record with these details already exists"), err)
+	case pqForeignKeyViolation:
+		// The same code covers inserting a row that points nowhere and
+		// deleting a row that is still referenced
+		if strings.HasPrefix(pqErr.Message, "update or delete") {
+			return domain.WrapAppError(domain.CodeConflict, "the record is still in use", err)
+		}
+		return domain.WrapAppError(domain.CodeInvalidReference, "a referenced record does not exist", err)
+	case pqCheckViolation:
+		return domain.WrapAppError(domain.CodeValidationFailed, "a value is out of the allowed range", err)
+	default:
+		return err
+	}
+}
+
+func constraintMessage(pqErr *pq.Error, fallback string) string {
+	if message, ok := constraintMessages[pqErr.Constraint]; ok {
+		return message
+	}
+	return fallback
+}
@@ -0,0 +1,89 @@
+// internal/repository/push_device_repository.go
+package repository
+
+import (
+	"GonPay_Backend/internal/domain"
+)
+
+type pushDeviceRepository struct {
+	db *PostgresDB
+}
+
+func NewPushDeviceRepository(db *PostgresDB) domain.PushDeviceRepository {
+	return &pushDeviceRepository{db: db}
+}
+
+func (r *pushDeviceRepository) Register(device *domain.PushDevice) error {
+	// A token belongs to one app install. When another user signs in on the
+	// same install the token moves to them.
+	query := `
+        INSERT INTO push_devices (user_id, platform, token)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (token) DO UPDATE
+        SET user_id = EXCLUDED.user_id, platform = EXCLUDED.platform, last_seen_at = CURRENT_TIMESTAMP
+        RETURNING push_device_id, created_at, last_seen_at`
+
+	err := r.db.DB.QueryRow(query, device.UserID, device.Platform, device.Token).
+		Scan(&device.ID, &device.CreatedAt, &device.LastSeenAt)
+	return translateError(err)
+}
+
+func (r *pushDeviceRepository) GetByUserID(userID int64) ([]*domain.PushDevice, error) {
+	query := `
+        SELECT push_device_id, user_id, platform, token, created_at, last_seen_at
+        FROM push_devices
+        WHERE user_id = $1
+        ORDER BY last_seen_at DESC`
+
+	rows, err := r.db.DB.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var devices []*domain.PushDevice
+	for rows.Next() {
+		device := &domain.PushDevice{}
+		err := rows.Scan(
+			&device.ID,
+			&device.UserID,
+			&device.Platform,
+			&device.Token,
+			&device.CreatedAt,
+			&device.LastSeenAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, device)
+	}
+
+	return devices, rows.Err()
+}
+
+func (r *pushDeviceRepository) Delete(id int64, userID int64) error {
+	query := `DELETE FROM push_devices WHERE push_device_id = $1 AND user_id = $2`
+
+	result, err := r.db.DB.Exec(query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrPushDeviceNotFound
+	}
+
+	return nil
+}
+
+func (r *pushDeviceRepository) DeleteByToken(token string) error {
+	query := `DELETE FROM push_devices WHERE token = $1`
+
+	_, err := r.db.DB.Exec(query, token)
+	return err
+}
@@ -0,0 +1,76 @@
+// internal/repository/request_signing_repository.go
+package repository
+
+import (
+	"GonPay_Backend/internal/domain"
+	"database/sql"
+	"time"
+)
+
+type signingSecretRepository struct {
+	db *PostgresDB
+}
+
+func NewSigningSecretRepository(db *PostgresDB) domain.SigningSecretRepository {
+	return &signingSecretRepository{db: db}
+}
+
+func (r *signingSecretRepository) Save(secret *domain.SigningSecret) error {
+	query := `
+        INSERT INTO merchant_signing_secrets (user_id, secret)
+        VALUES ($1, $2)
+        ON CONFLICT (user_id) DO UPDATE
+        SET secret = EXCLUDED.secret, created_at = CURRENT_TIMESTAMP
+        RETURNING created_at`
+
+	return r.db.DB.QueryRow(query, secret.UserID, secret.Secret).Scan(&secret.CreatedAt)
+}
+
+func (r *signingSecretRepository) GetByUserID(userID int64) (*domain.SigningSecret, error) {
+	secret := &domain.SigningSecret{}
+	query := `
+        SELECT user_id, secret, created_at
+        FROM merchant_signing_secrets
+        WHERE user_id = $1`
+
+	err := r.db.DB.QueryRow(query, userID).Scan(&secret.UserID, &secret.Secret, &secret.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return secret, err
+}
+
+type requestNonceRepository struct {
+	db *PostgresDB
+}
+
+func NewRequestNonceRepository(db *PostgresDB) domain.RequestNonceRepository {
+	return &requestNonceRepository{db: db}
+}
+
+func (r *requestNonceRepository) Store(userID int64, nonce string, at time.Time) (bool, error) {
+	query := `
+        INSERT INTO request_nonces (user_id, nonce, created_at)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (user_id, nonce) DO NOTHING`
+
+	result, err := r.db.DB.Exec(query, userID, nonce, at)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+func (r *requestNonceRepository) DeleteOlderThan(before time.Time) (int64, error) {
+	result, err := r.db.DB.Exec(`DELETE FROM request_nonces WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,181 @@
+// internal/repository/session_repository.go
+package repository
+
+import (
+	"GonPay_Backend/internal/domain"
+	"database/sql"
+)
+
+type sessionRepository struct {
+	db *PostgresDB
+}
+
+func NewSessionRepository(db *PostgresDB) domain.SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+const sessionColumns = `
+        session_id, user_id, user_agent, ip_address, impersonated_by, expires_at, revoked_at, last_used_at, created_at`
+
+func (r *sessionRepository) Create(s *domain.Session) error {
+	query := `
+        INSERT INTO sessions (user_id, user_agent, ip_address, impersonated_by, expires_at)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING session_id, last_used_at, created_at`
+
+	return r.db.DB.QueryRow(
+		query,
+		s.UserID,
+		s.UserAgent,
+		s.IPAddress,
+		s.ImpersonatedBy,
+		s.ExpiresAt,
+	).Scan(&s.ID, &s.LastUsedAt, &s.CreatedAt)
+}
+
+func (r *sessionRepository) GetByID(id int64) (*domain.Session, error) {
+	query := `SELECT` + sessionColumns + `
+        FROM sessions
+        WHERE session_id = $1`
+
+	s, err := scanSession(r.db.DB.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrSessionNotFound
+	}
+	return s, err
+}
+
+func (r *sessionRepository) GetActiveByUserID(userID int64) ([]*domain.Session, error) {
+	query := `SELECT` + sessionColumns + `
+        FROM sessions
+        WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+        ORDER BY last_used_at DESC`
+
+	rows, err := r.db.DB.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*domain.Session
+	for rows.Next() {
+		s, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+
+	return sessions, nil
+}
+
+func (r *sessionRepository) Touch(id int64, ipAddress, userAgent string) error {
+	query := `
+        UPDATE sessions
+        SET ip_address = $1, user_agent = $2, last_used_at = CURRENT_TIMESTAMP
+        WHERE session_id = $3`
+
+	_, err := r.db.DB.Exec(query, ipAddress, userAgent, id)
+	return err
+}
+
+func (r *sessionRepository) Revoke(id int64) error {
+	query := `
+        UPDATE sessions
+        SET revoked_at = CURRENT_TIMESTAMP
+        WHERE session_id = $1 AND revoked_at IS NULL`
+
+	_, err := r.db.DB.Exec(query, id)
+	return err
+}
+
+func (r *sessionRepository) RevokeAllByUserID(userID int64, notification *domain.Notification) error {
+	tx, err := r.db.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	query := `
+        UPDATE sessions
+        SET revoked_at = CURRENT_TIMESTAMP
+        WHERE user_id = $1 AND revoked_at IS NULL`
+
+	if _, err := tx.Exec(query, userID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := insertNotification(tx, notification); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *sessionRepository) CreateRefreshToken(t *domain.RefreshToken) error {
+	query := `
+        INSERT INTO refresh_tokens (token_hash, session_id)
+        VALUES ($1, $2)
+        RETURNING created_at`
+
+	return r.db.DB.QueryRow(query, t.TokenHash, t.SessionID).Scan(&t.CreatedAt)
+}
+
+func (r *sessionRepository) GetRefreshToken(tokenHash string) (*domain.RefreshToken, error) {
+	t := &domain.RefreshToken{}
+	query := `
+        SELECT token_hash, session_id, used_at, created_at
+        FROM refresh_tokens
+        WHERE token_hash = $1`
+
+	err := r.db.DB.QueryRow(query, tokenHash).Scan(
+		&t.TokenHash,
+		&t.SessionID,
+		&t.UsedAt,
+		&t.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrInvalidToken
+	}
+	return t, err
+}
+
+func (r *sessionRepository) MarkRefreshTokenUsed(tokenHash string) (bool, error) {
+	query := `
+        UPDATE refresh_tokens
+        SET used_at = CURRENT_TIMESTAMP
+        WHERE token_hash = $1 AND used_at IS NULL`
+
+	result, err := r.db.DB.Exec(query, tokenHash)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+func scanSession(row rowScanner) (*domain.Session, error) {
+	s := &domain.Session{}
+	err := row.Scan(
+		&s.ID,
+		&s.UserID,
+		&s.UserAgent,
+		&s.IPAddress,
+		&s.ImpersonatedBy,
+		&s.ExpiresAt,
+		&s.RevokedAt,
+		&s.LastUsedAt,
+		&s.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
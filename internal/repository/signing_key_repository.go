@@ -0,0 +1,80 @@
+// internal/repository/signing_key_repository.go
+package repository
+
+import (
+	"GonPay_Backend/internal/domain"
+	"time"
+)
+
+type signingKeyRepository struct {
+	db *PostgresDB
+}
+
+func NewSigningKeyRepository(db *PostgresDB) domain.SigningKeyRepository {
+	return &signingKeyRepository{db: db}
+}
+
+func (r *signingKeyRepository) Create(key *domain.SigningKey) error {
+	query := `
+        INSERT INTO signing_keys (kid, algorithm, private_key_encrypted, public_key_pem)
+        VALUES ($1, $2, $3, $4)
+        RETURNING created_at`
+
+	return r.db.DB.QueryRow(query, key.KID, key.Algorithm, key.EncryptedPrivateKey, key.PublicKeyPEM).Scan(&key.CreatedAt)
+}
+
+func (r *signingKeyRepository) GetVerifiable(now time.Time) ([]*domain.SigningKey, error) {
+	query := `
+        SELECT kid, algorithm, private_key_encrypted, public_key_pem, created_at, retired_at, expires_at
+        FROM signing_keys
+        WHERE expires_at IS NULL OR expires_at > $1
+        ORDER BY created_at DESC`
+
+	rows, err := r.db.DB.Query(query, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*domain.SigningKey
+	for rows.Next() {
+		key := &domain.SigningKey{}
+		if err := rows.Scan(
+			&key.KID,
+			&key.Algorithm,
+			&key.EncryptedPrivateKey,
+			&key.PublicKeyPEM,
+			&key.CreatedAt,
+			&key.RetiredAt,
+			&key.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+func (r *signingKeyRepository) RetireAllExcept(kid string, retiredAt, expiresAt time.Time) (int64, error) {
+	query := `
+        UPDATE signing_keys
+        SET retired_at = $1, expires_at = $2, private_key_encrypted = NULL
+        WHERE kid <> $3 AND retired_at IS NULL`
+
+	result, err := r.db.DB.Exec(query, retiredAt, expiresAt, kid)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *signingKeyRepository) DeleteExpired(now time.Time) (int64, error) {
+	query := `DELETE FROM signing_keys WHERE expires_at IS NOT NULL AND expires_at <= $1`
+
+	result, err := r.db.DB.Exec(query, now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -20,34 +20,55 @@ func NewTransactionLimitRepository(db *PostgresDB) domain.TransactionLimitReposi
 
 func (r *transactionLimitRepository) Create(limit *domain.TransactionLimit) error {
 	query := `
-        INSERT INTO transaction_limits (user_id, transaction_type, daily_limit, monthly_limit)
-        VALUES ($1, $2, $3, $4)
+        INSERT INTO transaction_limits (
+            user_id, transaction_type, daily_limit, monthly_limit,
+            max_single_amount, min_amount, hourly_count_limit, daily_count_limit,
+            new_recipient_max_amount, new_recipient_daily_limit, window_mode
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
         RETURNING limit_id, created_at`
 
-	return r.db.DB.QueryRow(
+	err := r.db.DB.QueryRow(
 		query,
 		limit.UserID,
 		limit.TransactionType,
 		limit.DailyLimit,
 		limit.MonthlyLimit,
+		limit.MaxSingleAmount,
+		limit.MinAmount,
+		limit.HourlyCountLimit,
+		limit.DailyCountLimit,
+		limit.NewRecipientMaxAmount,
+		limit.NewRecipientDailyLimit,
+		limit.WindowMode,
 	).Scan(&limit.ID, &limit.CreatedAt)
+	return translateError(err)
 }
 
 func (r *transactionLimitRepository) Update(limit *domain.TransactionLimit) error {
 	query := `
         UPDATE transaction_limits 
-        SET daily_limit = $1, monthly_limit = $2
-        WHERE limit_id = $3 AND user_id = $4`
+        SET daily_limit = $1, monthly_limit = $2, max_single_amount = $3, min_amount = $4,
+            hourly_count_limit = $5, daily_count_limit = $6,
+            new_recipient_max_amount = $7, new_recipient_daily_limit = $8, window_mode = $9
+        WHERE limit_id = $10 AND user_id = $11`
 
 	result, err := r.db.DB.Exec(
 		query,
 		limit.DailyLimit,
 		limit.MonthlyLimit,
+		limit.MaxSingleAmount,
+		limit.MinAmount,
+		limit.HourlyCountLimit,
+		limit.DailyCountLimit,
+		limit.NewRecipientMaxAmount,
+		limit.NewRecipientDailyLimit,
+		limit.WindowMode,
 		limit.ID,
 		limit.UserID,
 	)
 	if err != nil {
-		return err
+		return translateError(err)
 	}
 
 	rows, err := result.RowsAffected()
@@ -63,21 +84,14 @@ func (r *transactionLimitRepository) Update(limit *domain.TransactionLimit) erro
 }
 
 func (r *transactionLimitRepository) GetByUserAndType(userID int64, transactionType domain.TransactionType) (*domain.TransactionLimit, error) {
-	limit := &domain.TransactionLimit{}
 	query := `
-        SELECT limit_id, user_id, transaction_type, daily_limit, monthly_limit, created_at
+        SELECT limit_id, user_id, transaction_type, daily_limit, monthly_limit,
+               max_single_amount, min_amount, hourly_count_limit, daily_count_limit,
+               new_recipient_max_amount, new_recipient_daily_limit, window_mode, created_at
         FROM transaction_limits 
         WHERE user_id = $1 AND transaction_type = $2`
 
-	err := r.db.DB.QueryRow(query, userID, transactionType).Scan(
-		&limit.ID,
-		&limit.UserID,
-		&limit.TransactionType,
-		&limit.DailyLimit,
-		&limit.MonthlyLimit,
-		&limit.CreatedAt,
-	)
-
+	limit, err := scanTransactionLimit(r.db.DB.QueryRow(query, userID, transactionType))
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -86,7 +100,9 @@ func (r *transactionLimitRepository) GetByUserAndType(userID int64, transactionT
 
 func (r *transactionLimitRepository) GetByUserID(userID int64) ([]*domain.TransactionLimit, error) {
 	query := `
-        SELECT limit_id, user_id, transaction_type, daily_limit, monthly_limit, created_at
+        SELECT limit_id, user_id, transaction_type, daily_limit, monthly_limit,
+               max_single_amount, min_amount, hourly_count_limit, daily_count_limit,
+               new_recipient_max_amount, new_recipient_daily_limit, window_mode, created_at
         FROM transaction_limits 
         WHERE user_id = $1
         ORDER BY created_at DESC`
@@ -99,15 +115,7 @@ func (r *transactionLimitRepository) GetByUserID(userID int64) ([]*domain.Transa
 
 	var limits []*domain.TransactionLimit
 	for rows.Next() {
-		limit := &domain.TransactionLimit{}
-		err := rows.Scan(
-			&limit.ID,
-			&limit.UserID,
-			&limit.TransactionType,
-			&limit.DailyLimit,
-			&limit.MonthlyLimit,
-			&limit.CreatedAt,
-		)
+		limit, err := scanTransactionLimit(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -117,52 +125,146 @@ func (r *transactionLimitRepository) GetByUserID(userID int64) ([]*domain.Transa
 	return limits, nil
 }
 
-func (r *transactionLimitRepository) CheckLimit(userID int64, transactionType domain.TransactionType, amount float64) error {
-	// Check daily limit
-	dailyQuery := `
-        SELECT COALESCE(SUM(amount), 0)
-        FROM transactions
-        WHERE source_wallet_id IN (SELECT wallet_id FROM wallets WHERE user_id = $1)
-        AND transaction_type = $2
-        AND created_at >= CURRENT_DATE`
+func (r *transactionLimitRepository) GetUsage(userID int64, transactionType domain.TransactionType, windows domain.LimitWindows) (*domain.LimitUsage, error) {
+	return getUsage(r.db.DB, userID, transactionType, windows)
+}
 
-	var dailyTotal float64
-	if err := r.db.DB.QueryRow(dailyQuery, userID, transactionType).Scan(&dailyTotal); err != nil {
+// CreateWithinLimit holds a per-user advisory lock for the whole database
+// transaction, so concurrent payments by the same user read usage one at a
+// time and each sees the rows inserted before it.
+func (r *transactionLimitRepository) CreateWithinLimit(userID int64, transaction *domain.Transaction, windows domain.LimitWindows, check func(usage *domain.LimitUsage) error) error {
+	tx, err := r.db.DB.Begin()
+	if err != nil {
 		return err
 	}
 
-	// Check monthly limit
-	monthlyQuery := `
-        SELECT COALESCE(SUM(amount), 0)
-        FROM transactions
-        WHERE source_wallet_id IN (SELECT wallet_id FROM wallets WHERE user_id = $1)
-        AND transaction_type = $2
-        AND created_at >= DATE_TRUNC('month', CURRENT_DATE)`
-
-	var monthlyTotal float64
-	if err := r.db.DB.QueryRow(monthlyQuery, userID, transactionType).Scan(&monthlyTotal); err != nil {
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock($1)`, userID); err != nil {
+		tx.Rollback()
 		return err
 	}
 
-	// Get limits
-	limit, err := r.GetByUserAndType(userID, transactionType)
-	if err != nil {
-		return err
+	if check != nil {
+		usage, err := getUsage(tx, userID, transaction.Type, windows)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := check(usage); err != nil {
+			tx.Rollback()
+			return err
+		}
 	}
 
-	// If no limits set, allow transaction
-	if limit == nil {
-		return nil
+	if err := insertTransaction(tx, transaction); err != nil {
+		tx.Rollback()
+		return err
 	}
 
-	// Check if transaction would exceed limits
-	if dailyTotal+amount > limit.DailyLimit {
-		return domain.ErrInvalidOperation
+	return tx.Commit()
+}
+
+func getUsage(db queryRower, userID int64, transactionType domain.TransactionType, windows domain.LimitWindows) (*domain.LimitUsage, error) {
+	usage := &domain.LimitUsage{}
+
+	// The month window always starts first, so it bounds the whole query.
+	// Failed and reversed transactions never count towards a limit.
+	query := `
+        SELECT
+            COALESCE(SUM(amount) FILTER (WHERE created_at >= $4), 0),
+            COALESCE(SUM(amount), 0),
+            COUNT(*) FILTER (WHERE created_at >= $3),
+            COUNT(*) FILTER (WHERE created_at >= $4)
+        FROM transactions
+        WHERE source_wallet_id IN (SELECT wallet_id FROM wallets WHERE user_id = $1)
+        AND transaction_type = $2
+        AND status NOT IN ('FAILED', 'REVERSED')
+        AND created_at >= $5`
+
+	err := db.QueryRow(query, userID, transactionType, windows.HourStart, windows.DayStart, windows.MonthStart).Scan(
+		&usage.DailyAmount,
+		&usage.MonthlyAmount,
+		&usage.HourlyCount,
+		&usage.DailyCount,
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	if monthlyTotal+amount > limit.MonthlyLimit {
-		return domain.ErrInvalidOperation
+	// Amount sent in the day window to wallets of other users, or paid out to
+	// beneficiaries, that had never been paid before the window started
+	newRecipientQuery := `
+        SELECT COALESCE(SUM(t.amount), 0)
+        FROM transactions t
+        WHERE t.source_wallet_id IN (SELECT wallet_id FROM wallets WHERE user_id = $1)
+        AND t.transaction_type = $2
+        AND t.status NOT IN ('FAILED', 'REVERSED')
+        AND t.created_at >= $3
+        AND (
+            (
+                t.destination_wallet_id NOT IN (SELECT wallet_id FROM wallets WHERE user_id = $1)
+                AND NOT EXISTS (
+                    SELECT 1 FROM transactions p
+                    WHERE p.source_wallet_id IN (SELECT wallet_id FROM wallets WHERE user_id = $1)
+                    AND p.destination_wallet_id = t.destination_wallet_id
+                    AND p.status = 'COMPLETED'
+                    AND p.created_at < $3
+                )
+            )
+            OR EXISTS (
+                SELECT 1 FROM payouts po
+                WHERE po.transaction_id = t.transaction_id
+                AND NOT EXISTS (
+                    SELECT 1 FROM payouts pp
+                    WHERE pp.beneficiary_id = po.beneficiary_id
+                    AND pp.status = 'COMPLETED'
+                    AND pp.created_at < $3
+                )
+            )
+        )`
+
+	if err := db.QueryRow(newRecipientQuery, userID, transactionType, windows.DayStart).Scan(&usage.NewRecipientDailyAmount); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return usage, nil
+}
+
+func (r *transactionLimitRepository) HasPaidRecipient(userID int64, destinationWalletID int64) (bool, error) {
+	// The user's own wallets are never considered new recipients
+	query := `
+        SELECT EXISTS (
+            SELECT 1 FROM wallets WHERE wallet_id = $2 AND user_id = $1
+        ) OR EXISTS (
+            SELECT 1 FROM transactions
+            WHERE source_wallet_id IN (SELECT wallet_id FROM wallets WHERE user_id = $1)
+            AND destination_wallet_id = $2
+            AND status = 'COMPLETED'
+        )`
+
+	var paid bool
+	err := r.db.DB.QueryRow(query, userID, destinationWalletID).Scan(&paid)
+	return paid, err
+}
+
+func scanTransactionLimit(row rowScanner) (*domain.TransactionLimit, error) {
+	limit := &domain.TransactionLimit{}
+	err := row.Scan(
+		&limit.ID,
+		&limit.UserID,
+		&limit.TransactionType,
+		&limit.DailyLimit,
+		&limit.MonthlyLimit,
+		&limit.MaxSingleAmount,
+		&limit.MinAmount,
+		&limit.HourlyCountLimit,
+		&limit.DailyCountLimit,
+		&limit.NewRecipientMaxAmount,
+		&limit.NewRecipientDailyLimit,
+		&limit.WindowMode,
+		&limit.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return limit, nil
 }
@@ -0,0 +1,118 @@
+// internal/repository/transaction_pin_repository.go
+package repository
+
+import (
+	"GonPay_Backend/internal/domain"
+	"database/sql"
+	"time"
+)
+
+type transactionPINRepository struct {
+	db *PostgresDB
+}
+
+func NewTransactionPINRepository(db *PostgresDB) domain.TransactionPINRepository {
+	return &transactionPINRepository{db: db}
+}
+
+func (r *transactionPINRepository) Save(pin *domain.TransactionPIN) error {
+	query := `
+        INSERT INTO transaction_pins (user_id, pin_hash)
+        VALUES ($1, $2)
+        ON CONFLICT (user_id) DO UPDATE
+        SET pin_hash = EXCLUDED.pin_hash, updated_at = CURRENT_TIMESTAMP
+        RETURNING created_at, updated_at`
+
+	return r.db.DB.QueryRow(query, pin.UserID, pin.PINHash).Scan(&pin.CreatedAt, &pin.UpdatedAt)
+}
+
+func (r *transactionPINRepository) GetByUserID(userID int64) (*domain.TransactionPIN, error) {
+	pin := &domain.TransactionPIN{}
+	query := `
+        SELECT user_id, pin_hash, created_at, updated_at
+        FROM transaction_pins
+        WHERE user_id = $1`
+
+	err := r.db.DB.QueryRow(query, userID).Scan(
+		&pin.UserID,
+		&pin.PINHash,
+		&pin.CreatedAt,
+		&pin.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return pin, err
+}
+
+func (r *transactionPINRepository) ClaimAttempt(userID int64, max int) (int, bool, error) {
+	// An expired lock starts a new count. The WHERE clause makes the claim
+	// and the limit check one statement, so no row comes back once the
+	// user is locked or max attempts are in flight.
+	query := `
+        INSERT INTO step_up_failures (user_id, failed_attempts)
+        VALUES ($1, 1)
+        ON CONFLICT (user_id) DO UPDATE
+        SET failed_attempts = CASE
+                WHEN step_up_failures.locked_until <= CURRENT_TIMESTAMP THEN 1
+                ELSE step_up_failures.failed_attempts + 1
+            END,
+            locked_until = NULL,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE (step_up_failures.locked_until IS NULL OR step_up_failures.locked_until <= CURRENT_TIMESTAMP)
+          AND ($2 <= 0 OR step_up_failures.locked_until IS NOT NULL OR step_up_failures.failed_attempts < $2)
+        RETURNING failed_attempts`
+
+	var attempts int
+	err := r.db.DB.QueryRow(query, userID, max).Scan(&attempts)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return attempts, true, nil
+}
+
+func (r *transactionPINRepository) Lock(userID int64, until time.Time, notification *domain.Notification) error {
+	tx, err := r.db.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE step_up_failures SET locked_until = $1, updated_at = CURRENT_TIMESTAMP WHERE user_id = $2`
+
+	if _, err := tx.Exec(query, until, userID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := insertNotification(tx, notification); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *transactionPINRepository) LockedUntil(userID int64) (*time.Time, error) {
+	var lockedUntil *time.Time
+	query := `
+        SELECT locked_until
+        FROM step_up_failures
+        WHERE user_id = $1 AND locked_until > CURRENT_TIMESTAMP`
+
+	err := r.db.DB.QueryRow(query, userID).Scan(&lockedUntil)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return lockedUntil, err
+}
+
+func (r *transactionPINRepository) ResetFailures(userID int64) error {
+	query := `DELETE FROM step_up_failures WHERE user_id = $1`
+
+	_, err := r.db.DB.Exec(query, userID)
+	return err
+}
@@ -15,13 +15,17 @@ func NewTransactionRepository(db *PostgresDB) domain.TransactionRepository {
 }
 
 func (r *transactionRepository) Create(tx *domain.Transaction) error {
+	return insertTransaction(r.db.DB, tx)
+}
+
+func insertTransaction(db queryRower, tx *domain.Transaction) error {
 	query := `
         INSERT INTO transactions 
         (source_wallet_id, destination_wallet_id, transaction_type, amount, reference_id, status, description)
         VALUES ($1, $2, $3, $4, uuid_generate_v4(), $5, $6)
         RETURNING transaction_id, reference_id, created_at`
 
-	return r.db.DB.QueryRow(
+	return db.QueryRow(
 		query,
 		tx.SourceWalletID,
 		tx.DestinationWalletID,
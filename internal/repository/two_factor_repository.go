@@ -0,0 +1,162 @@
+// internal/repository/two_factor_repository.go
+package repository
+
+import (
+	"GonPay_Backend/internal/domain"
+	"database/sql"
+)
+
+type twoFactorRepository struct {
+	db *PostgresDB
+}
+
+func NewTwoFactorRepository(db *PostgresDB) domain.TwoFactorRepository {
+	return &twoFactorRepository{db: db}
+}
+
+func (r *twoFactorRepository) Save(tf *domain.TwoFactor) error {
+	query := `
+        INSERT INTO user_two_factor (user_id, secret, enabled)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (user_id) DO UPDATE
+        SET secret = EXCLUDED.secret, enabled = EXCLUDED.enabled, last_used_step = 0,
+            confirmed_at = NULL, created_at = CURRENT_TIMESTAMP
+        RETURNING created_at`
+
+	return r.db.DB.QueryRow(query, tf.UserID, tf.Secret, tf.Enabled).Scan(&tf.CreatedAt)
+}
+
+func (r *twoFactorRepository) GetByUserID(userID int64) (*domain.TwoFactor, error) {
+	tf := &domain.TwoFactor{}
+	query := `
+        SELECT user_id, secret, enabled, last_used_step, confirmed_at, created_at
+        FROM user_two_factor
+        WHERE user_id = $1`
+
+	err := r.db.DB.QueryRow(query, userID).Scan(
+		&tf.UserID,
+		&tf.Secret,
+		&tf.Enabled,
+		&tf.LastUsedStep,
+		&tf.ConfirmedAt,
+		&tf.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return tf, err
+}
+
+func (r *twoFactorRepository) Enable(userID int64) error {
+	query := `
+        UPDATE user_two_factor
+        SET enabled = true, confirmed_at = CURRENT_TIMESTAMP
+        WHERE user_id = $1`
+
+	result, err := r.db.DB.Exec(query, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrInvalidOperation
+	}
+
+	return nil
+}
+
+func (r *twoFactorRepository) Delete(userID int64, notification *domain.Notification) error {
+	tx, err := r.db.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM recovery_codes WHERE user_id = $1`, userID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM user_two_factor WHERE user_id = $1`, userID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := insertNotification(tx, notification); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *twoFactorRepository) UseStep(userID int64, step int64) (bool, error) {
+	query := `
+        UPDATE user_two_factor
+        SET last_used_step = $1
+        WHERE user_id = $2 AND last_used_step < $1`
+
+	result, err := r.db.DB.Exec(query, step, userID)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+func (r *twoFactorRepository) ReplaceRecoveryCodes(userID int64, codeHashes []string) error {
+	tx, err := r.db.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM recovery_codes WHERE user_id = $1`, userID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, hash := range codeHashes {
+		if _, err := tx.Exec(`INSERT INTO recovery_codes (user_id, code_hash) VALUES ($1, $2)`, userID, hash); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *twoFactorRepository) UseRecoveryCode(userID int64, codeHash string) (bool, error) {
+	query := `
+        UPDATE recovery_codes
+        SET used_at = CURRENT_TIMESTAMP
+        WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`
+
+	result, err := r.db.DB.Exec(query, userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+func (r *twoFactorRepository) CountRecoveryCodes(userID int64) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM recovery_codes WHERE user_id = $1 AND used_at IS NULL`
+	err := r.db.DB.QueryRow(query, userID).Scan(&count)
+	return count, err
+}
@@ -4,6 +4,8 @@ package repository
 import (
 	"GonPay_Backend/internal/domain"
 	"database/sql"
+	"strings"
+	"time"
 )
 
 type userRepository struct {
@@ -37,7 +39,7 @@ func (r *userRepository) Create(user *domain.User) error {
         VALUES ($1, $2, $3, $4, $5, $6, $7)
         RETURNING user_id, created_at, updated_at`
 
-	return r.db.DB.QueryRow(
+	err := r.db.DB.QueryRow(
 		query,
 		user.Username,
 		user.Email,
@@ -47,28 +49,20 @@ func (r *userRepository) Create(user *domain.User) error {
 		user.Preferences,
 		user.Role,
 	).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+	return translateError(err)
 }
 
+const userColumns = `
+        user_id, username, email, COALESCE(phone_number, ''), password_hash, status, preferences, role, kyc_tier,
+        email_verified_at, phone_verified_at, failed_login_attempts, last_failed_login_at, locked_until,
+        closed_at, retain_until, created_at, updated_at`
+
 func (r *userRepository) GetByID(id int64) (*domain.User, error) {
-	user := &domain.User{}
-	query := `
-        SELECT user_id, username, email, phone_number, password_hash, status, preferences, role, created_at, updated_at
+	query := `SELECT` + userColumns + `
         FROM users 
         WHERE user_id = $1`
 
-	err := r.db.DB.QueryRow(query, id).Scan(
-		&user.ID,
-		&user.Username,
-		&user.Email,
-		&user.PhoneNumber,
-		&user.PasswordHash,
-		&user.Status,
-		&user.Preferences,
-		&user.Role, // Thêm role vào đây
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
-
+	user, err := scanUser(r.db.DB.QueryRow(query, id))
 	if err == sql.ErrNoRows {
 		return nil, domain.ErrUserNotFound
 	}
@@ -76,25 +70,11 @@ func (r *userRepository) GetByID(id int64) (*domain.User, error) {
 }
 
 func (r *userRepository) GetByEmail(email string) (*domain.User, error) {
-	user := &domain.User{}
-	query := `
-        SELECT user_id, username, email, phone_number, password_hash, status, preferences, role, created_at, updated_at
+	query := `SELECT` + userColumns + `
         FROM users 
         WHERE email = $1`
 
-	err := r.db.DB.QueryRow(query, email).Scan(
-		&user.ID,
-		&user.Username,
-		&user.Email,
-		&user.PhoneNumber,
-		&user.PasswordHash,
-		&user.Status,
-		&user.Preferences,
-		&user.Role, // Thêm role vào đây
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
-
+	user, err := scanUser(r.db.DB.QueryRow(query, email))
 	if err == sql.ErrNoRows {
 		return nil, domain.ErrUserNotFound
 	}
@@ -104,8 +84,9 @@ func (r *userRepository) GetByEmail(email string) (*domain.User, error) {
 func (r *userRepository) Update(user *domain.User) error {
 	query := `
         UPDATE users 
-        SET username = $1, email = $2, phone_number = $3, status = $4, preferences = $5, role = $6, updated_at = $7
-        WHERE user_id = $8`
+        SET username = $1, email = $2, phone_number = $3, status = $4, role = $5, updated_at = $6,
+            email_verified_at = $7, phone_verified_at = $8
+        WHERE user_id = $9`
 
 	result, err := r.db.DB.Exec(
 		query,
@@ -113,15 +94,240 @@ func (r *userRepository) Update(user *domain.User) error {
 		user.Email,
 		user.PhoneNumber,
 		user.Status,
-		user.Preferences,
 		user.Role, // Thêm role vào đây
 		user.UpdatedAt,
+		user.EmailVerifiedAt,
+		user.PhoneVerifiedAt,
 		user.ID,
 	)
 	if err != nil {
+		return translateError(err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) UpdatePassword(id int64, passwordHash string) error {
+	query := `UPDATE users SET password_hash = $1, updated_at = CURRENT_TIMESTAMP WHERE user_id = $2`
+
+	result, err := r.db.DB.Exec(query, passwordHash, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) ResetPassword(id int64, passwordHash string, notification *domain.Notification) error {
+	tx, err := r.db.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	query := `
+        UPDATE users
+        SET password_hash = $1, failed_login_attempts = 0, last_failed_login_at = NULL, locked_until = NULL,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE user_id = $2`
+
+	result, err := tx.Exec(query, passwordHash, id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if rows == 0 {
+		tx.Rollback()
+		return domain.ErrUserNotFound
+	}
+
+	query = `UPDATE sessions SET revoked_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND revoked_at IS NULL`
+
+	if _, err := tx.Exec(query, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := insertNotification(tx, notification); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *userRepository) SetVerified(id int64, channel domain.VerificationChannel, at time.Time) error {
+	var query string
+	switch channel {
+	case domain.VerificationChannelEmail:
+		query = `UPDATE users SET email_verified_at = $1 WHERE user_id = $2`
+	case domain.VerificationChannelPhone:
+		query = `UPDATE users SET phone_verified_at = $1 WHERE user_id = $2`
+	default:
+		return domain.ErrInvalidOperation
+	}
+
+	result, err := r.db.DB.Exec(query, at, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) Close(id int64, closedAt, retainUntil time.Time) error {
+	tx, err := r.db.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	// Lock the wallets so no payment can land between the check and closing them
+	query := `
+        SELECT balance, status
+        FROM wallets
+        WHERE user_id = $1 AND status <> $2
+        FOR UPDATE`
+
+	rows, err := tx.Query(query, id, domain.WalletStatusClosed)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	for rows.Next() {
+		var balance float64
+		var status domain.WalletStatus
+		if err := rows.Scan(&balance, &status); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return err
+		}
+		if err := status.CheckDebit(); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return err
+		}
+		if balance != 0 {
+			rows.Close()
+			tx.Rollback()
+			return domain.ErrNonZeroBalance
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query = `
+        UPDATE users
+        SET username = 'closed-' || user_id,
+            email = 'closed-' || user_id || '@closed.invalid',
+            phone_number = NULL,
+            password_hash = '',
+            preferences = '{}',
+            status = $1,
+            email_verified_at = NULL,
+            phone_verified_at = NULL,
+            failed_login_attempts = 0,
+            last_failed_login_at = NULL,
+            locked_until = NULL,
+            closed_at = $2,
+            retain_until = $3,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE user_id = $4 AND status <> $1`
+
+	result, err := tx.Exec(query, domain.UserStatusClosed, closedAt, retainUntil, id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
 		return err
 	}
 
+	if affected == 0 {
+		tx.Rollback()
+		return domain.ErrUserNotFound
+	}
+
+	// Financial records (wallets, transactions, payouts), KYC records and
+	// audit logs are kept. Everything else is revoked or deleted.
+	statements := []struct {
+		query string
+		args  []interface{}
+	}{
+		{`UPDATE wallets SET status = $1 WHERE user_id = $2 AND status <> $1`, []interface{}{domain.WalletStatusClosed, id}},
+		{`UPDATE sessions SET revoked_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND revoked_at IS NULL`, []interface{}{id}},
+		{`UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND revoked_at IS NULL`, []interface{}{id}},
+		{`UPDATE payment_methods SET status = $1, is_default = false WHERE user_id = $2`, []interface{}{domain.UserStatusInactive, id}},
+		{`UPDATE login_attempts SET email = 'closed-' || user_id || '@closed.invalid' WHERE user_id = $1`, []interface{}{id}},
+		{`DELETE FROM beneficiaries WHERE user_id = $1`, []interface{}{id}},
+		{`DELETE FROM notification_deliveries WHERE user_id = $1`, []interface{}{id}},
+		{`DELETE FROM notifications WHERE user_id = $1`, []interface{}{id}},
+		{`DELETE FROM push_devices WHERE user_id = $1`, []interface{}{id}},
+		{`DELETE FROM user_two_factor WHERE user_id = $1`, []interface{}{id}},
+		{`DELETE FROM recovery_codes WHERE user_id = $1`, []interface{}{id}},
+		{`DELETE FROM transaction_pins WHERE user_id = $1`, []interface{}{id}},
+		{`DELETE FROM step_up_failures WHERE user_id = $1`, []interface{}{id}},
+		{`DELETE FROM verification_codes WHERE user_id = $1`, []interface{}{id}},
+		{`DELETE FROM password_reset_tokens WHERE user_id = $1`, []interface{}{id}},
+		{`DELETE FROM merchant_signing_secrets WHERE user_id = $1`, []interface{}{id}},
+	}
+
+	for _, stmt := range statements {
+		if _, err := tx.Exec(stmt.query, stmt.args...); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *userRepository) UpdatePreferences(id int64, preferences string) error {
+	query := `UPDATE users SET preferences = $1, updated_at = CURRENT_TIMESTAMP WHERE user_id = $2`
+
+	result, err := r.db.DB.Exec(query, preferences, id)
+	if err != nil {
+		return translateError(err)
+	}
+
 	rows, err := result.RowsAffected()
 	if err != nil {
 		return err
@@ -134,10 +340,119 @@ func (r *userRepository) Update(user *domain.User) error {
 	return nil
 }
 
-func (r *userRepository) Delete(id int64) error {
+func (r *userRepository) SetStatus(id int64, status domain.UserStatus, notification *domain.Notification) error {
+	tx, err := r.db.DB.Begin()
+	if err != nil {
+		return err
+	}
+
 	query := `UPDATE users SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE user_id = $2`
 
-	result, err := r.db.DB.Exec(query, domain.UserStatusInactive, id)
+	result, err := tx.Exec(query, status, id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if rows == 0 {
+		tx.Rollback()
+		return domain.ErrUserNotFound
+	}
+
+	if status == domain.UserStatusInactive {
+		query = `UPDATE sessions SET revoked_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND revoked_at IS NULL`
+
+		if _, err := tx.Exec(query, id); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := insertNotification(tx, notification); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *userRepository) Search(query string, limit, offset int) ([]*domain.User, error) {
+	// Escape LIKE wildcards so they match literally
+	pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(query) + "%"
+
+	sqlQuery := `SELECT` + userColumns + `
+        FROM users
+        WHERE username ILIKE $1 OR email ILIKE $1 OR phone_number ILIKE $1
+        ORDER BY created_at DESC
+        LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.DB.Query(sqlQuery, pattern, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+func (r *userRepository) RecordFailedLogin(id int64) (int, error) {
+	var attempts int
+	query := `
+        UPDATE users
+        SET failed_login_attempts = failed_login_attempts + 1, last_failed_login_at = CURRENT_TIMESTAMP
+        WHERE user_id = $1
+        RETURNING failed_login_attempts`
+
+	err := r.db.DB.QueryRow(query, id).Scan(&attempts)
+	if err == sql.ErrNoRows {
+		return 0, domain.ErrUserNotFound
+	}
+	return attempts, err
+}
+
+func (r *userRepository) Lock(id int64, until time.Time, notification *domain.Notification) error {
+	tx, err := r.db.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE users SET locked_until = $1 WHERE user_id = $2`
+
+	if _, err := tx.Exec(query, until, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := insertNotification(tx, notification); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *userRepository) ResetFailedLogins(id int64) error {
+	query := `
+        UPDATE users
+        SET failed_login_attempts = 0, last_failed_login_at = NULL, locked_until = NULL
+        WHERE user_id = $1`
+
+	result, err := r.db.DB.Exec(query, id)
 	if err != nil {
 		return err
 	}
@@ -153,3 +468,31 @@ func (r *userRepository) Delete(id int64) error {
 
 	return nil
 }
+
+func scanUser(row rowScanner) (*domain.User, error) {
+	user := &domain.User{}
+	err := row.Scan(
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.PhoneNumber,
+		&user.PasswordHash,
+		&user.Status,
+		&user.Preferences,
+		&user.Role,
+		&user.KYCTier,
+		&user.EmailVerifiedAt,
+		&user.PhoneVerifiedAt,
+		&user.FailedLoginAttempts,
+		&user.LastFailedLoginAt,
+		&user.LockedUntil,
+		&user.ClosedAt,
+		&user.RetainUntil,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,79 @@
+// internal/repository/verification_code_repository.go
+package repository
+
+import (
+	"GonPay_Backend/internal/domain"
+	"database/sql"
+)
+
+type verificationCodeRepository struct {
+	db *PostgresDB
+}
+
+func NewVerificationCodeRepository(db *PostgresDB) domain.VerificationCodeRepository {
+	return &verificationCodeRepository{db: db}
+}
+
+func (r *verificationCodeRepository) Create(c *domain.VerificationCode) error {
+	query := `
+        INSERT INTO verification_codes (user_id, channel, destination, code_hash, expires_at)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING code_id, created_at`
+
+	return r.db.DB.QueryRow(
+		query,
+		c.UserID,
+		c.Channel,
+		c.Destination,
+		c.CodeHash,
+		c.ExpiresAt,
+	).Scan(&c.ID, &c.CreatedAt)
+}
+
+func (r *verificationCodeRepository) GetLatest(userID int64, channel domain.VerificationChannel) (*domain.VerificationCode, error) {
+	c := &domain.VerificationCode{}
+	query := `
+        SELECT code_id, user_id, channel, destination, code_hash, attempts, expires_at, verified_at, created_at
+        FROM verification_codes
+        WHERE user_id = $1 AND channel = $2
+        ORDER BY created_at DESC
+        LIMIT 1`
+
+	err := r.db.DB.QueryRow(query, userID, channel).Scan(
+		&c.ID,
+		&c.UserID,
+		&c.Channel,
+		&c.Destination,
+		&c.CodeHash,
+		&c.Attempts,
+		&c.ExpiresAt,
+		&c.VerifiedAt,
+		&c.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return c, err
+}
+
+func (r *verificationCodeRepository) ClaimAttempt(id int64, max int) (bool, error) {
+	query := `
+        UPDATE verification_codes
+        SET attempts = attempts + 1
+        WHERE code_id = $1 AND attempts < $2 AND verified_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+        RETURNING code_id`
+
+	err := r.db.DB.QueryRow(query, id, max).Scan(&id)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *verificationCodeRepository) MarkVerified(id int64) error {
+	query := `UPDATE verification_codes SET verified_at = CURRENT_TIMESTAMP WHERE code_id = $1 AND verified_at IS NULL`
+
+	_, err := r.db.DB.Exec(query, id)
+	return err
+}
@@ -0,0 +1,237 @@
+// internal/repository/wallet_adjustment_repository.go
+package repository
+
+import (
+	"GonPay_Backend/internal/domain"
+	"database/sql"
+)
+
+type walletAdjustmentRepository struct {
+	db *PostgresDB
+}
+
+func NewWalletAdjustmentRepository(db *PostgresDB) domain.WalletAdjustmentRepository {
+	return &walletAdjustmentRepository{db: db}
+}
+
+const walletAdjustmentColumns = `
+        wallet_adjustment_id, wallet_id, amount, reason, status, proposed_by,
+        reviewed_by, COALESCE(review_note, ''), reviewed_at, transaction_id, created_at`
+
+func (r *walletAdjustmentRepository) Create(adjustment *domain.WalletAdjustment) error {
+	query := `
+        INSERT INTO wallet_adjustments (wallet_id, amount, reason, status, proposed_by)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING wallet_adjustment_id, created_at`
+
+	err := r.db.DB.QueryRow(
+		query,
+		adjustment.WalletID,
+		adjustment.Amount,
+		adjustment.Reason,
+		adjustment.Status,
+		adjustment.ProposedBy,
+	).Scan(&adjustment.ID, &adjustment.CreatedAt)
+	return translateError(err)
+}
+
+func (r *walletAdjustmentRepository) GetByID(id int64) (*domain.WalletAdjustment, error) {
+	query := `SELECT` + walletAdjustmentColumns + `
+        FROM wallet_adjustments
+        WHERE wallet_adjustment_id = $1`
+
+	adjustment, err := scanWalletAdjustment(r.db.DB.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrAdjustmentNotFound
+	}
+	return adjustment, err
+}
+
+func (r *walletAdjustmentRepository) GetByStatus(status domain.AdjustmentStatus, limit, offset int) ([]*domain.WalletAdjustment, error) {
+	query := `SELECT` + walletAdjustmentColumns + `
+        FROM wallet_adjustments
+        WHERE status = $1
+        ORDER BY created_at ASC
+        LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.DB.Query(query, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var adjustments []*domain.WalletAdjustment
+	for rows.Next() {
+		adjustment, err := scanWalletAdjustment(rows)
+		if err != nil {
+			return nil, err
+		}
+		adjustments = append(adjustments, adjustment)
+	}
+
+	return adjustments, rows.Err()
+}
+
+func (r *walletAdjustmentRepository) Review(adjustment *domain.WalletAdjustment) error {
+	query := `
+        UPDATE wallet_adjustments
+        SET status = $1, review_note = NULLIF($2, ''), reviewed_by = $3, reviewed_at = $4
+        WHERE wallet_adjustment_id = $5 AND status = $6`
+
+	result, err := r.db.DB.Exec(
+		query,
+		adjustment.Status,
+		adjustment.ReviewNote,
+		adjustment.ReviewedBy,
+		adjustment.ReviewedAt,
+		adjustment.ID,
+		domain.AdjustmentStatusPending,
+	)
+	if err != nil {
+		return translateError(err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrAdjustmentReviewed
+	}
+
+	return nil
+}
+
+func (r *walletAdjustmentRepository) Approve(adjustment *domain.WalletAdjustment, transaction *domain.Transaction, notification *domain.Notification) error {
+	tx, err := r.db.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	query := `
+        UPDATE wallet_adjustments
+        SET status = $1, review_note = NULLIF($2, ''), reviewed_by = $3, reviewed_at = $4
+        WHERE wallet_adjustment_id = $5 AND status = $6`
+
+	result, err := tx.Exec(
+		query,
+		domain.AdjustmentStatusApproved,
+		adjustment.ReviewNote,
+		adjustment.ReviewedBy,
+		adjustment.ReviewedAt,
+		adjustment.ID,
+		domain.AdjustmentStatusPending,
+	)
+	if err != nil {
+		tx.Rollback()
+		return translateError(err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if rows == 0 {
+		tx.Rollback()
+		return domain.ErrAdjustmentReviewed
+	}
+
+	// Lock the wallet row as updateBalance does, so the balance check and
+	// the update see the same balance. Adjustments bypass freezes.
+	var balance float64
+	var status domain.WalletStatus
+	query = `
+        SELECT balance, status
+        FROM wallets
+        WHERE wallet_id = $1
+        FOR UPDATE`
+
+	err = tx.QueryRow(query, adjustment.WalletID).Scan(&balance, &status)
+	if err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return domain.ErrWalletNotFound
+		}
+		return err
+	}
+
+	if status == domain.WalletStatusClosed {
+		tx.Rollback()
+		return domain.ErrWalletNotFound
+	}
+
+	if balance+adjustment.Amount < 0 {
+		tx.Rollback()
+		return domain.ErrInsufficientFunds
+	}
+
+	query = `
+        INSERT INTO transactions
+        (source_wallet_id, transaction_type, amount, reference_id, status, description)
+        VALUES ($1, $2, $3, uuid_generate_v4(), $4, $5)
+        RETURNING transaction_id, reference_id, created_at`
+
+	err = tx.QueryRow(
+		query,
+		transaction.SourceWalletID,
+		transaction.Type,
+		transaction.Amount,
+		transaction.Status,
+		transaction.Description,
+	).Scan(&transaction.ID, &transaction.ReferenceID, &transaction.CreatedAt)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query = `UPDATE wallets SET balance = balance + $1 WHERE wallet_id = $2`
+
+	if _, err := tx.Exec(query, adjustment.Amount, adjustment.WalletID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query = `UPDATE wallet_adjustments SET transaction_id = $1 WHERE wallet_adjustment_id = $2`
+
+	if _, err := tx.Exec(query, transaction.ID, adjustment.ID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := insertNotification(tx, notification); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	adjustment.Status = domain.AdjustmentStatusApproved
+	adjustment.TransactionID = &transaction.ID
+	return nil
+}
+
+func scanWalletAdjustment(row rowScanner) (*domain.WalletAdjustment, error) {
+	adjustment := &domain.WalletAdjustment{}
+	err := row.Scan(
+		&adjustment.ID,
+		&adjustment.WalletID,
+		&adjustment.Amount,
+		&adjustment.Reason,
+		&adjustment.Status,
+		&adjustment.ProposedBy,
+		&adjustment.ReviewedBy,
+		&adjustment.ReviewNote,
+		&adjustment.ReviewedAt,
+		&adjustment.TransactionID,
+		&adjustment.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return adjustment, nil
+}
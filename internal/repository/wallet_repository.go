@@ -50,6 +50,28 @@ func (r *walletRepository) GetByID(id int64) (*domain.Wallet, error) {
 	return wallet, err
 }
 
+func (r *walletRepository) GetByWalletNumber(walletNumber string) (*domain.Wallet, error) {
+	wallet := &domain.Wallet{}
+	query := `
+        SELECT wallet_id, user_id, wallet_number, balance, status, created_at
+        FROM wallets 
+        WHERE wallet_number::text = $1`
+
+	err := r.db.DB.QueryRow(query, walletNumber).Scan(
+		&wallet.ID,
+		&wallet.UserID,
+		&wallet.WalletNumber,
+		&wallet.Balance,
+		&wallet.Status,
+		&wallet.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrWalletNotFound
+	}
+	return wallet, err
+}
+
 func (r *walletRepository) GetByUserID(userID int64) ([]*domain.Wallet, error) {
 	query := `
         SELECT wallet_id, user_id, wallet_number, balance, status, created_at
@@ -84,6 +106,27 @@ func (r *walletRepository) GetByUserID(userID int64) ([]*domain.Wallet, error) {
 }
 
 func (r *walletRepository) UpdateBalance(id int64, amount float64) error {
+	return r.updateBalance(id, amount, func(status domain.WalletStatus) error {
+		if amount < 0 {
+			return status.CheckDebit()
+		}
+		return status.CheckCredit()
+	})
+}
+
+func (r *walletRepository) AdjustBalance(id int64, amount float64) error {
+	return r.updateBalance(id, amount, func(status domain.WalletStatus) error {
+		if status == domain.WalletStatusClosed {
+			return domain.ErrWalletNotFound
+		}
+		return nil
+	})
+}
+
+// updateBalance locks the wallet row, lets check refuse the change based on
+// the wallet's status and then applies it. Status changes update the same
+// row, so they wait for the lock too.
+func (r *walletRepository) updateBalance(id int64, amount float64, check func(domain.WalletStatus) error) error {
 	tx, err := r.db.DB.Begin()
 	if err != nil {
 		return err
@@ -91,13 +134,14 @@ func (r *walletRepository) UpdateBalance(id int64, amount float64) error {
 
 	// Get current balance with lock
 	var currentBalance float64
+	var status domain.WalletStatus
 	query := `
-        SELECT balance 
+        SELECT balance, status
         FROM wallets 
-        WHERE wallet_id = $1 AND status = 'ACTIVE'
+        WHERE wallet_id = $1
         FOR UPDATE`
 
-	err = tx.QueryRow(query, id).Scan(&currentBalance)
+	err = tx.QueryRow(query, id).Scan(&currentBalance, &status)
 	if err != nil {
 		tx.Rollback()
 		if err == sql.ErrNoRows {
@@ -106,6 +150,11 @@ func (r *walletRepository) UpdateBalance(id int64, amount float64) error {
 		return err
 	}
 
+	if err := check(status); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// Check if new balance would be negative
 	if currentBalance+amount < 0 {
 		tx.Rollback()
@@ -116,7 +165,7 @@ func (r *walletRepository) UpdateBalance(id int64, amount float64) error {
 	query = `
         UPDATE wallets 
         SET balance = balance + $1
-        WHERE wallet_id = $2 AND status = 'ACTIVE'`
+        WHERE wallet_id = $2`
 
 	result, err := tx.Exec(query, amount, id)
 	if err != nil {
@@ -138,10 +187,43 @@ func (r *walletRepository) UpdateBalance(id int64, amount float64) error {
 	return tx.Commit()
 }
 
+func (r *walletRepository) SetStatus(id int64, status domain.WalletStatus, notification *domain.Notification) error {
+	tx, err := r.db.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE wallets SET status = $1 WHERE wallet_id = $2 AND status <> $3`
+
+	result, err := tx.Exec(query, status, id, domain.WalletStatusClosed)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if rows == 0 {
+		tx.Rollback()
+		return domain.ErrWalletNotFound
+	}
+
+	if err := insertNotification(tx, notification); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func (r *walletRepository) Delete(id int64) error {
 	query := `UPDATE wallets SET status = $1 WHERE wallet_id = $2`
 
-	result, err := r.db.DB.Exec(query, domain.UserStatusInactive, id)
+	result, err := r.db.DB.Exec(query, domain.WalletStatusClosed, id)
 	if err != nil {
 		return err
 	}
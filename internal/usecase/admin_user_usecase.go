@@ -0,0 +1,262 @@
+// internal/usecase/admin_user_usecase.go
+package usecase
+
+import (
+	"GonPay_Backend/internal/domain"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AdminUserUseCase lets staff look up and manage user accounts. Every change
+// is recorded in the audit log under the acting admin's ID.
+type AdminUserUseCase struct {
+	userRepo            domain.UserRepository
+	walletRepo          domain.WalletRepository
+	transactionRepo     domain.TransactionRepository
+	sessionRepo         domain.SessionRepository
+	twoFactorRepo       domain.TwoFactorRepository
+	lockoutUseCase      *LockoutUseCase
+	auditUseCase        *AuditUseCase
+	notificationUseCase *NotificationUseCase
+}
+
+func NewAdminUserUseCase(
+	userRepo domain.UserRepository,
+	walletRepo domain.WalletRepository,
+	transactionRepo domain.TransactionRepository,
+	sessionRepo domain.SessionRepository,
+	twoFactorRepo domain.TwoFactorRepository,
+	lockoutUseCase *LockoutUseCase,
+	auditUseCase *AuditUseCase,
+	notificationUseCase *NotificationUseCase,
+) *AdminUserUseCase {
+	return &AdminUserUseCase{
+		userRepo:            userRepo,
+		walletRepo:          walletRepo,
+		transactionRepo:     transactionRepo,
+		sessionRepo:         sessionRepo,
+		twoFactorRepo:       twoFactorRepo,
+		lockoutUseCase:      lockoutUseCase,
+		auditUseCase:        auditUseCase,
+		notificationUseCase: notificationUseCase,
+	}
+}
+
+// SearchUsers finds users whose username, email or phone number contains
+// query.
+func (u *AdminUserUseCase) SearchUsers(query string, page, limit int) ([]*domain.User, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	offset := (page - 1) * limit
+	return u.userRepo.Search(strings.TrimSpace(query), limit, offset)
+}
+
+func (u *AdminUserUseCase) GetUser(userID int64) (*domain.AdminUserView, error) {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactor, err := u.twoFactorRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := u.sessionRepo.GetActiveByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AdminUserView{
+		User:             user,
+		Locked:           user.Locked(time.Now()),
+		TwoFactorEnabled: twoFactor != nil && twoFactor.Enabled,
+		ActiveSessions:   len(sessions),
+	}, nil
+}
+
+func (u *AdminUserUseCase) GetUserWallets(userID int64) ([]*domain.Wallet, error) {
+	if _, err := u.userRepo.GetByID(userID); err != nil {
+		return nil, err
+	}
+	return u.walletRepo.GetByUserID(userID)
+}
+
+// GetUserTransactions lists the user's most recent transactions.
+func (u *AdminUserUseCase) GetUserTransactions(userID int64, page, limit int) ([]*domain.Transaction, error) {
+	if _, err := u.userRepo.GetByID(userID); err != nil {
+		return nil, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	offset := (page - 1) * limit
+	return u.transactionRepo.GetUserTransactions(userID, limit, offset)
+}
+
+// Suspend deactivates an account and signs it out everywhere. The user
+// cannot sign in, refresh tokens or use access tokens already issued until
+// reactivated.
+func (u *AdminUserUseCase) Suspend(adminID int64, userID int64, reason string, client domain.ClientInfo) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len(reason) > 500 {
+		return fmt.Errorf("%w: a reason of at most 500 characters is required", domain.ErrInvalidOperation)
+	}
+	if adminID == userID {
+		return fmt.Errorf("%w: you cannot suspend your own account", domain.ErrInvalidOperation)
+	}
+
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.Status == domain.UserStatusClosed {
+		return domain.ErrAccountClosed
+	}
+	if user.Status == domain.UserStatusInactive {
+		return fmt.Errorf("%w: account is already suspended", domain.ErrInvalidOperation)
+	}
+
+	notification := u.notificationUseCase.Prepare(userID, domain.NotificationTypeAccount, "account_suspended", reason)
+	if err := u.userRepo.SetStatus(userID, domain.UserStatusInactive, notification); err != nil {
+		return err
+	}
+
+	u.auditUseCase.LogChange(adminID, domain.AuditActionSuspendUser, entityUser, userID,
+		map[string]interface{}{"status": user.Status},
+		map[string]interface{}{"status": domain.UserStatusInactive, "reason": reason},
+		client,
+	)
+
+	return nil
+}
+
+func (u *AdminUserUseCase) Reactivate(adminID int64, userID int64, client domain.ClientInfo) error {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.Status == domain.UserStatusClosed {
+		return domain.ErrAccountClosed
+	}
+	if user.Status == domain.UserStatusActive {
+		return fmt.Errorf("%w: account is already active", domain.ErrInvalidOperation)
+	}
+
+	notification := u.notificationUseCase.Prepare(userID, domain.NotificationTypeAccount, "account_reactivated")
+	if err := u.userRepo.SetStatus(userID, domain.UserStatusActive, notification); err != nil {
+		return err
+	}
+
+	u.auditUseCase.LogChange(adminID, domain.AuditActionReactivateUser, entityUser, userID,
+		map[string]interface{}{"status": user.Status},
+		map[string]interface{}{"status": domain.UserStatusActive},
+		client,
+	)
+
+	return nil
+}
+
+// ForceLogout revokes all of the user's sessions, for example when their
+// device was stolen.
+func (u *AdminUserUseCase) ForceLogout(adminID int64, userID int64, client domain.ClientInfo) error {
+	if _, err := u.userRepo.GetByID(userID); err != nil {
+		return err
+	}
+
+	sessions, err := u.sessionRepo.GetActiveByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	notification := u.notificationUseCase.Prepare(userID, domain.NotificationTypeSecurity, "signed_out_everywhere")
+	if err := u.sessionRepo.RevokeAllByUserID(userID, notification); err != nil {
+		return err
+	}
+
+	u.auditUseCase.LogChange(adminID, domain.AuditActionForceLogout, entityUser, userID,
+		map[string]int{"active_sessions": len(sessions)}, nil, client)
+
+	return nil
+}
+
+// Reset2FA removes the user's 2FA enrollment when they have lost their
+// authenticator and recovery codes. They sign in with their password alone
+// until they set up 2FA again.
+func (u *AdminUserUseCase) Reset2FA(adminID int64, userID int64, client domain.ClientInfo) error {
+	if _, err := u.userRepo.GetByID(userID); err != nil {
+		return err
+	}
+
+	twoFactor, err := u.twoFactorRepo.GetByUserID(userID)
+	if err != nil {
+		return err
+	}
+	if twoFactor == nil {
+		return domain.Err2FANotEnabled
+	}
+
+	notification := u.notificationUseCase.Prepare(userID, domain.NotificationTypeSecurity, "two_factor_reset")
+	if err := u.twoFactorRepo.Delete(userID, notification); err != nil {
+		return err
+	}
+
+	u.auditUseCase.LogChange(adminID, domain.AuditActionReset2FA, entityTwoFactor, userID,
+		map[string]bool{"enabled": twoFactor.Enabled}, map[string]bool{"enabled": false}, client)
+
+	return nil
+}
+
+// UnlockUser lifts a login lockout.
+func (u *AdminUserUseCase) UnlockUser(adminID int64, userID int64, client domain.ClientInfo) error {
+	return u.lockoutUseCase.Unlock(adminID, userID, client)
+}
+
+// AssignRole changes a user's role. Access tokens already issued carry the
+// new permissions within the session check interval, since permissions are
+// looked up from the current role. Admins cannot change their own role.
+func (u *AdminUserUseCase) AssignRole(adminID int64, userID int64, role string, client domain.ClientInfo) (*domain.User, error) {
+	if !domain.ValidRole(role) {
+		return nil, domain.ErrInvalidRole
+	}
+	if adminID == userID {
+		return nil, domain.ErrInvalidOperation
+	}
+
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Status == domain.UserStatusClosed {
+		return nil, domain.ErrAccountClosed
+	}
+	if user.Role == role {
+		return user, nil
+	}
+
+	oldRole := user.Role
+	user.Role = role
+	user.UpdatedAt = time.Now()
+	if err := u.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	// The user_role_audit trigger also records the change, but without the
+	// admin who made it
+	u.auditUseCase.LogChange(adminID, domain.AuditActionAssignRole, entityUser, userID,
+		map[string]string{"role": oldRole}, map[string]string{"role": role}, client)
+
+	return user, nil
+}
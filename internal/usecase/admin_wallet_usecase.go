@@ -0,0 +1,310 @@
+// internal/usecase/admin_wallet_usecase.go
+package usecase
+
+import (
+	"GonPay_Backend/internal/domain"
+	"GonPay_Backend/internal/i18n"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	entityWallet           = "WALLET"
+	entityWalletAdjustment = "WALLET_ADJUSTMENT"
+)
+
+// AdminWalletUseCase lets staff freeze and block wallets and correct their
+// balances. Adjustments follow a four-eyes rule: the admin who proposes one
+// cannot approve it.
+type AdminWalletUseCase struct {
+	walletRepo          domain.WalletRepository
+	adjustmentRepo      domain.WalletAdjustmentRepository
+	auditUseCase        *AuditUseCase
+	notificationUseCase *NotificationUseCase
+}
+
+func NewAdminWalletUseCase(
+	walletRepo domain.WalletRepository,
+	adjustmentRepo domain.WalletAdjustmentRepository,
+	auditUseCase *AuditUseCase,
+	notificationUseCase *NotificationUseCase,
+) *AdminWalletUseCase {
+	return &AdminWalletUseCase{
+		walletRepo:          walletRepo,
+		adjustmentRepo:      adjustmentRepo,
+		auditUseCase:        auditUseCase,
+		notificationUseCase: notificationUseCase,
+	}
+}
+
+func (u *AdminWalletUseCase) GetWallet(walletID int64) (*domain.Wallet, error) {
+	return u.walletRepo.GetByID(walletID)
+}
+
+// Freeze stops money leaving the wallet. Incoming payments are still
+// accepted.
+func (u *AdminWalletUseCase) Freeze(adminID int64, walletID int64, reason string, client domain.ClientInfo) (*domain.Wallet, error) {
+	return u.setStatus(adminID, walletID, domain.WalletStatusFrozen, reason, client, "wallet_frozen")
+}
+
+// Block stops all money movement in and out of the wallet.
+func (u *AdminWalletUseCase) Block(adminID int64, walletID int64, reason string, client domain.ClientInfo) (*domain.Wallet, error) {
+	return u.setStatus(adminID, walletID, domain.WalletStatusBlocked, reason, client, "wallet_blocked")
+}
+
+// Unfreeze returns a frozen or blocked wallet to normal use.
+func (u *AdminWalletUseCase) Unfreeze(adminID int64, walletID int64, reason string, client domain.ClientInfo) (*domain.Wallet, error) {
+	return u.setStatus(adminID, walletID, domain.WalletStatusActive, reason, client, "wallet_restored")
+}
+
+func (u *AdminWalletUseCase) setStatus(
+	adminID int64,
+	walletID int64,
+	status domain.WalletStatus,
+	reason string,
+	client domain.ClientInfo,
+	notification string,
+) (*domain.Wallet, error) {
+	reason, err := requireReason(reason)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := u.walletRepo.GetByID(walletID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet.Status == domain.WalletStatusClosed {
+		return nil, domain.ErrWalletNotFound
+	}
+	if wallet.Status == status {
+		return nil, fmt.Errorf("%w: wallet is already %s", domain.ErrInvalidOperation, strings.ToLower(string(status)))
+	}
+
+	message := u.notificationUseCase.Prepare(wallet.UserID, domain.NotificationTypeAccount, notification, wallet.WalletNumber, reason)
+	if err := u.walletRepo.SetStatus(walletID, status, message); err != nil {
+		return nil, err
+	}
+
+	oldStatus := wallet.Status
+	wallet.Status = status
+
+	u.auditUseCase.LogChange(adminID, domain.AuditActionWalletStatus, entityWallet, walletID,
+		map[string]interface{}{"status": oldStatus},
+		map[string]interface{}{"status": status, "reason": reason},
+		client,
+	)
+
+	return wallet, nil
+}
+
+// ProposeAdjustment records a balance correction for another admin to
+// approve. amount is positive to credit the wallet and negative to debit it.
+func (u *AdminWalletUseCase) ProposeAdjustment(adminID int64, walletID int64, amount float64, reason string, client domain.ClientInfo) (*domain.WalletAdjustment, error) {
+	if amount == 0 || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return nil, domain.ErrInvalidAmount
+	}
+
+	reason, err := requireReason(reason)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := u.walletRepo.GetByID(walletID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet.Status == domain.WalletStatusClosed {
+		return nil, domain.ErrWalletNotFound
+	}
+	if wallet.UserID == adminID {
+		return nil, fmt.Errorf("%w: you cannot adjust your own wallet", domain.ErrInvalidOperation)
+	}
+
+	adjustment := &domain.WalletAdjustment{
+		WalletID:   walletID,
+		Amount:     amount,
+		Reason:     reason,
+		Status:     domain.AdjustmentStatusPending,
+		ProposedBy: adminID,
+	}
+
+	if err := u.adjustmentRepo.Create(adjustment); err != nil {
+		return nil, err
+	}
+
+	u.auditUseCase.LogChange(adminID, domain.AuditActionProposeAdjust, entityWalletAdjustment, adjustment.ID,
+		nil,
+		map[string]interface{}{"wallet_id": walletID, "amount": amount, "reason": reason},
+		client,
+	)
+
+	return adjustment, nil
+}
+
+// GetAdjustments lists adjustments with the given status, oldest first.
+// An empty status lists the ones awaiting approval.
+func (u *AdminWalletUseCase) GetAdjustments(status string, page, limit int) ([]*domain.WalletAdjustment, error) {
+	adjustmentStatus := domain.AdjustmentStatus(strings.ToUpper(status))
+	switch adjustmentStatus {
+	case "":
+		adjustmentStatus = domain.AdjustmentStatusPending
+	case domain.AdjustmentStatusPending, domain.AdjustmentStatusApproved,
+		domain.AdjustmentStatusRejected, domain.AdjustmentStatusFailed:
+	default:
+		return nil, fmt.Errorf("%w: unknown adjustment status %q", domain.ErrInvalidOperation, status)
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	offset := (page - 1) * limit
+	return u.adjustmentRepo.GetByStatus(adjustmentStatus, limit, offset)
+}
+
+// ApproveAdjustment posts a pending adjustment as an ADJUSTMENT_CREDIT or
+// ADJUSTMENT_DEBIT transaction. Adjustments bypass freezes so that staff can
+// correct frozen or blocked wallets, but a debit still cannot take the
+// balance below zero. If the wallet is closed or the balance is too low the
+// adjustment is marked FAILED and must be proposed again.
+func (u *AdminWalletUseCase) ApproveAdjustment(adminID int64, adjustmentID int64, note string, client domain.ClientInfo) (*domain.WalletAdjustment, error) {
+	adjustment, wallet, err := u.prepareReview(adminID, adjustmentID, domain.AdjustmentStatusApproved, strings.TrimSpace(note))
+	if err != nil {
+		return nil, err
+	}
+
+	txType := domain.TransactionTypeAdjustmentCredit
+	if adjustment.Amount < 0 {
+		txType = domain.TransactionTypeAdjustmentDebit
+	}
+
+	tx := &domain.Transaction{
+		SourceWalletID: adjustment.WalletID,
+		Type:           txType,
+		Amount:         math.Abs(adjustment.Amount),
+		Status:         domain.TransactionStatusCompleted,
+		Description:    adjustment.Reason,
+	}
+
+	template := "balance_credited"
+	if adjustment.Amount < 0 {
+		template = "balance_debited"
+	}
+	notification := u.notificationUseCase.Prepare(wallet.UserID, domain.NotificationTypeTransaction, template,
+		i18n.VND(math.Abs(adjustment.Amount)), wallet.WalletNumber, adjustment.Reason,
+	)
+
+	if err := u.adjustmentRepo.Approve(adjustment, tx, notification); err != nil {
+		if errors.Is(err, domain.ErrInsufficientFunds) || errors.Is(err, domain.ErrWalletNotFound) {
+			adjustment.Status = domain.AdjustmentStatusFailed
+			if err := u.adjustmentRepo.Review(adjustment); err != nil {
+				return nil, err
+			}
+			u.logReview(adminID, adjustment, domain.AdjustmentStatusFailed, client)
+		}
+		return nil, err
+	}
+
+	u.logReview(adminID, adjustment, domain.AdjustmentStatusApproved, client)
+
+	return adjustment, nil
+}
+
+// RejectAdjustment discards a pending adjustment. A note explaining why is
+// required.
+func (u *AdminWalletUseCase) RejectAdjustment(adminID int64, adjustmentID int64, note string, client domain.ClientInfo) (*domain.WalletAdjustment, error) {
+	note, err := requireReason(note)
+	if err != nil {
+		return nil, err
+	}
+
+	adjustment, _, err := u.review(adminID, adjustmentID, domain.AdjustmentStatusRejected, note)
+	if err != nil {
+		return nil, err
+	}
+
+	u.logReview(adminID, adjustment, domain.AdjustmentStatusRejected, client)
+
+	return adjustment, nil
+}
+
+// review records the decision on a pending adjustment and returns it with
+// its wallet.
+func (u *AdminWalletUseCase) review(adminID int64, adjustmentID int64, status domain.AdjustmentStatus, note string) (*domain.WalletAdjustment, *domain.Wallet, error) {
+	adjustment, wallet, err := u.prepareReview(adminID, adjustmentID, status, note)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := u.adjustmentRepo.Review(adjustment); err != nil {
+		return nil, nil, err
+	}
+
+	return adjustment, wallet, nil
+}
+
+// prepareReview fills in the decision on a pending adjustment without
+// storing it. Neither the proposer nor the wallet's owner may review it.
+func (u *AdminWalletUseCase) prepareReview(adminID int64, adjustmentID int64, status domain.AdjustmentStatus, note string) (*domain.WalletAdjustment, *domain.Wallet, error) {
+	if len(note) > 500 {
+		return nil, nil, fmt.Errorf("%w: the note must be at most 500 characters", domain.ErrInvalidOperation)
+	}
+
+	adjustment, err := u.adjustmentRepo.GetByID(adjustmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if adjustment.ProposedBy == adminID {
+		return nil, nil, fmt.Errorf("%w: an adjustment must be reviewed by a different admin", domain.ErrInvalidOperation)
+	}
+	if adjustment.Status != domain.AdjustmentStatusPending {
+		return nil, nil, domain.ErrAdjustmentReviewed
+	}
+
+	wallet, err := u.walletRepo.GetByID(adjustment.WalletID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if wallet.UserID == adminID {
+		return nil, nil, fmt.Errorf("%w: you cannot review an adjustment to your own wallet", domain.ErrInvalidOperation)
+	}
+
+	now := time.Now()
+	adjustment.Status = status
+	adjustment.ReviewNote = note
+	adjustment.ReviewedBy = &adminID
+	adjustment.ReviewedAt = &now
+
+	return adjustment, wallet, nil
+}
+
+func (u *AdminWalletUseCase) logReview(adminID int64, adjustment *domain.WalletAdjustment, status domain.AdjustmentStatus, client domain.ClientInfo) {
+	adjustment.Status = status
+	u.auditUseCase.LogChange(adminID, domain.AuditActionReviewAdjust, entityWalletAdjustment, adjustment.ID,
+		map[string]interface{}{"status": domain.AdjustmentStatusPending},
+		map[string]interface{}{
+			"status":         status,
+			"wallet_id":      adjustment.WalletID,
+			"amount":         adjustment.Amount,
+			"note":           adjustment.ReviewNote,
+			"transaction_id": adjustment.TransactionID,
+		},
+		client,
+	)
+}
+
+// requireReason trims reason and checks it is between 1 and 500 characters.
+func requireReason(reason string) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len(reason) > 500 {
+		return "", fmt.Errorf("%w: a reason of at most 500 characters is required", domain.ErrInvalidOperation)
+	}
+	return reason, nil
+}
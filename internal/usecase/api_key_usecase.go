@@ -0,0 +1,216 @@
+// internal/usecase/api_key_usecase.go
+package usecase
+
+import (
+	"GonPay_Backend/internal/domain"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	entityAPIKey = "API_KEY"
+	apiKeyPrefix = "gpk_"
+)
+
+type APIKeyUseCase struct {
+	apiKeyRepo          domain.APIKeyRepository
+	userRepo            domain.UserRepository
+	auditUseCase        *AuditUseCase
+	notificationUseCase *NotificationUseCase
+}
+
+func NewAPIKeyUseCase(
+	apiKeyRepo domain.APIKeyRepository,
+	userRepo domain.UserRepository,
+	auditUseCase *AuditUseCase,
+	notificationUseCase *NotificationUseCase,
+) *APIKeyUseCase {
+	return &APIKeyUseCase{
+		apiKeyRepo:          apiKeyRepo,
+		userRepo:            userRepo,
+		auditUseCase:        auditUseCase,
+		notificationUseCase: notificationUseCase,
+	}
+}
+
+// CreateKey issues a new API key for a merchant account. The full key is only
+// returned here. expiresInDays of 0 creates a key that does not expire.
+func (u *APIKeyUseCase) CreateKey(userID int64, name string, scopes []domain.APIScope, expiresInDays int, client domain.ClientInfo) (*domain.CreatedAPIKey, error) {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != domain.RoleMerchant {
+		return nil, domain.ErrMerchantOnly
+	}
+
+	scopes, err = normalizeScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 100 {
+		return nil, fmt.Errorf("%w: name must be 1 to 100 characters", domain.ErrInvalidOperation)
+	}
+	if expiresInDays < 0 {
+		return nil, fmt.Errorf("%w: expiry must not be negative", domain.ErrInvalidOperation)
+	}
+
+	prefix, err := generateKeyPrefix()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := generateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	key := &domain.APIKey{
+		UserID:     userID,
+		Name:       name,
+		Prefix:     prefix,
+		SecretHash: hashToken(secret),
+		Scopes:     scopes,
+	}
+	if expiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, expiresInDays)
+		key.ExpiresAt = &expiresAt
+	}
+
+	if err := u.apiKeyRepo.Create(key); err != nil {
+		return nil, err
+	}
+
+	u.auditUseCase.LogChange(userID, domain.AuditActionCreateAPIKey, entityAPIKey, key.ID, nil, map[string]interface{}{
+		"name":       key.Name,
+		"prefix":     key.Prefix,
+		"scopes":     key.Scopes,
+		"expires_at": key.ExpiresAt,
+	}, client)
+
+	return &domain.CreatedAPIKey{APIKey: key, Key: prefix + "." + secret}, nil
+}
+
+func (u *APIKeyUseCase) GetKeys(userID int64) ([]*domain.APIKey, error) {
+	return u.apiKeyRepo.GetByUserID(userID)
+}
+
+// RevokeKey revokes one of the merchant's own keys.
+func (u *APIKeyUseCase) RevokeKey(userID int64, keyID int64, client domain.ClientInfo) error {
+	key, err := u.apiKeyRepo.GetByID(keyID)
+	if err != nil {
+		return err
+	}
+	if key.UserID != userID {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	return u.revoke(userID, key, client)
+}
+
+// AdminRevokeKey revokes any key, for example one that has leaked, and tells
+// the merchant.
+func (u *APIKeyUseCase) AdminRevokeKey(adminID int64, keyID int64, client domain.ClientInfo) error {
+	key, err := u.apiKeyRepo.GetByID(keyID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.revoke(adminID, key, client); err != nil {
+		return err
+	}
+
+	u.notificationUseCase.Notify(key.UserID, domain.NotificationTypeSecurity, "api_key_revoked", key.Name, key.Prefix)
+
+	return nil
+}
+
+// Authenticate checks an API key sent by a merchant server and returns the
+// principal it acts as. Each successful use is counted on the key.
+func (u *APIKeyUseCase) Authenticate(rawKey string, client domain.ClientInfo) (*domain.Principal, error) {
+	prefix, secret, ok := strings.Cut(rawKey, ".")
+	if !ok || !strings.HasPrefix(prefix, apiKeyPrefix) || secret == "" {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	key, err := u.apiKeyRepo.GetByPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil || subtle.ConstantTimeCompare([]byte(hashToken(secret)), []byte(key.SecretHash)) != 1 {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if !key.Active(now) {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	// A key stops working when its account is deactivated or is no longer a
+	// merchant
+	user, err := u.userRepo.GetByID(key.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Status != domain.UserStatusActive || user.Role != domain.RoleMerchant {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	if err := u.apiKeyRepo.RecordUsage(key.ID, clientIP(client), now); err != nil {
+		return nil, err
+	}
+
+	return &domain.Principal{
+		UserID:     user.ID,
+		Role:       user.Role,
+		AuthMethod: domain.AuthMethodAPIKey,
+		APIKeyID:   key.ID,
+		Scopes:     key.Scopes,
+	}, nil
+}
+
+func (u *APIKeyUseCase) revoke(actorID int64, key *domain.APIKey, client domain.ClientInfo) error {
+	if err := u.apiKeyRepo.Revoke(key.ID, time.Now()); err != nil {
+		return err
+	}
+
+	u.auditUseCase.LogChange(actorID, domain.AuditActionRevokeAPIKey, entityAPIKey, key.ID, map[string]interface{}{
+		"name":   key.Name,
+		"prefix": key.Prefix,
+	}, nil, client)
+
+	return nil
+}
+
+// normalizeScopes rejects unknown scopes and drops duplicates.
+func normalizeScopes(scopes []domain.APIScope) ([]domain.APIScope, error) {
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", domain.ErrInvalidScope)
+	}
+
+	seen := make(map[domain.APIScope]bool, len(scopes))
+	var out []domain.APIScope
+	for _, s := range scopes {
+		if !domain.ValidAPIScope(s) {
+			return nil, fmt.Errorf("%w: %s", domain.ErrInvalidScope, s)
+		}
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func generateKeyPrefix() (string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + hex.EncodeToString(b), nil
+}
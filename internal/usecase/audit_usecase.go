@@ -4,6 +4,7 @@ package usecase
 
 import (
 	"GonPay_Backend/internal/domain"
+	"encoding/json"
 	"net"
 	"time"
 )
@@ -47,6 +48,35 @@ func (u *AuditUseCase) LogAction(
 	return u.auditRepo.Create(log)
 }
 
+// LogChange records an action with the old and new state of the entity
+// marshalled to JSON. Either value may be nil.
+func (u *AuditUseCase) LogChange(
+	userID int64,
+	action domain.AuditAction,
+	entityType string,
+	entityID int64,
+	oldValue, newValue interface{},
+	client domain.ClientInfo,
+) error {
+	oldJSON, err := marshalAuditValue(oldValue)
+	if err != nil {
+		return err
+	}
+	newJSON, err := marshalAuditValue(newValue)
+	if err != nil {
+		return err
+	}
+
+	return u.LogAction(userID, action, entityType, entityID, oldJSON, newJSON, client.IPAddress, client.UserAgent)
+}
+
+func marshalAuditValue(value interface{}) ([]byte, error) {
+	if value == nil {
+		return nil, nil
+	}
+	return json.Marshal(value)
+}
+
 func (u *AuditUseCase) GetUserAuditLogs(userID int64, page, limit int) ([]*domain.AuditLog, error) {
 	if page < 1 {
 		page = 1
@@ -3,16 +3,46 @@ package usecase
 
 import (
 	"GonPay_Backend/internal/domain"
+	"GonPay_Backend/internal/i18n"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 type BeneficiaryUseCase struct {
-	beneficiaryRepo domain.BeneficiaryRepository
+	beneficiaryRepo     domain.BeneficiaryRepository
+	userRepo            domain.UserRepository
+	walletRepo          domain.WalletRepository
+	bankLookup          domain.BankAccountLookup
+	pinUseCase          *TransactionPINUseCase
+	notificationUseCase *NotificationUseCase
+	coolingOffPeriod    time.Duration
+	coolingOffMaxAmount float64
 }
 
-func NewBeneficiaryUseCase(beneficiaryRepo domain.BeneficiaryRepository) *BeneficiaryUseCase {
+func NewBeneficiaryUseCase(
+	beneficiaryRepo domain.BeneficiaryRepository,
+	userRepo domain.UserRepository,
+	walletRepo domain.WalletRepository,
+	bankLookup domain.BankAccountLookup,
+	pinUseCase *TransactionPINUseCase,
+	notificationUseCase *NotificationUseCase,
+	coolingOffHours int64,
+	coolingOffMaxAmount float64,
+) *BeneficiaryUseCase {
 	return &BeneficiaryUseCase{
-		beneficiaryRepo: beneficiaryRepo,
+		beneficiaryRepo:     beneficiaryRepo,
+		userRepo:            userRepo,
+		walletRepo:          walletRepo,
+		bankLookup:          bankLookup,
+		pinUseCase:          pinUseCase,
+		notificationUseCase: notificationUseCase,
+		coolingOffPeriod:    time.Hour * time.Duration(coolingOffHours),
+		coolingOffMaxAmount: coolingOffMaxAmount,
 	}
 }
 
@@ -23,7 +53,7 @@ func (u *BeneficiaryUseCase) CreateBeneficiary(userID int64, name string, accoun
 		return nil, err
 	}
 	if existing != nil {
-		return nil, errors.New("beneficiary with this account already exists")
+		return nil, domain.NewAppError(domain.CodeAlreadyExists, "beneficiary with this account already exists")
 	}
 
 	beneficiary := &domain.Beneficiary{
@@ -32,12 +62,27 @@ func (u *BeneficiaryUseCase) CreateBeneficiary(userID int64, name string, accoun
 		AccountIdentifier: accountIdentifier,
 		AccountType:       accountType,
 		BankName:          bankName,
+		CoolingOffUntil:   u.coolingOffUntil(),
+	}
+
+	if err := u.verifyName(beneficiary); err != nil {
+		return nil, err
 	}
 
 	if err := u.beneficiaryRepo.Create(beneficiary); err != nil {
 		return nil, err
 	}
 
+	if beneficiary.NameMatchStatus == domain.NameMatchMismatch {
+		u.notifyNameMismatch(beneficiary)
+	}
+
+	if beneficiary.CoolingOffUntil != nil {
+		u.notificationUseCase.Notify(userID, domain.NotificationTypeSecurity, "beneficiary_added",
+			name, *beneficiary.CoolingOffUntil, i18n.VND(u.coolingOffMaxAmount),
+		)
+	}
+
 	return beneficiary, nil
 }
 
@@ -60,19 +105,39 @@ func (u *BeneficiaryUseCase) UpdateBeneficiary(id int64, userID int64, name stri
 			return nil, err
 		}
 		if existing != nil && existing.ID != id {
-			return nil, errors.New("beneficiary with this account already exists")
+			return nil, domain.NewAppError(domain.CodeAlreadyExists, "beneficiary with this account already exists")
 		}
 	}
 
+	// Pointing an existing beneficiary at a different account restarts the cooling-off period
+	accountChanged := beneficiary.AccountIdentifier != accountIdentifier ||
+		beneficiary.AccountType != accountType ||
+		beneficiary.BankName != bankName
+	if accountChanged {
+		beneficiary.CoolingOffUntil = u.coolingOffUntil()
+	}
+
+	nameChanged := beneficiary.BeneficiaryName != name
+
 	beneficiary.BeneficiaryName = name
 	beneficiary.AccountIdentifier = accountIdentifier
 	beneficiary.AccountType = accountType
 	beneficiary.BankName = bankName
 
+	if accountChanged || nameChanged {
+		if err := u.verifyName(beneficiary); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := u.beneficiaryRepo.Update(beneficiary); err != nil {
 		return nil, err
 	}
 
+	if (accountChanged || nameChanged) && beneficiary.NameMatchStatus == domain.NameMatchMismatch {
+		u.notifyNameMismatch(beneficiary)
+	}
+
 	return beneficiary, nil
 }
 
@@ -107,3 +172,126 @@ func (u *BeneficiaryUseCase) GetBeneficiary(id int64, userID int64) (*domain.Ben
 func (u *BeneficiaryUseCase) GetUserBeneficiaries(userID int64) ([]*domain.Beneficiary, error) {
 	return u.beneficiaryRepo.GetByUserID(userID)
 }
+
+// LiftCoolingOff removes the transfer cap of a beneficiary in its cooling-off
+// period once the user has confirmed it with their transaction PIN or a TOTP
+// code. Wrong credentials count towards the step-up lock.
+func (u *BeneficiaryUseCase) LiftCoolingOff(id int64, userID int64, credentials domain.StepUpCredentials) (*domain.Beneficiary, error) {
+	beneficiary, err := u.GetBeneficiary(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.pinUseCase.VerifyStepUp(userID, credentials); err != nil {
+		return nil, err
+	}
+
+	if !beneficiary.InCoolingOff(time.Now()) {
+		return beneficiary, nil
+	}
+
+	if err := u.beneficiaryRepo.LiftCoolingOff(id, userID); err != nil {
+		return nil, err
+	}
+	beneficiary.CoolingOffUntil = nil
+
+	u.notificationUseCase.Notify(userID, domain.NotificationTypeSecurity, "beneficiary_unrestricted", beneficiary.BeneficiaryName)
+
+	return beneficiary, nil
+}
+
+// CheckTransfer returns an error wrapping domain.ErrLimitExceeded if the
+// destination account is one of the user's beneficiaries that is still in its
+// cooling-off period and the amount is above the cap.
+func (u *BeneficiaryUseCase) CheckTransfer(userID int64, accountIdentifier string, accountType domain.AccountType, amount float64) error {
+	beneficiary, err := u.beneficiaryRepo.GetByUserAndAccount(userID, accountIdentifier, accountType)
+	if err != nil || beneficiary == nil {
+		return err
+	}
+
+	if beneficiary.InCoolingOff(time.Now()) && amount > u.coolingOffMaxAmount {
+		return fmt.Errorf(
+			"%w: %s was added recently, transfers are limited to %.2f until %s",
+			domain.ErrLimitExceeded, beneficiary.BeneficiaryName, u.coolingOffMaxAmount, beneficiary.CoolingOffUntil.Format(time.RFC3339),
+		)
+	}
+
+	return nil
+}
+
+// verifyName looks up the real holder name of the beneficiary's account and
+// compares it with the name the user entered. Internal wallets are resolved
+// directly, bank accounts through the bank lookup provider. An account that
+// does not exist is rejected; a mismatch is only flagged so the user can
+// decide. If the provider is unavailable the beneficiary stays UNVERIFIED.
+func (u *BeneficiaryUseCase) verifyName(b *domain.Beneficiary) error {
+	b.VerifiedName = ""
+	b.NameMatchStatus = domain.NameMatchUnverified
+
+	var holderName string
+	switch b.AccountType {
+	case domain.AccountTypeWallet:
+		wallet, err := u.walletRepo.GetByWalletNumber(b.AccountIdentifier)
+		if err == domain.ErrWalletNotFound {
+			return domain.ErrAccountNotFound
+		}
+		if err != nil {
+			return err
+		}
+		holder, err := u.userRepo.GetByID(wallet.UserID)
+		if err != nil {
+			return err
+		}
+		holderName = holder.Username
+	case domain.AccountTypeBankAccount:
+		if b.BankName == "" {
+			return domain.NewAppError(domain.CodeValidationFailed, "bank name is required for bank account beneficiaries")
+		}
+		name, err := u.bankLookup.LookupAccountName(b.BankName, b.AccountIdentifier)
+		if errors.Is(err, domain.ErrAccountNotFound) {
+			return err
+		}
+		if err != nil {
+			return nil
+		}
+		holderName = name
+	default:
+		return domain.ErrInvalidOperation
+	}
+
+	b.VerifiedName = holderName
+	if normalizeName(holderName) == normalizeName(b.BeneficiaryName) {
+		b.NameMatchStatus = domain.NameMatchMatched
+	} else {
+		b.NameMatchStatus = domain.NameMatchMismatch
+	}
+	return nil
+}
+
+func (u *BeneficiaryUseCase) notifyNameMismatch(b *domain.Beneficiary) {
+	u.notificationUseCase.Notify(b.UserID, domain.NotificationTypeSecurity, "beneficiary_name_mismatch", b.AccountIdentifier, b.VerifiedName, b.BeneficiaryName)
+}
+
+// normalizeName makes names comparable regardless of case, Vietnamese
+// diacritics and spacing, e.g. "Nguyễn Văn Bình" and "NGUYEN VAN BINH".
+func normalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(name) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case r == 'đ' || r == 'Đ':
+			r = 'D'
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+func (u *BeneficiaryUseCase) coolingOffUntil() *time.Time {
+	if u.coolingOffPeriod <= 0 {
+		return nil
+	}
+	until := time.Now().Add(u.coolingOffPeriod)
+	return &until
+}
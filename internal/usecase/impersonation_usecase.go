@@ -0,0 +1,161 @@
+// internal/usecase/impersonation_usecase.go
+package usecase
+
+import (
+	"GonPay_Backend/internal/auth"
+	"GonPay_Backend/internal/domain"
+	"fmt"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// ImpersonationUseCase lets support staff see the app exactly as a user sees
+// it. The admin gets a short-lived access token for the user, bound to a
+// session of its own, that only works for read-only requests and carries no
+// staff permissions. Every request made with it is audited under the admin.
+type ImpersonationUseCase struct {
+	sessionRepo         domain.SessionRepository
+	userRepo            domain.UserRepository
+	auditUseCase        *AuditUseCase
+	notificationUseCase *NotificationUseCase
+	tokens              *auth.TokenManager
+	ttl                 time.Duration
+}
+
+func NewImpersonationUseCase(
+	sessionRepo domain.SessionRepository,
+	userRepo domain.UserRepository,
+	auditUseCase *AuditUseCase,
+	notificationUseCase *NotificationUseCase,
+	tokens *auth.TokenManager,
+	ttlMinutes int64,
+) *ImpersonationUseCase {
+	return &ImpersonationUseCase{
+		sessionRepo:         sessionRepo,
+		userRepo:            userRepo,
+		auditUseCase:        auditUseCase,
+		notificationUseCase: notificationUseCase,
+		tokens:              tokens,
+		ttl:                 time.Minute * time.Duration(ttlMinutes),
+	}
+}
+
+// Start opens an impersonation session and returns its access token. There
+// is no refresh token; the admin starts a new session when it expires.
+// Staff accounts cannot be impersonated.
+func (u *ImpersonationUseCase) Start(adminID int64, userID int64, reason string, client domain.ClientInfo) (*AuthResponse, error) {
+	reason, err := requireReason(reason)
+	if err != nil {
+		return nil, err
+	}
+	if adminID == userID {
+		return nil, fmt.Errorf("%w: you cannot impersonate yourself", domain.ErrInvalidOperation)
+	}
+
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Status == domain.UserStatusClosed {
+		return nil, domain.ErrAccountClosed
+	}
+	if len(domain.RolePermissions[user.Role]) > 0 {
+		return nil, fmt.Errorf("%w: staff accounts cannot be impersonated", domain.ErrInvalidOperation)
+	}
+
+	session := &domain.Session{
+		UserID:         userID,
+		UserAgent:      client.UserAgent,
+		IPAddress:      clientIP(client),
+		ImpersonatedBy: &adminID,
+		ExpiresAt:      time.Now().Add(u.ttl),
+	}
+
+	if err := u.sessionRepo.Create(session); err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{
+		"user_id":         user.ID,
+		"email":           user.Email,
+		"role":            user.Role,
+		"permissions":     []domain.Permission{},
+		"sid":             session.ID,
+		"impersonator_id": adminID,
+		"iat":             time.Now().Unix(),
+		"exp":             session.ExpiresAt.Unix(),
+	}
+
+	token, err := u.tokens.Sign(claims)
+	if err != nil {
+		u.sessionRepo.Revoke(session.ID)
+		return nil, err
+	}
+
+	u.auditUseCase.LogChange(adminID, domain.AuditActionImpersonate, entitySession, session.ID, nil,
+		map[string]interface{}{"user_id": userID, "reason": reason, "expires_at": session.ExpiresAt},
+		client,
+	)
+
+	u.notificationUseCase.Notify(userID, domain.NotificationTypeSecurity, "impersonation_started", reason, session.ExpiresAt)
+
+	return &AuthResponse{
+		User:      user,
+		Token:     token,
+		ExpiresIn: int64(time.Until(session.ExpiresAt).Seconds()),
+		SessionID: session.ID,
+	}, nil
+}
+
+// End revokes an impersonation session before it expires. Its token stops
+// working on the next request.
+func (u *ImpersonationUseCase) End(adminID int64, sessionID int64, client domain.ClientInfo) error {
+	session, err := u.sessionRepo.GetByID(sessionID)
+	if err != nil {
+		return err
+	}
+	if session.ImpersonatedBy == nil {
+		return domain.ErrSessionNotFound
+	}
+	if session.RevokedAt != nil {
+		return nil
+	}
+
+	if err := u.sessionRepo.Revoke(sessionID); err != nil {
+		return err
+	}
+
+	u.auditUseCase.LogChange(adminID, domain.AuditActionEndImpersonation, entitySession, sessionID,
+		map[string]interface{}{"user_id": session.UserID, "impersonated_by": *session.ImpersonatedBy}, nil, client)
+
+	return nil
+}
+
+// Authorize checks on every request that the impersonation session behind
+// the principal is still active, so revoking it, by the admin or by the user
+// from their session list, takes effect at once.
+func (u *ImpersonationUseCase) Authorize(principal *domain.Principal) error {
+	session, err := u.sessionRepo.GetByID(principal.SessionID)
+	if err != nil {
+		return err
+	}
+
+	if session.ImpersonatedBy == nil ||
+		*session.ImpersonatedBy != principal.ImpersonatorID ||
+		session.UserID != principal.UserID ||
+		!session.Active(time.Now()) {
+		return domain.ErrInvalidToken
+	}
+
+	return nil
+}
+
+// LogRequest records a request made with an impersonation token, including
+// ones refused because they would change something.
+func (u *ImpersonationUseCase) LogRequest(principal *domain.Principal, method, path string, status int, client domain.ClientInfo) error {
+	return u.auditUseCase.LogChange(principal.ImpersonatorID, domain.AuditActionImpersonatedCall, entitySession, principal.SessionID, nil,
+		map[string]interface{}{"user_id": principal.UserID, "method": method, "path": path, "status": status},
+		client,
+	)
+}
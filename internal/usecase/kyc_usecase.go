@@ -0,0 +1,336 @@
+// internal/usecase/kyc_usecase.go
+package usecase
+
+import (
+	"GonPay_Backend/internal/domain"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const entityKYCSubmission = "KYC_SUBMISSION"
+
+// KYCPolicy limits uploaded documents and sets the most a user may hold at
+// each KYC tier.
+type KYCPolicy struct {
+	MaxDocumentSize int64
+	// TierMaxBalances[t] is the most a tier t user may hold across all
+	// wallets. Zero means no cap; tiers past the end use the last entry.
+	TierMaxBalances []float64
+}
+
+// kycContentTypes are the accepted document formats, detected from the file
+// content rather than trusted from the upload.
+var kycContentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"application/pdf": true,
+}
+
+type KYCUseCase struct {
+	kycRepo             domain.KYCRepository
+	userRepo            domain.UserRepository
+	walletRepo          domain.WalletRepository
+	blobStore           domain.BlobStore
+	auditUseCase        *AuditUseCase
+	notificationUseCase *NotificationUseCase
+	policy              KYCPolicy
+}
+
+func NewKYCUseCase(
+	kycRepo domain.KYCRepository,
+	userRepo domain.UserRepository,
+	walletRepo domain.WalletRepository,
+	blobStore domain.BlobStore,
+	auditUseCase *AuditUseCase,
+	notificationUseCase *NotificationUseCase,
+	policy KYCPolicy,
+) *KYCUseCase {
+	return &KYCUseCase{
+		kycRepo:             kycRepo,
+		userRepo:            userRepo,
+		walletRepo:          walletRepo,
+		blobStore:           blobStore,
+		auditUseCase:        auditUseCase,
+		notificationUseCase: notificationUseCase,
+		policy:              policy,
+	}
+}
+
+// GetState returns the user's tier and the status of their latest
+// submission, NONE if they never submitted one.
+func (u *KYCUseCase) GetState(userID int64) (*domain.KYCState, error) {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := u.kycRepo.GetLatest(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &domain.KYCState{
+		Tier:       user.KYCTier,
+		MaxBalance: u.MaxBalance(user.KYCTier),
+		Status:     domain.KYCStatusNone,
+		Submission: latest,
+	}
+	if latest != nil {
+		state.Status = latest.Status
+		state.RejectionReason = latest.RejectionReason
+	}
+	return state, nil
+}
+
+// Submit stores the documents and queues a request to verify the user up to
+// tier. Only one submission can be under review at a time.
+func (u *KYCUseCase) Submit(userID int64, tier int, uploads []domain.KYCUpload, client domain.ClientInfo) (*domain.KYCSubmission, error) {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tier < 1 || tier > domain.MaxKYCTier {
+		return nil, fmt.Errorf("%w: tier must be between 1 and %d", domain.ErrInvalidKYCTier, domain.MaxKYCTier)
+	}
+	if tier <= user.KYCTier {
+		return nil, fmt.Errorf("%w: already verified to tier %d", domain.ErrInvalidKYCTier, user.KYCTier)
+	}
+
+	latest, err := u.kycRepo.GetLatest(userID)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && latest.Status == domain.KYCStatusPending {
+		return nil, domain.ErrKYCPending
+	}
+
+	docs, err := u.checkUploads(tier, uploads)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, doc := range docs {
+		key, err := generateOpaqueToken()
+		if err != nil {
+			u.deleteBlobs(docs[:i])
+			return nil, err
+		}
+		doc.StorageKey = fmt.Sprintf("kyc/%d/%s", userID, key)
+
+		if err := u.blobStore.Put(doc.StorageKey, uploads[i].Content); err != nil {
+			u.deleteBlobs(docs[:i])
+			return nil, err
+		}
+	}
+
+	submission := &domain.KYCSubmission{
+		UserID:        userID,
+		RequestedTier: tier,
+		Status:        domain.KYCStatusPending,
+		Documents:     docs,
+	}
+
+	if err := u.kycRepo.CreateSubmission(submission); err != nil {
+		u.deleteBlobs(docs)
+		return nil, err
+	}
+
+	u.auditUseCase.LogChange(userID, domain.AuditActionSubmitKYC, entityKYCSubmission, submission.ID, nil, map[string]interface{}{
+		"requested_tier": tier,
+		"documents":      len(docs),
+	}, client)
+
+	return submission, nil
+}
+
+// GetQueue lists submissions with the given status, oldest first.
+func (u *KYCUseCase) GetQueue(status domain.KYCStatus, page, limit int) ([]*domain.KYCSubmission, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	offset := (page - 1) * limit
+	return u.kycRepo.GetByStatus(status, limit, offset)
+}
+
+func (u *KYCUseCase) GetSubmission(id int64) (*domain.KYCSubmission, error) {
+	return u.kycRepo.GetSubmission(id)
+}
+
+// OpenDocument returns a document and its content for review. The caller
+// must close the reader.
+func (u *KYCUseCase) OpenDocument(submissionID, documentID int64) (*domain.KYCDocument, io.ReadCloser, error) {
+	doc, err := u.kycRepo.GetDocument(submissionID, documentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := u.blobStore.Get(doc.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return doc, content, nil
+}
+
+// Approve verifies the user up to the requested tier. Staff cannot review
+// their own submission.
+func (u *KYCUseCase) Approve(reviewerID int64, submissionID int64, client domain.ClientInfo) (*domain.KYCSubmission, error) {
+	return u.review(reviewerID, submissionID, domain.KYCStatusVerified, "", client)
+}
+
+// Reject turns down a submission. The reason is shown to the user, who can
+// then submit new documents.
+func (u *KYCUseCase) Reject(reviewerID int64, submissionID int64, reason string, client domain.ClientInfo) (*domain.KYCSubmission, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len(reason) > 500 {
+		return nil, fmt.Errorf("%w: a rejection reason of at most 500 characters is required", domain.ErrInvalidOperation)
+	}
+
+	return u.review(reviewerID, submissionID, domain.KYCStatusRejected, reason, client)
+}
+
+// MaxDocumentSize is the largest document file accepted, in bytes.
+func (u *KYCUseCase) MaxDocumentSize() int64 {
+	return u.policy.MaxDocumentSize
+}
+
+// MaxBalance returns the most a user at tier may hold, or 0 for no cap.
+func (u *KYCUseCase) MaxBalance(tier int) float64 {
+	caps := u.policy.TierMaxBalances
+	if len(caps) == 0 {
+		return 0
+	}
+	if tier >= len(caps) {
+		tier = len(caps) - 1
+	}
+	if tier < 0 {
+		tier = 0
+	}
+	return caps[tier]
+}
+
+// CheckBalanceCap is called before money is credited to one of the user's
+// wallets and fails if their total balance would exceed their tier's cap.
+func (u *KYCUseCase) CheckBalanceCap(userID int64, amount float64) error {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	maxBalance := u.MaxBalance(user.KYCTier)
+	if maxBalance <= 0 {
+		return nil
+	}
+
+	wallets, err := u.walletRepo.GetByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	var total float64
+	for _, wallet := range wallets {
+		total += wallet.Balance
+	}
+
+	if total+amount > maxBalance {
+		return fmt.Errorf("%w: tier %d accounts may hold at most %.2f", domain.ErrBalanceCapExceeded, user.KYCTier, maxBalance)
+	}
+	return nil
+}
+
+func (u *KYCUseCase) review(reviewerID int64, submissionID int64, status domain.KYCStatus, reason string, client domain.ClientInfo) (*domain.KYCSubmission, error) {
+	submission, err := u.kycRepo.GetSubmission(submissionID)
+	if err != nil {
+		return nil, err
+	}
+	if submission.UserID == reviewerID {
+		return nil, domain.ErrInvalidOperation
+	}
+	if submission.Status != domain.KYCStatusPending {
+		return nil, domain.ErrKYCReviewed
+	}
+
+	now := time.Now()
+	submission.Status = status
+	submission.RejectionReason = reason
+	submission.ReviewedBy = &reviewerID
+	submission.ReviewedAt = &now
+
+	var notification *domain.Notification
+	if status == domain.KYCStatusVerified {
+		notification = u.notificationUseCase.Prepare(submission.UserID, domain.NotificationTypeAccount, "kyc_approved", submission.RequestedTier)
+	} else {
+		notification = u.notificationUseCase.Prepare(submission.UserID, domain.NotificationTypeAccount, "kyc_rejected", reason)
+	}
+
+	if err := u.kycRepo.Review(submission, notification); err != nil {
+		return nil, err
+	}
+
+	u.auditUseCase.LogChange(reviewerID, domain.AuditActionReviewKYC, entityKYCSubmission, submission.ID,
+		map[string]interface{}{"status": domain.KYCStatusPending},
+		map[string]interface{}{"status": status, "requested_tier": submission.RequestedTier, "reason": reason},
+		client,
+	)
+
+	return submission, nil
+}
+
+// checkUploads makes sure the uploads are exactly the documents the tier
+// needs, each within the size limit and in an accepted format.
+func (u *KYCUseCase) checkUploads(tier int, uploads []domain.KYCUpload) ([]*domain.KYCDocument, error) {
+	required := domain.KYCTierDocuments[tier]
+	if len(uploads) != len(required) {
+		return nil, fmt.Errorf("%w: tier %d needs %v", domain.ErrInvalidKYCDocument, tier, required)
+	}
+
+	seen := make(map[domain.KYCDocumentType]bool, len(uploads))
+	docs := make([]*domain.KYCDocument, 0, len(uploads))
+	for _, upload := range uploads {
+		if seen[upload.Type] || !containsDocumentType(required, upload.Type) {
+			return nil, fmt.Errorf("%w: tier %d needs %v", domain.ErrInvalidKYCDocument, tier, required)
+		}
+		seen[upload.Type] = true
+
+		size := int64(len(upload.Content))
+		if size == 0 || (u.policy.MaxDocumentSize > 0 && size > u.policy.MaxDocumentSize) {
+			return nil, fmt.Errorf("%w: %s must be between 1 and %d bytes", domain.ErrInvalidKYCDocument, upload.Type, u.policy.MaxDocumentSize)
+		}
+
+		contentType := http.DetectContentType(upload.Content)
+		if !kycContentTypes[contentType] || (upload.Type == domain.KYCDocumentSelfie && contentType == "application/pdf") {
+			return nil, fmt.Errorf("%w: %s has unsupported format %s", domain.ErrInvalidKYCDocument, upload.Type, contentType)
+		}
+
+		docs = append(docs, &domain.KYCDocument{
+			Type:        upload.Type,
+			ContentType: contentType,
+			Size:        size,
+		})
+	}
+
+	return docs, nil
+}
+
+func (u *KYCUseCase) deleteBlobs(docs []*domain.KYCDocument) {
+	for _, doc := range docs {
+		u.blobStore.Delete(doc.StorageKey)
+	}
+}
+
+func containsDocumentType(types []domain.KYCDocumentType, t domain.KYCDocumentType) bool {
+	for _, candidate := range types {
+		if candidate == t {
+			return true
+		}
+	}
+	return false
+}
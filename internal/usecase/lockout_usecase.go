@@ -0,0 +1,218 @@
+// internal/usecase/lockout_usecase.go
+package usecase
+
+import (
+	"GonPay_Backend/internal/domain"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const entityUser = "USER"
+
+// LockoutPolicy configures how failed logins are throttled. A zero
+// MaxFailedAttempts or IPMaxFailedAttempts disables that check.
+type LockoutPolicy struct {
+	// After DelayAfterAttempts failures each further try must wait BaseDelay,
+	// doubling with every failure.
+	DelayAfterAttempts int
+	BaseDelay          time.Duration
+	// After MaxFailedAttempts failures the account is locked for LockoutDuration.
+	MaxFailedAttempts int
+	LockoutDuration   time.Duration
+	// An IP address with IPMaxFailedAttempts failures within IPWindow is
+	// refused until the window has passed.
+	IPMaxFailedAttempts int
+	IPWindow            time.Duration
+}
+
+type LockoutUseCase struct {
+	userRepo            domain.UserRepository
+	loginAttemptRepo    domain.LoginAttemptRepository
+	auditUseCase        *AuditUseCase
+	notificationUseCase *NotificationUseCase
+	policy              LockoutPolicy
+}
+
+func NewLockoutUseCase(
+	userRepo domain.UserRepository,
+	loginAttemptRepo domain.LoginAttemptRepository,
+	auditUseCase *AuditUseCase,
+	notificationUseCase *NotificationUseCase,
+	policy LockoutPolicy,
+) *LockoutUseCase {
+	return &LockoutUseCase{
+		userRepo:            userRepo,
+		loginAttemptRepo:    loginAttemptRepo,
+		auditUseCase:        auditUseCase,
+		notificationUseCase: notificationUseCase,
+		policy:              policy,
+	}
+}
+
+// CheckIP refuses logins from an address with too many recent failures.
+func (u *LockoutUseCase) CheckIP(client domain.ClientInfo) error {
+	ip := clientIP(client)
+	if u.policy.IPMaxFailedAttempts <= 0 || ip == "" {
+		return nil
+	}
+
+	failures, err := u.loginAttemptRepo.CountFailuresByIP(ip, time.Now().Add(-u.policy.IPWindow))
+	if err != nil {
+		return err
+	}
+
+	if failures >= u.policy.IPMaxFailedAttempts {
+		return &domain.LoginBlockedError{Err: domain.ErrTooManyAttempts, RetryAfter: u.policy.IPWindow}
+	}
+
+	return nil
+}
+
+// CheckUser refuses logins to a locked account or one still inside its
+// progressive delay.
+func (u *LockoutUseCase) CheckUser(user *domain.User) error {
+	now := time.Now()
+
+	if user.Locked(now) {
+		return &domain.LoginBlockedError{Err: domain.ErrAccountLocked, RetryAfter: user.LockedUntil.Sub(now)}
+	}
+
+	if u.policy.DelayAfterAttempts <= 0 || user.FailedLoginAttempts < u.policy.DelayAfterAttempts || user.LastFailedLoginAt == nil {
+		return nil
+	}
+
+	if wait := user.LastFailedLoginAt.Add(u.delay(user.FailedLoginAttempts)).Sub(now); wait > 0 {
+		return &domain.LoginBlockedError{Err: domain.ErrTooManyAttempts, RetryAfter: wait}
+	}
+
+	return nil
+}
+
+// RecordFailure logs a failed login. user is nil when the email is unknown.
+// The account is locked once it reaches the failure limit.
+func (u *LockoutUseCase) RecordFailure(user *domain.User, email string, client domain.ClientInfo, reason string) error {
+	attempt := &domain.LoginAttempt{
+		Email:     email,
+		IPAddress: clientIP(client),
+		UserAgent: client.UserAgent,
+		Reason:    reason,
+	}
+	if user != nil {
+		attempt.UserID = &user.ID
+	}
+	if err := u.loginAttemptRepo.Create(attempt); err != nil {
+		return err
+	}
+
+	if user == nil {
+		return nil
+	}
+
+	u.auditUseCase.LogChange(user.ID, domain.AuditActionFailedLogin, entityUser, user.ID, nil, map[string]string{"reason": reason}, client)
+
+	failures, err := u.userRepo.RecordFailedLogin(user.ID)
+	if err != nil {
+		return err
+	}
+
+	if u.policy.MaxFailedAttempts <= 0 || failures < u.policy.MaxFailedAttempts || user.Locked(time.Now()) {
+		return nil
+	}
+
+	until := time.Now().Add(u.policy.LockoutDuration)
+	notification := u.notificationUseCase.Prepare(user.ID, domain.NotificationTypeSecurity, "account_locked", until, failures)
+	if err := u.userRepo.Lock(user.ID, until, notification); err != nil {
+		return err
+	}
+
+	u.auditUseCase.LogChange(user.ID, domain.AuditActionAccountLocked, entityUser, user.ID, nil, map[string]interface{}{
+		"failed_attempts": failures,
+		"locked_until":    until,
+	}, client)
+
+	return nil
+}
+
+// RecordSuccess logs a completed login and clears the failure counter.
+func (u *LockoutUseCase) RecordSuccess(user *domain.User, client domain.ClientInfo) error {
+	if err := u.loginAttemptRepo.Create(&domain.LoginAttempt{
+		UserID:    &user.ID,
+		Email:     user.Email,
+		IPAddress: clientIP(client),
+		UserAgent: client.UserAgent,
+		Success:   true,
+	}); err != nil {
+		return err
+	}
+
+	if user.FailedLoginAttempts == 0 && user.LockedUntil == nil {
+		return nil
+	}
+	return u.userRepo.ResetFailedLogins(user.ID)
+}
+
+// Reauthenticate checks the password of a signed-in user before a sensitive
+// change. Wrong passwords count towards the same delay and lock as failed
+// logins, so a stolen access token cannot be used to guess the password.
+func (u *LockoutUseCase) Reauthenticate(user *domain.User, password string, client domain.ClientInfo) error {
+	if err := u.CheckPassword(user, password, client); err != nil {
+		return err
+	}
+
+	return u.RecordSuccess(user, client)
+}
+
+// CheckPassword is Reauthenticate without clearing the failure counter, for
+// callers that check a second factor next and must only call RecordSuccess
+// once that has passed too.
+func (u *LockoutUseCase) CheckPassword(user *domain.User, password string, client domain.ClientInfo) error {
+	if err := u.CheckUser(user); err != nil {
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		if err := u.RecordFailure(user, user.Email, client, "invalid password on re-authentication"); err != nil {
+			return err
+		}
+		return domain.ErrInvalidCredentials
+	}
+
+	return nil
+}
+
+// Unlock lets an admin lift a lock before it expires.
+func (u *LockoutUseCase) Unlock(adminID int64, userID int64, client domain.ClientInfo) error {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.userRepo.ResetFailedLogins(userID); err != nil {
+		return err
+	}
+
+	u.auditUseCase.LogChange(adminID, domain.AuditActionUnlockAccount, entityUser, userID, map[string]interface{}{
+		"failed_attempts": user.FailedLoginAttempts,
+		"locked_until":    user.LockedUntil,
+	}, nil, client)
+
+	if user.LockedUntil != nil {
+		u.notificationUseCase.Notify(userID, domain.NotificationTypeSecurity, "account_unlocked")
+	}
+
+	return nil
+}
+
+// delay doubles with every failure past DelayAfterAttempts and never
+// exceeds the lockout duration.
+func (u *LockoutUseCase) delay(failures int) time.Duration {
+	delay := u.policy.BaseDelay
+	for i := u.policy.DelayAfterAttempts; i < failures; i++ {
+		delay *= 2
+		if u.policy.LockoutDuration > 0 && delay >= u.policy.LockoutDuration {
+			return u.policy.LockoutDuration
+		}
+	}
+	return delay
+}
@@ -0,0 +1,211 @@
+// internal/usecase/notification_delivery_usecase.go
+package usecase
+
+import (
+	"GonPay_Backend/internal/domain"
+	"GonPay_Backend/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const entityNotificationDelivery = "NOTIFICATION_DELIVERY"
+
+// DeliveryPolicy configures how often the outbox is worked and how failed
+// deliveries are retried.
+type DeliveryPolicy struct {
+	// The outbox is checked every PollInterval for up to BatchSize due
+	// deliveries. A claimed delivery is hidden from other dispatchers for
+	// Lease, which must be longer than a send can take.
+	PollInterval time.Duration
+	BatchSize    int
+	Lease        time.Duration
+	// A failed attempt is retried after BaseBackoff, doubling with every
+	// failure up to MaxBackoff. After MaxAttempts attempts the delivery is
+	// dead and only an admin can retry it.
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+// NotificationDeliveryUseCase works the notification outbox: it sends each
+// pending delivery on its channel, schedules retries and lets admins look
+// at and retry dead deliveries.
+type NotificationDeliveryUseCase struct {
+	deliveryRepo     domain.NotificationDeliveryRepository
+	notificationRepo domain.NotificationRepository
+	userRepo         domain.UserRepository
+	senders          map[domain.NotificationChannel]domain.NotificationSender
+	auditUseCase     *AuditUseCase
+	logger           logger.Logger
+	policy           DeliveryPolicy
+}
+
+func NewNotificationDeliveryUseCase(
+	deliveryRepo domain.NotificationDeliveryRepository,
+	notificationRepo domain.NotificationRepository,
+	userRepo domain.UserRepository,
+	senders map[domain.NotificationChannel]domain.NotificationSender,
+	auditUseCase *AuditUseCase,
+	logger logger.Logger,
+	policy DeliveryPolicy,
+) *NotificationDeliveryUseCase {
+	return &NotificationDeliveryUseCase{
+		deliveryRepo:     deliveryRepo,
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		senders:          senders,
+		auditUseCase:     auditUseCase,
+		logger:           logger,
+		policy:           policy,
+	}
+}
+
+// Run dispatches due deliveries until ctx is cancelled. A full batch is
+// followed by the next one straight away, so a backlog drains without
+// waiting for the poll interval.
+func (u *NotificationDeliveryUseCase) Run(ctx context.Context) {
+	ticker := time.NewTicker(u.policy.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if u.DispatchDue() == u.policy.BatchSize && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchDue sends one batch of due deliveries and returns how many it
+// claimed.
+func (u *NotificationDeliveryUseCase) DispatchDue() int {
+	deliveries, err := u.deliveryRepo.ClaimDue(u.policy.BatchSize, u.policy.Lease)
+	if err != nil {
+		u.logger.Error("Cannot claim notification deliveries", "error", err)
+		return 0
+	}
+
+	for _, delivery := range deliveries {
+		u.recordOutcome(delivery, u.send(delivery))
+	}
+
+	return len(deliveries)
+}
+
+func (u *NotificationDeliveryUseCase) send(delivery *domain.NotificationDelivery) error {
+	sender, ok := u.senders[delivery.Channel]
+	if !ok {
+		return fmt.Errorf("no sender for channel %s", delivery.Channel)
+	}
+
+	notification, err := u.notificationRepo.GetByID(delivery.NotificationID)
+	if err != nil {
+		return err
+	}
+
+	user, err := u.userRepo.GetByID(delivery.UserID)
+	if err != nil {
+		return err
+	}
+	if user.Status == domain.UserStatusClosed {
+		return domain.ErrNoDeliveryAddress
+	}
+
+	return sender.Send(user, notification)
+}
+
+// recordOutcome stores the result of an attempt. Users who cannot be
+// reached on the channel are skipped rather than retried.
+func (u *NotificationDeliveryUseCase) recordOutcome(delivery *domain.NotificationDelivery, err error) {
+	now := time.Now()
+	switch {
+	case err == nil:
+		delivery.Status = domain.DeliveryStatusSent
+		delivery.SentAt = &now
+		delivery.LastError = ""
+	case errors.Is(err, domain.ErrNoDeliveryAddress), errors.Is(err, domain.ErrUserNotFound):
+		delivery.Status = domain.DeliveryStatusSkipped
+		delivery.LastError = err.Error()
+	case delivery.Attempts >= u.policy.MaxAttempts:
+		delivery.Status = domain.DeliveryStatusDead
+		delivery.LastError = err.Error()
+		u.logger.Warn("Notification delivery is dead",
+			"delivery_id", delivery.ID,
+			"channel", delivery.Channel,
+			"attempts", delivery.Attempts,
+			"error", err,
+		)
+	default:
+		delivery.NextAttemptAt = now.Add(u.backoff(delivery.Attempts))
+		delivery.LastError = err.Error()
+	}
+
+	if err := u.deliveryRepo.SetOutcome(delivery); err != nil {
+		u.logger.Error("Cannot record notification delivery", "delivery_id", delivery.ID, "error", err)
+	}
+}
+
+// backoff is the wait before the attempt after the given number of failed
+// attempts.
+func (u *NotificationDeliveryUseCase) backoff(attempts int) time.Duration {
+	delay := u.policy.BaseBackoff
+	for i := 1; i < attempts && delay < u.policy.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > u.policy.MaxBackoff {
+		delay = u.policy.MaxBackoff
+	}
+	return delay
+}
+
+// GetDeadLetters lists dead deliveries, most recently failed first,
+// optionally for one channel only.
+func (u *NotificationDeliveryUseCase) GetDeadLetters(channel string, page, limit int) ([]*domain.NotificationDelivery, error) {
+	deliveryChannel := domain.NotificationChannel(strings.ToUpper(channel))
+	switch deliveryChannel {
+	case "", domain.NotificationChannelEmail, domain.NotificationChannelSMS, domain.NotificationChannelPush:
+	default:
+		return nil, fmt.Errorf("%w: unknown notification channel %q", domain.ErrInvalidOperation, channel)
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	offset := (page - 1) * limit
+	return u.deliveryRepo.GetDead(deliveryChannel, limit, offset)
+}
+
+// Retry puts a dead delivery back in the outbox with a fresh set of
+// attempts, for when the cause of the failures has been fixed.
+func (u *NotificationDeliveryUseCase) Retry(adminID int64, deliveryID int64, client domain.ClientInfo) (*domain.NotificationDelivery, error) {
+	delivery, err := u.deliveryRepo.GetByID(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.Status != domain.DeliveryStatusDead {
+		return nil, domain.ErrDeliveryNotDead
+	}
+
+	if err := u.deliveryRepo.Requeue(deliveryID); err != nil {
+		return nil, err
+	}
+
+	u.auditUseCase.LogChange(adminID, domain.AuditActionRetryDelivery, entityNotificationDelivery, deliveryID,
+		map[string]interface{}{"status": domain.DeliveryStatusDead, "attempts": delivery.Attempts, "last_error": delivery.LastError},
+		map[string]interface{}{"status": domain.DeliveryStatusPending},
+		client,
+	)
+
+	return u.deliveryRepo.GetByID(deliveryID)
+}
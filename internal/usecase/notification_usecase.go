@@ -3,15 +3,27 @@ package usecase
 
 import (
 	"GonPay_Backend/internal/domain"
+	"GonPay_Backend/internal/i18n"
 )
 
 type NotificationUseCase struct {
 	notificationRepo domain.NotificationRepository
+	deliveryRepo     domain.NotificationDeliveryRepository
+	pushDeviceRepo   domain.PushDeviceRepository
+	userRepo         domain.UserRepository
 }
 
-func NewNotificationUseCase(notificationRepo domain.NotificationRepository) *NotificationUseCase {
+func NewNotificationUseCase(
+	notificationRepo domain.NotificationRepository,
+	deliveryRepo domain.NotificationDeliveryRepository,
+	pushDeviceRepo domain.PushDeviceRepository,
+	userRepo domain.UserRepository,
+) *NotificationUseCase {
 	return &NotificationUseCase{
 		notificationRepo: notificationRepo,
+		deliveryRepo:     deliveryRepo,
+		pushDeviceRepo:   pushDeviceRepo,
+		userRepo:         userRepo,
 	}
 }
 
@@ -31,6 +43,73 @@ func (u *NotificationUseCase) CreateNotification(userID int64, title string, con
 	return notification, nil
 }
 
+// Notify creates a notification from the notification.<template>.title and
+// .body catalog entries and queues it on the user's channels. See Prepare.
+func (u *NotificationUseCase) Notify(userID int64, notificationType domain.NotificationType, template string, args ...interface{}) (*domain.Notification, error) {
+	notification := u.Prepare(userID, notificationType, template, args...)
+	if err := u.notificationRepo.Create(notification); err != nil {
+		return nil, err
+	}
+
+	return notification, nil
+}
+
+// Prepare builds a notification without storing it, for repositories that
+// store it in the same transaction as the change it reports. The text is
+// written in the user's language with dates and amounts in their time zone
+// and currency format, and is fixed when the notification is created.
+func (u *NotificationUseCase) Prepare(userID int64, notificationType domain.NotificationType, template string, args ...interface{}) *domain.Notification {
+	locale := i18n.DefaultLocale()
+	var channels []domain.NotificationChannel
+	if user, err := u.userRepo.GetByID(userID); err == nil {
+		locale = i18n.UserLocale(user)
+		channels = notificationChannels(user, notificationType)
+	}
+
+	return &domain.Notification{
+		UserID:           userID,
+		Title:            locale.T("notification." + template + ".title"),
+		Content:          locale.T("notification."+template+".body", args...),
+		NotificationType: notificationType,
+		Channels:         channels,
+	}
+}
+
+// notificationChannels picks the channels a notification goes out on from
+// the user's preferences. Security notifications go out on every channel,
+// and promotions only to users who agreed to marketing.
+func notificationChannels(user *domain.User, notificationType domain.NotificationType) []domain.NotificationChannel {
+	preferences, err := domain.ParsePreferences(user.Preferences)
+	if err != nil {
+		preferences = domain.DefaultPreferences()
+	}
+
+	switch notificationType {
+	case domain.NotificationTypeSecurity:
+		return []domain.NotificationChannel{
+			domain.NotificationChannelEmail,
+			domain.NotificationChannelSMS,
+			domain.NotificationChannelPush,
+		}
+	case domain.NotificationTypePromotion:
+		if !preferences.Privacy.MarketingConsent {
+			return nil
+		}
+	}
+
+	var channels []domain.NotificationChannel
+	if preferences.Notifications.Email {
+		channels = append(channels, domain.NotificationChannelEmail)
+	}
+	if preferences.Notifications.SMS {
+		channels = append(channels, domain.NotificationChannelSMS)
+	}
+	if preferences.Notifications.Push {
+		channels = append(channels, domain.NotificationChannelPush)
+	}
+	return channels
+}
+
 func (u *NotificationUseCase) GetUserNotifications(userID int64, page, limit int) ([]*domain.Notification, error) {
 	if page < 1 {
 		page = 1
@@ -43,6 +122,8 @@ func (u *NotificationUseCase) GetUserNotifications(userID int64, page, limit int
 	return u.notificationRepo.GetByUserID(userID, limit, offset)
 }
 
+// GetNotification returns one of the user's notifications with its status
+// on each channel.
 func (u *NotificationUseCase) GetNotification(id int64, userID int64) (*domain.Notification, error) {
 	notification, err := u.notificationRepo.GetByID(id)
 	if err != nil {
@@ -53,6 +134,11 @@ func (u *NotificationUseCase) GetNotification(id int64, userID int64) (*domain.N
 		return nil, domain.ErrInvalidOperation
 	}
 
+	notification.Deliveries, err = u.deliveryRepo.GetByNotificationID(id)
+	if err != nil {
+		return nil, err
+	}
+
 	return notification, nil
 }
 
@@ -67,3 +153,27 @@ func (u *NotificationUseCase) MarkAsRead(id int64, userID int64) error {
 func (u *NotificationUseCase) MarkAllAsRead(userID int64) error {
 	return u.notificationRepo.MarkAllAsRead(userID)
 }
+
+// RegisterDevice stores the push token of an app install, so push
+// notifications reach it.
+func (u *NotificationUseCase) RegisterDevice(userID int64, platform domain.DevicePlatform, token string) (*domain.PushDevice, error) {
+	device := &domain.PushDevice{
+		UserID:   userID,
+		Platform: platform,
+		Token:    token,
+	}
+
+	if err := u.pushDeviceRepo.Register(device); err != nil {
+		return nil, err
+	}
+
+	return device, nil
+}
+
+func (u *NotificationUseCase) GetDevices(userID int64) ([]*domain.PushDevice, error) {
+	return u.pushDeviceRepo.GetByUserID(userID)
+}
+
+func (u *NotificationUseCase) DeleteDevice(id int64, userID int64) error {
+	return u.pushDeviceRepo.Delete(id, userID)
+}
@@ -0,0 +1,186 @@
+// internal/usecase/password_reset_usecase.go
+package usecase
+
+import (
+	"GonPay_Backend/internal/domain"
+	"GonPay_Backend/internal/i18n"
+	"GonPay_Backend/pkg/logger"
+	"GonPay_Backend/pkg/validator"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordResetPolicy configures reset links and how often they can be
+// requested.
+type PasswordResetPolicy struct {
+	TokenTTL       time.Duration
+	ResendInterval time.Duration
+	IPMaxRequests  int
+	IPWindow       time.Duration
+	URL            string
+}
+
+type PasswordResetUseCase struct {
+	resetRepo           domain.PasswordResetRepository
+	userRepo            domain.UserRepository
+	auditUseCase        *AuditUseCase
+	notificationUseCase *NotificationUseCase
+	mailSender          domain.MailSender
+	validator           validator.ValidatorInterface
+	logger              logger.Logger
+	policy              PasswordResetPolicy
+}
+
+func NewPasswordResetUseCase(
+	resetRepo domain.PasswordResetRepository,
+	userRepo domain.UserRepository,
+	auditUseCase *AuditUseCase,
+	notificationUseCase *NotificationUseCase,
+	mailSender domain.MailSender,
+	validator validator.ValidatorInterface,
+	logger logger.Logger,
+	policy PasswordResetPolicy,
+) *PasswordResetUseCase {
+	return &PasswordResetUseCase{
+		resetRepo:           resetRepo,
+		userRepo:            userRepo,
+		auditUseCase:        auditUseCase,
+		notificationUseCase: notificationUseCase,
+		mailSender:          mailSender,
+		validator:           validator,
+		logger:              logger,
+		policy:              policy,
+	}
+}
+
+// RequestReset emails a reset link if an active account exists for the
+// email. Only the IP throttle is checked before returning; the account is
+// looked up and the mail sent in the background, so neither the response nor
+// its timing tells whether the account exists.
+func (u *PasswordResetUseCase) RequestReset(email string, client domain.ClientInfo) error {
+	if err := u.checkIP(client); err != nil {
+		return err
+	}
+
+	go u.issueReset(strings.TrimSpace(email))
+
+	return nil
+}
+
+func (u *PasswordResetUseCase) checkIP(client domain.ClientInfo) error {
+	ip := clientIP(client)
+	if u.policy.IPMaxRequests <= 0 || ip == "" {
+		return nil
+	}
+
+	requests, err := u.resetRepo.CountRequestsByIP(ip, time.Now().Add(-u.policy.IPWindow))
+	if err != nil {
+		return err
+	}
+	if requests >= u.policy.IPMaxRequests {
+		return &domain.LoginBlockedError{Err: domain.ErrRateLimited, RetryAfter: u.policy.IPWindow}
+	}
+
+	return u.resetRepo.RecordRequest(ip)
+}
+
+func (u *PasswordResetUseCase) issueReset(email string) {
+	user, err := u.userRepo.GetByEmail(email)
+	if err == domain.ErrUserNotFound {
+		return
+	}
+	if err != nil {
+		u.logger.Error("Cannot look up user for password reset", "error", err)
+		return
+	}
+
+	if user.Status != domain.UserStatusActive {
+		return
+	}
+
+	// A recent link is still on its way; the requester is not told either way
+	latest, err := u.resetRepo.GetLatest(user.ID)
+	if err != nil {
+		u.logger.Error("Cannot load password reset token", "user_id", user.ID, "error", err)
+		return
+	}
+	if latest != nil && time.Since(latest.CreatedAt) < u.policy.ResendInterval {
+		return
+	}
+
+	token, err := generateOpaqueToken()
+	if err != nil {
+		u.logger.Error("Cannot generate password reset token", "user_id", user.ID, "error", err)
+		return
+	}
+
+	// Only the most recent link works
+	if err := u.resetRepo.InvalidateByUserID(user.ID); err != nil {
+		u.logger.Error("Cannot invalidate password reset tokens", "user_id", user.ID, "error", err)
+		return
+	}
+
+	if err := u.resetRepo.Create(&domain.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(u.policy.TokenTTL),
+	}); err != nil {
+		u.logger.Error("Cannot create password reset token", "user_id", user.ID, "error", err)
+		return
+	}
+
+	u.sendResetMail(user, token)
+}
+
+// ResetPassword sets a new password using an emailed token and signs the
+// user out everywhere.
+func (u *PasswordResetUseCase) ResetPassword(token, newPassword string, client domain.ClientInfo) error {
+	resetToken, err := u.resetRepo.GetByHash(hashToken(token))
+	if err != nil {
+		return err
+	}
+
+	if resetToken.UsedAt != nil || time.Now().After(resetToken.ExpiresAt) {
+		return domain.ErrInvalidToken
+	}
+
+	if err := u.validator.ValidatePassword(newPassword); err != nil {
+		return validationError(err)
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	fresh, err := u.resetRepo.MarkUsed(resetToken.ID)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return domain.ErrInvalidToken
+	}
+
+	// Proving control of the mailbox also lifts a login lockout
+	notification := u.notificationUseCase.Prepare(resetToken.UserID, domain.NotificationTypeSecurity, "password_reset")
+	if err := u.userRepo.ResetPassword(resetToken.UserID, string(hashedPassword), notification); err != nil {
+		return err
+	}
+
+	u.auditUseCase.LogChange(resetToken.UserID, domain.AuditActionResetPassword, entityUser, resetToken.UserID, nil, map[string]bool{"sessions_revoked": true}, client)
+
+	return nil
+}
+
+func (u *PasswordResetUseCase) sendResetMail(user *domain.User, token string) {
+	link := fmt.Sprintf(u.policy.URL, token)
+	locale := i18n.UserLocale(user)
+	body := locale.T("mail.password_reset.body", user.Username, int(u.policy.TokenTTL.Minutes()), link)
+
+	if err := u.mailSender.Send(user.Email, locale.T("mail.password_reset.subject"), body); err != nil {
+		u.logger.Error("Cannot send password reset email", "user_id", user.ID, "error", err)
+	}
+}
@@ -0,0 +1,158 @@
+// internal/usecase/preferences_usecase.go
+package usecase
+
+import (
+	"GonPay_Backend/internal/domain"
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+type PreferencesUseCase struct {
+	userRepo     domain.UserRepository
+	walletRepo   domain.WalletRepository
+	auditUseCase *AuditUseCase
+}
+
+func NewPreferencesUseCase(
+	userRepo domain.UserRepository,
+	walletRepo domain.WalletRepository,
+	auditUseCase *AuditUseCase,
+) *PreferencesUseCase {
+	return &PreferencesUseCase{
+		userRepo:     userRepo,
+		walletRepo:   walletRepo,
+		auditUseCase: auditUseCase,
+	}
+}
+
+// GetPreferences returns the user's preferences, upgraded to the current
+// schema version. The upgrade is written back on the next update.
+func (u *PreferencesUseCase) GetPreferences(userID int64) (*domain.Preferences, error) {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.ParsePreferences(user.Preferences)
+}
+
+// UpdatePreferences applies a JSON Merge Patch (RFC 7396) to the user's
+// preferences: fields in the patch replace the current value, null resets a
+// field to its default and fields not mentioned are left alone. The result
+// must match the schema; unknown fields are refused.
+func (u *PreferencesUseCase) UpdatePreferences(userID int64, patch []byte, client domain.ClientInfo) (*domain.Preferences, error) {
+	var patchDocument interface{}
+	if err := json.Unmarshal(patch, &patchDocument); err != nil {
+		return nil, fmt.Errorf("%w: patch is not valid JSON", domain.ErrInvalidPreferences)
+	}
+	patchObject, ok := patchDocument.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: patch must be a JSON object", domain.ErrInvalidPreferences)
+	}
+	if _, ok := patchObject["version"]; ok {
+		return nil, fmt.Errorf("%w: version cannot be changed", domain.ErrInvalidPreferences)
+	}
+
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := domain.ParsePreferences(user.Preferences)
+	if err != nil {
+		return nil, err
+	}
+
+	currentDocument, err := toJSONDocument(current)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := json.Marshal(mergePatch(currentDocument, patchObject))
+	if err != nil {
+		return nil, err
+	}
+
+	// Decoding over the defaults gives removed fields their default value
+	updated := domain.DefaultPreferences()
+	decoder := json.NewDecoder(bytes.NewReader(merged))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(updated); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidPreferences, err)
+	}
+
+	if err := updated.Validate(); err != nil {
+		return nil, err
+	}
+
+	if updated.DefaultWalletID != nil && (current.DefaultWalletID == nil || *current.DefaultWalletID != *updated.DefaultWalletID) {
+		if err := u.checkDefaultWallet(userID, *updated.DefaultWalletID); err != nil {
+			return nil, err
+		}
+	}
+
+	raw, err := json.Marshal(updated)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.userRepo.UpdatePreferences(userID, string(raw)); err != nil {
+		return nil, err
+	}
+
+	u.auditUseCase.LogChange(userID, domain.AuditActionUpdatePrefs, entityUser, userID, current, updated, client)
+
+	return updated, nil
+}
+
+// checkDefaultWallet makes sure the default wallet is one of the user's own
+// wallets and is still open.
+func (u *PreferencesUseCase) checkDefaultWallet(userID, walletID int64) error {
+	wallet, err := u.walletRepo.GetByID(walletID)
+	if err != nil || wallet.UserID != userID {
+		return fmt.Errorf("%w: default_wallet_id is not one of your wallets", domain.ErrInvalidPreferences)
+	}
+	if wallet.Status == domain.WalletStatusClosed {
+		return fmt.Errorf("%w: default wallet is closed", domain.ErrInvalidPreferences)
+	}
+	return nil
+}
+
+// mergePatch applies patch to target as described in RFC 7396.
+func mergePatch(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+
+	return targetObject
+}
+
+// toJSONDocument converts a value to the generic form encoding/json decodes
+// objects into.
+func toJSONDocument(value interface{}) (interface{}, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(raw, &document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
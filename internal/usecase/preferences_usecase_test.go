@@ -0,0 +1,49 @@
+// internal/usecase/preferences_usecase_test.go
+package usecase
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// The examples of RFC 7396 Appendix A
+func TestMergePatchRFC7396Examples(t *testing.T) {
+	tests := []struct {
+		target string
+		patch  string
+		want   string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range tests {
+		got := mergePatch(decodeJSON(t, tt.target), decodeJSON(t, tt.patch))
+		if want := decodeJSON(t, tt.want); !reflect.DeepEqual(got, want) {
+			t.Errorf("mergePatch(%s, %s) = %v, want %s", tt.target, tt.patch, got, tt.want)
+		}
+	}
+}
+
+func decodeJSON(t *testing.T, raw string) interface{} {
+	t.Helper()
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		t.Fatalf("invalid JSON %s: %v", raw, err)
+	}
+	return value
+}
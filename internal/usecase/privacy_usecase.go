@@ -0,0 +1,381 @@
+// internal/usecase/privacy_usecase.go
+package usecase
+
+import (
+	"GonPay_Backend/internal/domain"
+	"GonPay_Backend/pkg/logger"
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	entityDataExport = "DATA_EXPORT"
+
+	// exportPageSize is how many rows are read at a time when collecting
+	// paginated data for an export.
+	exportPageSize = 500
+	// A PENDING export older than this is assumed lost, e.g. in a restart,
+	// and the user may request a new one.
+	exportStaleAfter = 15 * time.Minute
+)
+
+// PrivacyPolicy sets how long export archives can be downloaded and how long
+// the financial records of a closed account are retained.
+type PrivacyPolicy struct {
+	ExportTTL       time.Duration
+	RetentionPeriod time.Duration
+}
+
+// PrivacyUseCase lets users download a copy of their personal data and close
+// their account.
+type PrivacyUseCase struct {
+	userRepo            domain.UserRepository
+	walletRepo          domain.WalletRepository
+	transactionRepo     domain.TransactionRepository
+	beneficiaryRepo     domain.BeneficiaryRepository
+	paymentMethodRepo   domain.PaymentMethodRepository
+	notificationRepo    domain.NotificationRepository
+	auditRepo           domain.AuditRepository
+	exportRepo          domain.DataExportRepository
+	blobStore           domain.BlobStore
+	lockoutUseCase      *LockoutUseCase
+	auditUseCase        *AuditUseCase
+	notificationUseCase *NotificationUseCase
+	logger              logger.Logger
+	policy              PrivacyPolicy
+}
+
+func NewPrivacyUseCase(
+	userRepo domain.UserRepository,
+	walletRepo domain.WalletRepository,
+	transactionRepo domain.TransactionRepository,
+	beneficiaryRepo domain.BeneficiaryRepository,
+	paymentMethodRepo domain.PaymentMethodRepository,
+	notificationRepo domain.NotificationRepository,
+	auditRepo domain.AuditRepository,
+	exportRepo domain.DataExportRepository,
+	blobStore domain.BlobStore,
+	lockoutUseCase *LockoutUseCase,
+	auditUseCase *AuditUseCase,
+	notificationUseCase *NotificationUseCase,
+	logger logger.Logger,
+	policy PrivacyPolicy,
+) *PrivacyUseCase {
+	return &PrivacyUseCase{
+		userRepo:            userRepo,
+		walletRepo:          walletRepo,
+		transactionRepo:     transactionRepo,
+		beneficiaryRepo:     beneficiaryRepo,
+		paymentMethodRepo:   paymentMethodRepo,
+		notificationRepo:    notificationRepo,
+		auditRepo:           auditRepo,
+		exportRepo:          exportRepo,
+		blobStore:           blobStore,
+		lockoutUseCase:      lockoutUseCase,
+		auditUseCase:        auditUseCase,
+		notificationUseCase: notificationUseCase,
+		logger:              logger,
+		policy:              policy,
+	}
+}
+
+// RequestExport starts assembling a ZIP archive of the user's data in the
+// background. The user is notified when it can be downloaded.
+func (u *PrivacyUseCase) RequestExport(userID int64, client domain.ClientInfo) (*domain.DataExport, error) {
+	latest, err := u.exportRepo.GetLatest(userID)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && latest.Status == domain.DataExportStatusPending && time.Since(latest.CreatedAt) < exportStaleAfter {
+		return nil, domain.ErrDataExportPending
+	}
+
+	export := &domain.DataExport{
+		UserID: userID,
+		Status: domain.DataExportStatusPending,
+	}
+
+	if err := u.exportRepo.Create(export); err != nil {
+		return nil, err
+	}
+
+	u.auditUseCase.LogChange(userID, domain.AuditActionRequestExport, entityDataExport, export.ID, nil, nil, client)
+
+	go u.buildExport(*export)
+
+	return export, nil
+}
+
+// GetLatestExport returns the user's most recent export, or nil.
+func (u *PrivacyUseCase) GetLatestExport(userID int64) (*domain.DataExport, error) {
+	return u.exportRepo.GetLatest(userID)
+}
+
+// OpenExport returns the archive of a ready export. The caller must close it.
+func (u *PrivacyUseCase) OpenExport(userID int64, exportID int64, client domain.ClientInfo) (io.ReadCloser, *domain.DataExport, error) {
+	export, err := u.exportRepo.GetByID(exportID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if export.UserID != userID {
+		return nil, nil, domain.ErrDataExportNotFound
+	}
+	if export.Status != domain.DataExportStatusReady || export.StorageKey == "" || export.Expired(time.Now()) {
+		return nil, nil, domain.ErrDataExportNotReady
+	}
+
+	content, err := u.blobStore.Get(export.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	u.auditUseCase.LogChange(userID, domain.AuditActionDownloadExport, entityDataExport, export.ID, nil, nil, client)
+
+	return content, export, nil
+}
+
+// CloseAccount closes the user's account after checking their password.
+// Every wallet must be emptied first. Personal data is replaced with
+// placeholders; financial records are kept for the retention period.
+// Staff must have their role removed before closing their account.
+func (u *PrivacyUseCase) CloseAccount(userID int64, password string, client domain.ClientInfo) error {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.Status == domain.UserStatusClosed {
+		return domain.ErrAccountClosed
+	}
+	if len(domain.RolePermissions[user.Role]) > 0 {
+		return fmt.Errorf("%w: staff accounts cannot be closed while they hold a staff role", domain.ErrInvalidOperation)
+	}
+
+	if err := u.lockoutUseCase.Reauthenticate(user, password, client); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	retainUntil := now.Add(u.policy.RetentionPeriod)
+	if err := u.userRepo.Close(userID, now, retainUntil); err != nil {
+		return err
+	}
+
+	u.auditUseCase.LogChange(userID, domain.AuditActionCloseAccount, entityUser, userID,
+		map[string]interface{}{"status": user.Status},
+		map[string]interface{}{"status": domain.UserStatusClosed, "retain_until": retainUntil},
+		client,
+	)
+
+	// Export archives hold the personal data that was just removed
+	exports, err := u.exportRepo.GetByUserID(userID)
+	if err != nil {
+		u.logger.Error("Cannot list data exports of closed account", "user_id", userID, "error", err)
+		return nil
+	}
+	for _, export := range exports {
+		if export.StorageKey == "" {
+			continue
+		}
+		if err := u.blobStore.Delete(export.StorageKey); err != nil {
+			u.logger.Error("Cannot delete data export", "export_id", export.ID, "error", err)
+		}
+	}
+	if err := u.exportRepo.DeleteByUserID(userID); err != nil {
+		u.logger.Error("Cannot delete data exports of closed account", "user_id", userID, "error", err)
+	}
+
+	return nil
+}
+
+// buildExport assembles and stores the archive, then replaces any older
+// archive of the user. It runs in its own goroutine, so failures are logged
+// and the export is marked FAILED.
+func (u *PrivacyUseCase) buildExport(export domain.DataExport) {
+	archive, err := u.assembleArchive(export.UserID)
+	if err == nil {
+		export.StorageKey = fmt.Sprintf("exports/%d/%d.zip", export.UserID, export.ID)
+		err = u.blobStore.Put(export.StorageKey, archive)
+	}
+	if err != nil {
+		u.logger.Error("Cannot build data export", "export_id", export.ID, "user_id", export.UserID, "error", err)
+		if err := u.exportRepo.Fail(export.ID); err != nil {
+			u.logger.Error("Cannot mark data export failed", "export_id", export.ID, "error", err)
+		}
+		u.notificationUseCase.Notify(export.UserID, domain.NotificationTypeAccount, "data_export_failed")
+		return
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(u.policy.ExportTTL)
+	export.Status = domain.DataExportStatusReady
+	export.SizeBytes = int64(len(archive))
+	export.CompletedAt = &now
+	export.ExpiresAt = &expiresAt
+
+	if err := u.exportRepo.Complete(&export); err != nil {
+		u.logger.Error("Cannot complete data export", "export_id", export.ID, "error", err)
+		u.blobStore.Delete(export.StorageKey)
+		return
+	}
+
+	u.notificationUseCase.Notify(export.UserID, domain.NotificationTypeAccount, "data_export_ready", expiresAt)
+
+	u.purgeOlderExports(&export)
+}
+
+func (u *PrivacyUseCase) purgeOlderExports(current *domain.DataExport) {
+	exports, err := u.exportRepo.GetByUserID(current.UserID)
+	if err != nil {
+		u.logger.Error("Cannot list data exports", "user_id", current.UserID, "error", err)
+		return
+	}
+
+	for _, export := range exports {
+		if export.ID == current.ID || export.StorageKey == "" {
+			continue
+		}
+		if err := u.blobStore.Delete(export.StorageKey); err != nil {
+			u.logger.Error("Cannot delete data export", "export_id", export.ID, "error", err)
+			continue
+		}
+		if err := u.exportRepo.Purge(export.ID); err != nil {
+			u.logger.Error("Cannot purge data export", "export_id", export.ID, "error", err)
+		}
+	}
+}
+
+// assembleArchive collects the user's data into a ZIP archive with one JSON
+// file per kind of record.
+func (u *PrivacyUseCase) assembleArchive(userID int64) ([]byte, error) {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Status == domain.UserStatusClosed {
+		return nil, domain.ErrAccountClosed
+	}
+
+	preferences, err := domain.ParsePreferences(user.Preferences)
+	if err != nil {
+		return nil, err
+	}
+
+	wallets, err := u.walletRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := u.allTransactions(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	beneficiaries, err := u.beneficiaryRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentMethods, err := u.paymentMethodRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, err := u.allNotifications(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	devices, err := u.notificationUseCase.GetDevices(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	auditLogs, err := u.allAuditLogs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []struct {
+		name  string
+		value interface{}
+	}{
+		{"profile.json", user},
+		{"preferences.json", preferences},
+		{"wallets.json", wallets},
+		{"transactions.json", transactions},
+		{"beneficiaries.json", beneficiaries},
+		{"payment_methods.json", paymentMethods},
+		{"notifications.json", notifications},
+		{"push_devices.json", devices},
+		{"audit_logs.json", auditLogs},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, file := range files {
+		content, err := json.MarshalIndent(file.value, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		w, err := zw.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (u *PrivacyUseCase) allTransactions(userID int64) ([]*domain.Transaction, error) {
+	var all []*domain.Transaction
+	for offset := 0; ; offset += exportPageSize {
+		page, err := u.transactionRepo.GetUserTransactions(userID, exportPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < exportPageSize {
+			return all, nil
+		}
+	}
+}
+
+func (u *PrivacyUseCase) allNotifications(userID int64) ([]*domain.Notification, error) {
+	var all []*domain.Notification
+	for offset := 0; ; offset += exportPageSize {
+		page, err := u.notificationRepo.GetByUserID(userID, exportPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < exportPageSize {
+			return all, nil
+		}
+	}
+}
+
+func (u *PrivacyUseCase) allAuditLogs(userID int64) ([]*domain.AuditLog, error) {
+	var all []*domain.AuditLog
+	for offset := 0; ; offset += exportPageSize {
+		page, err := u.auditRepo.GetByUserID(userID, exportPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < exportPageSize {
+			return all, nil
+		}
+	}
+}
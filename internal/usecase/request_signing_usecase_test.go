@@ -0,0 +1,72 @@
+// internal/usecase/request_signing_usecase_test.go
+package usecase
+
+import "testing"
+
+// Expected signatures were computed independently from the documented
+// canonical form:
+//
+//	METHOD \n /path?query \n timestamp \n nonce \n hex(sha256(body))
+func TestSignRequestKnownAnswer(t *testing.T) {
+	const (
+		secret    = "merchant-secret"
+		timestamp = "1700000000"
+		nonce     = "b7f3c2a1d4e5f60718293a4b"
+	)
+
+	tests := []struct {
+		name       string
+		method     string
+		requestURI string
+		body       []byte
+		want       string
+	}{
+		{
+			name:       "body and query, method upper-cased",
+			method:     "post",
+			requestURI: "/api/merchant/payments?currency=VND",
+			body:       []byte(`{"amount":150000}`),
+			want:       "31e7a993d33c846768f0a3eb52e7713983586360ce8a4c41ac67dc9740e2cd73",
+		},
+		{
+			name:       "empty body",
+			method:     "GET",
+			requestURI: "/api/merchant/payments/42",
+			body:       nil,
+			want:       "96dc372b59d1df5e96706e8aa13e91657932a3efbd779d8749a2963a08906112",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SignRequest(secret, tt.method, tt.requestURI, timestamp, nonce, tt.body)
+			if got != tt.want {
+				t.Errorf("SignRequest() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignRequestCoversEveryField(t *testing.T) {
+	base := SignRequest("secret", "POST", "/pay?a=1", "1700000000", "nonce-0123456789", []byte("{}"))
+
+	tests := []struct {
+		name string
+		got  string
+	}{
+		{"secret", SignRequest("other", "POST", "/pay?a=1", "1700000000", "nonce-0123456789", []byte("{}"))},
+		{"method", SignRequest("secret", "PUT", "/pay?a=1", "1700000000", "nonce-0123456789", []byte("{}"))},
+		{"query", SignRequest("secret", "POST", "/pay?a=2", "1700000000", "nonce-0123456789", []byte("{}"))},
+		{"timestamp", SignRequest("secret", "POST", "/pay?a=1", "1700000001", "nonce-0123456789", []byte("{}"))},
+		{"nonce", SignRequest("secret", "POST", "/pay?a=1", "1700000000", "nonce-0123456780", []byte("{}"))},
+		{"body", SignRequest("secret", "POST", "/pay?a=1", "1700000000", "nonce-0123456789", []byte("{ }"))},
+		// Fields are newline separated, so moving a boundary changes the payload
+		{"field boundary", SignRequest("secret", "POST", "/pay?a=1\n1700000000", "", "nonce-0123456789", []byte("{}"))},
+	}
+
+	for _, tt := range tests {
+		if tt.got == base {
+			t.Errorf("changing the %s did not change the signature", tt.name)
+		}
+	}
+}
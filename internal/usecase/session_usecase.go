@@ -0,0 +1,306 @@
+// internal/usecase/session_usecase.go
+package usecase
+
+import (
+	"GonPay_Backend/internal/auth"
+	"GonPay_Backend/internal/domain"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const entitySession = "SESSION"
+
+// sessionCheckTTL is how long a successful session check is reused. A
+// revoked session, suspended user or role change takes effect within this
+// time on every API instance.
+const sessionCheckTTL = 30 * time.Second
+
+type SessionUseCase struct {
+	sessionRepo         domain.SessionRepository
+	userRepo            domain.UserRepository
+	auditUseCase        *AuditUseCase
+	notificationUseCase *NotificationUseCase
+	tokens              *auth.TokenManager
+	accessTTL           time.Duration
+	refreshTTL          time.Duration
+
+	checkedMu sync.Mutex
+	checked   map[int64]sessionCheck
+}
+
+// sessionCheck is a cached result of Authorize.
+type sessionCheck struct {
+	role  string
+	until time.Time
+}
+
+func NewSessionUseCase(
+	sessionRepo domain.SessionRepository,
+	userRepo domain.UserRepository,
+	auditUseCase *AuditUseCase,
+	notificationUseCase *NotificationUseCase,
+	tokens *auth.TokenManager,
+	accessTTLMinutes int64,
+	refreshTTLHours int64,
+) *SessionUseCase {
+	return &SessionUseCase{
+		sessionRepo:         sessionRepo,
+		userRepo:            userRepo,
+		auditUseCase:        auditUseCase,
+		notificationUseCase: notificationUseCase,
+		tokens:              tokens,
+		accessTTL:           time.Minute * time.Duration(accessTTLMinutes),
+		refreshTTL:          time.Hour * time.Duration(refreshTTLHours),
+		checked:             make(map[int64]sessionCheck),
+	}
+}
+
+// StartSession signs the user in on a new device and returns the first
+// access and refresh token pair of the session.
+func (u *SessionUseCase) StartSession(user *domain.User, client domain.ClientInfo) (*AuthResponse, error) {
+	session := &domain.Session{
+		UserID:    user.ID,
+		UserAgent: client.UserAgent,
+		IPAddress: clientIP(client),
+		ExpiresAt: time.Now().Add(u.refreshTTL),
+	}
+
+	if err := u.sessionRepo.Create(session); err != nil {
+		return nil, err
+	}
+
+	u.auditUseCase.LogChange(user.ID, domain.AuditActionLogin, entitySession, session.ID, nil, session, client)
+
+	return u.issueTokens(user, session)
+}
+
+// Refresh exchanges a refresh token for a new token pair. Each refresh token
+// can be used once; presenting one that was already rotated revokes the whole
+// session, since either the client or an attacker holds a stolen copy.
+func (u *SessionUseCase) Refresh(refreshToken string, client domain.ClientInfo) (*AuthResponse, error) {
+	tokenHash := hashToken(refreshToken)
+
+	token, err := u.sessionRepo.GetRefreshToken(tokenHash)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := u.sessionRepo.GetByID(token.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !session.Active(time.Now()) {
+		return nil, domain.ErrInvalidToken
+	}
+
+	fresh, err := u.sessionRepo.MarkRefreshTokenUsed(tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		if err := u.sessionRepo.Revoke(session.ID); err != nil {
+			return nil, err
+		}
+		u.notificationUseCase.Notify(session.UserID, domain.NotificationTypeSecurity, "token_reused")
+		return nil, domain.ErrTokenReused
+	}
+
+	user, err := u.userRepo.GetByID(session.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Status != domain.UserStatusActive {
+		u.sessionRepo.Revoke(session.ID)
+		return nil, domain.ErrAccountInactive
+	}
+
+	session.IPAddress = clientIP(client)
+	session.UserAgent = client.UserAgent
+	if err := u.sessionRepo.Touch(session.ID, session.IPAddress, session.UserAgent); err != nil {
+		return nil, err
+	}
+
+	return u.issueTokens(user, session)
+}
+
+// Logout ends the session the access token was issued for.
+func (u *SessionUseCase) Logout(userID int64, sessionID int64, client domain.ClientInfo) error {
+	return u.RevokeSession(userID, sessionID, client)
+}
+
+// GetSessions lists the user's active sessions, flagging the one making the request.
+func (u *SessionUseCase) GetSessions(userID int64, currentSessionID int64) ([]*domain.Session, error) {
+	sessions, err := u.sessionRepo.GetActiveByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range sessions {
+		s.Current = s.ID == currentSessionID
+	}
+
+	return sessions, nil
+}
+
+// Authorize checks on every request that the session an access token was
+// issued for is still active and that its user may still sign in, so signing
+// out, suspending or closing an account does not wait for the token to
+// expire. The principal gets the role the user holds now and its
+// permissions, whatever the token claims. Successful checks are reused for
+// sessionCheckTTL.
+func (u *SessionUseCase) Authorize(principal *domain.Principal) error {
+	now := time.Now()
+	role, ok := u.recentlyChecked(principal.SessionID, now)
+	if !ok {
+		session, err := u.sessionRepo.GetByID(principal.SessionID)
+		if err != nil {
+			if errors.Is(err, domain.ErrSessionNotFound) {
+				return domain.ErrInvalidToken
+			}
+			return err
+		}
+		if session.UserID != principal.UserID || session.ImpersonatedBy != nil || !session.Active(now) {
+			return domain.ErrInvalidToken
+		}
+
+		user, err := u.userRepo.GetByID(principal.UserID)
+		if err != nil {
+			return err
+		}
+		if user.Status != domain.UserStatusActive {
+			return domain.ErrAccountInactive
+		}
+
+		role = user.Role
+		u.remember(principal.SessionID, role, now)
+	}
+
+	principal.Role = role
+	principal.Permissions = domain.RolePermissions[role]
+	return nil
+}
+
+func (u *SessionUseCase) recentlyChecked(sessionID int64, now time.Time) (string, bool) {
+	u.checkedMu.Lock()
+	defer u.checkedMu.Unlock()
+	check, ok := u.checked[sessionID]
+	if !ok || !now.Before(check.until) {
+		return "", false
+	}
+	return check.role, true
+}
+
+func (u *SessionUseCase) remember(sessionID int64, role string, now time.Time) {
+	u.checkedMu.Lock()
+	defer u.checkedMu.Unlock()
+	for id, check := range u.checked {
+		if !now.Before(check.until) {
+			delete(u.checked, id)
+		}
+	}
+	u.checked[sessionID] = sessionCheck{role: role, until: now.Add(sessionCheckTTL)}
+}
+
+// forget drops a session from the check cache so that this instance refuses
+// its access tokens straight away.
+func (u *SessionUseCase) forget(sessionID int64) {
+	u.checkedMu.Lock()
+	defer u.checkedMu.Unlock()
+	delete(u.checked, sessionID)
+}
+
+// RevokeSession signs a device out. Its refresh tokens and access tokens
+// stop working immediately.
+func (u *SessionUseCase) RevokeSession(userID int64, sessionID int64, client domain.ClientInfo) error {
+	session, err := u.sessionRepo.GetByID(sessionID)
+	if err != nil {
+		return err
+	}
+
+	if session.UserID != userID {
+		return domain.ErrSessionNotFound
+	}
+
+	if session.RevokedAt != nil {
+		return nil
+	}
+
+	if err := u.sessionRepo.Revoke(sessionID); err != nil {
+		return err
+	}
+	u.forget(sessionID)
+
+	u.auditUseCase.LogChange(userID, domain.AuditActionLogout, entitySession, sessionID, session, nil, client)
+
+	return nil
+}
+
+func (u *SessionUseCase) issueTokens(user *domain.User, session *domain.Session) (*AuthResponse, error) {
+	accessToken, err := u.generateAccessToken(user, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := generateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.sessionRepo.CreateRefreshToken(&domain.RefreshToken{
+		TokenHash: hashToken(refreshToken),
+		SessionID: session.ID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &AuthResponse{
+		User:         user,
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(u.accessTTL.Seconds()),
+		SessionID:    session.ID,
+	}, nil
+}
+
+func (u *SessionUseCase) generateAccessToken(user *domain.User, sessionID int64) (string, error) {
+	claims := jwt.MapClaims{
+		"user_id":     user.ID,
+		"email":       user.Email,
+		"role":        user.Role,
+		"permissions": domain.RolePermissions[user.Role],
+		"sid":         sessionID,
+		"iat":         time.Now().Unix(),
+		"exp":         time.Now().Add(u.accessTTL).Unix(),
+	}
+
+	return u.tokens.Sign(claims)
+}
+
+func generateOpaqueToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func clientIP(client domain.ClientInfo) string {
+	if client.IPAddress == nil {
+		return ""
+	}
+	return client.IPAddress.String()
+}
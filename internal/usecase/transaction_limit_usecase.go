@@ -3,44 +3,80 @@ package usecase
 
 import (
 	"GonPay_Backend/internal/domain"
-	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	entityTransactionLimit = "TRANSACTION_LIMIT"
+	entityLimitPolicy      = "LIMIT_POLICY"
+
+	// defaultTimezone is used for limit windows when the user has not set one
+	defaultTimezone = "Asia/Ho_Chi_Minh"
 )
 
 type TransactionLimitUseCase struct {
-	limitRepo domain.TransactionLimitRepository
+	limitRepo    domain.TransactionLimitRepository
+	policyRepo   domain.LimitPolicyRepository
+	userRepo     domain.UserRepository
+	auditUseCase *AuditUseCase
 }
 
-func NewTransactionLimitUseCase(limitRepo domain.TransactionLimitRepository) *TransactionLimitUseCase {
+func NewTransactionLimitUseCase(
+	limitRepo domain.TransactionLimitRepository,
+	policyRepo domain.LimitPolicyRepository,
+	userRepo domain.UserRepository,
+	auditUseCase *AuditUseCase,
+) *TransactionLimitUseCase {
 	return &TransactionLimitUseCase{
-		limitRepo: limitRepo,
+		limitRepo:    limitRepo,
+		policyRepo:   policyRepo,
+		userRepo:     userRepo,
+		auditUseCase: auditUseCase,
 	}
 }
 
-func (u *TransactionLimitUseCase) SetTransactionLimit(userID int64, transactionType domain.TransactionType, dailyLimit, monthlyLimit float64) (*domain.TransactionLimit, error) {
-	// Validate limits
-	if dailyLimit <= 0 || monthlyLimit <= 0 {
-		return nil, errors.New("limits must be greater than 0")
+// SetTransactionLimit lets a user set their own limit. It may only be
+// stricter than the limit policy that applies to them.
+func (u *TransactionLimitUseCase) SetTransactionLimit(userID int64, limit *domain.TransactionLimit, client domain.ClientInfo) (*domain.TransactionLimit, error) {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := u.policyRepo.GetApplicable(user, limit.TransactionType)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a window mode the limit is counted like the policy
+	if policy != nil && limit.WindowMode == "" {
+		limit.WindowMode = policy.WindowMode
+	}
+
+	if err := validateLimit(&limit.LimitValues); err != nil {
+		return nil, err
 	}
 
-	if dailyLimit > monthlyLimit {
-		return nil, errors.New("daily limit cannot exceed monthly limit")
+	if policy != nil && limit.WindowMode != policy.WindowMode {
+		return nil, domain.NewAppError(domain.CodeValidationFailed, "window mode must match the limit policy")
+	}
+	if policy != nil && !limit.LimitValues.Within(policy.LimitValues) {
+		return nil, domain.ErrLimitAbovePolicy
 	}
 
 	// Check if limit exists
-	existingLimit, err := u.limitRepo.GetByUserAndType(userID, transactionType)
+	existingLimit, err := u.limitRepo.GetByUserAndType(userID, limit.TransactionType)
 	if err != nil {
 		return nil, err
 	}
 
-	limit := &domain.TransactionLimit{
-		UserID:          userID,
-		TransactionType: transactionType,
-		DailyLimit:      dailyLimit,
-		MonthlyLimit:    monthlyLimit,
-	}
+	limit.UserID = userID
 
 	if existingLimit != nil {
 		limit.ID = existingLimit.ID
+		limit.CreatedAt = existingLimit.CreatedAt
 		err = u.limitRepo.Update(limit)
 	} else {
 		err = u.limitRepo.Create(limit)
@@ -50,21 +86,525 @@ func (u *TransactionLimitUseCase) SetTransactionLimit(userID int64, transactionT
 		return nil, err
 	}
 
+	var oldValue interface{}
+	if existingLimit != nil {
+		oldValue = existingLimit
+	}
+	if err := u.auditUseCase.LogChange(userID, domain.AuditActionUpdateLimits, entityTransactionLimit, limit.ID, oldValue, limit, client); err != nil {
+		return nil, err
+	}
+
 	return limit, nil
 }
 
-func (u *TransactionLimitUseCase) GetUserLimits(userID int64) ([]*domain.TransactionLimit, error) {
-	return u.limitRepo.GetByUserID(userID)
+// GetUserLimits returns the effective limit, usage and headroom for every
+// transaction type that is limited for the user.
+func (u *TransactionLimitUseCase) GetUserLimits(userID int64) ([]*domain.LimitStatus, error) {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*domain.LimitStatus, 0, len(domain.TransactionTypes))
+	for _, transactionType := range domain.TransactionTypes {
+		status, err := u.limitStatus(user, transactionType)
+		if err != nil {
+			return nil, err
+		}
+		if status != nil {
+			statuses = append(statuses, status)
+		}
+	}
+
+	return statuses, nil
+}
+
+// GetLimitUsage reports consumption and headroom per window for every
+// transaction type that is limited for the user.
+func (u *TransactionLimitUseCase) GetLimitUsage(userID int64) ([]*domain.LimitUsageReport, error) {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	location := userLocation(user)
+
+	reports := make([]*domain.LimitUsageReport, 0, len(domain.TransactionTypes))
+	for _, transactionType := range domain.TransactionTypes {
+		limit, _, _, err := u.effectiveLimit(user, transactionType)
+		if err != nil {
+			return nil, err
+		}
+		if limit == nil {
+			continue
+		}
+
+		windows := limitWindows(now, location, limit.WindowMode)
+		usage, err := u.limitRepo.GetUsage(user.ID, transactionType, windows)
+		if err != nil {
+			return nil, err
+		}
+
+		reports = append(reports, &domain.LimitUsageReport{
+			TransactionType: transactionType,
+			WindowMode:      limit.WindowMode,
+			Timezone:        location.String(),
+			Windows:         usageWindows(now, location, windows, limit, usage),
+		})
+	}
+
+	return reports, nil
 }
 
 func (u *TransactionLimitUseCase) GetLimitByType(userID int64, transactionType domain.TransactionType) (*domain.TransactionLimit, error) {
 	return u.limitRepo.GetByUserAndType(userID, transactionType)
 }
 
-func (u *TransactionLimitUseCase) CheckTransactionLimit(userID int64, transactionType domain.TransactionType, amount float64) error {
+// CheckTransactionLimit returns an error wrapping domain.ErrLimitExceeded if
+// the transaction would break the user's effective limits. newRecipient is set
+// for the first payment to a wallet or beneficiary and applies the
+// new-recipient limits. It lets a payment fail before step-up credentials
+// are asked for; RecordTransaction checks again when the row is inserted.
+func (u *TransactionLimitUseCase) CheckTransactionLimit(userID int64, transactionType domain.TransactionType, amount float64, newRecipient bool) error {
 	if amount <= 0 {
 		return domain.ErrInvalidAmount
 	}
 
-	return u.limitRepo.CheckLimit(userID, transactionType, amount)
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	limit, _, _, err := u.effectiveLimit(user, transactionType)
+	if err != nil {
+		return err
+	}
+
+	// If no limits set, allow transaction
+	if limit == nil {
+		return nil
+	}
+
+	usage, err := u.limitRepo.GetUsage(userID, transactionType, limitWindows(time.Now(), userLocation(user), limit.WindowMode))
+	if err != nil {
+		return err
+	}
+
+	return checkLimit(limit, usage, amount, newRecipient)
+}
+
+// RecordTransaction inserts the pending transaction of the user if it fits
+// their effective limits. The usage is read and the row inserted under a
+// per-user lock, so parallel payments are counted against each other.
+func (u *TransactionLimitUseCase) RecordTransaction(userID int64, transaction *domain.Transaction, newRecipient bool) error {
+	if transaction.Amount <= 0 {
+		return domain.ErrInvalidAmount
+	}
+
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	limit, _, _, err := u.effectiveLimit(user, transaction.Type)
+	if err != nil {
+		return err
+	}
+
+	if limit == nil {
+		return u.limitRepo.CreateWithinLimit(userID, transaction, domain.LimitWindows{}, nil)
+	}
+
+	windows := limitWindows(time.Now(), userLocation(user), limit.WindowMode)
+	return u.limitRepo.CreateWithinLimit(userID, transaction, windows, func(usage *domain.LimitUsage) error {
+		return checkLimit(limit, usage, transaction.Amount, newRecipient)
+	})
+}
+
+// checkLimit returns an error wrapping domain.ErrLimitExceeded if amount on
+// top of usage would break limit.
+func checkLimit(limit *domain.LimitValues, usage *domain.LimitUsage, amount float64, newRecipient bool) error {
+	if limit.MinAmount > 0 && amount < limit.MinAmount {
+		return fmt.Errorf("%w: minimum amount is %.2f", domain.ErrLimitExceeded, limit.MinAmount)
+	}
+
+	if limit.MaxSingleAmount > 0 && amount > limit.MaxSingleAmount {
+		return fmt.Errorf("%w: maximum single transaction amount is %.2f", domain.ErrLimitExceeded, limit.MaxSingleAmount)
+	}
+
+	if usage.DailyAmount+amount > limit.DailyLimit {
+		return fmt.Errorf("%w: daily limit, %.2f remaining", domain.ErrLimitExceeded, limit.DailyLimit-usage.DailyAmount)
+	}
+
+	if usage.MonthlyAmount+amount > limit.MonthlyLimit {
+		return fmt.Errorf("%w: monthly limit, %.2f remaining", domain.ErrLimitExceeded, limit.MonthlyLimit-usage.MonthlyAmount)
+	}
+
+	if limit.HourlyCountLimit > 0 && usage.HourlyCount >= limit.HourlyCountLimit {
+		return fmt.Errorf("%w: at most %d transactions per hour", domain.ErrLimitExceeded, limit.HourlyCountLimit)
+	}
+
+	if limit.DailyCountLimit > 0 && usage.DailyCount >= limit.DailyCountLimit {
+		return fmt.Errorf("%w: at most %d transactions per day", domain.ErrLimitExceeded, limit.DailyCountLimit)
+	}
+
+	if !newRecipient {
+		return nil
+	}
+
+	if limit.NewRecipientMaxAmount > 0 && amount > limit.NewRecipientMaxAmount {
+		return fmt.Errorf("%w: maximum amount to a new recipient is %.2f", domain.ErrLimitExceeded, limit.NewRecipientMaxAmount)
+	}
+
+	if limit.NewRecipientDailyLimit > 0 && usage.NewRecipientDailyAmount+amount > limit.NewRecipientDailyLimit {
+		return fmt.Errorf("%w: daily limit to new recipients, %.2f remaining", domain.ErrLimitExceeded, limit.NewRecipientDailyLimit-usage.NewRecipientDailyAmount)
+	}
+
+	return nil
+}
+
+// IsNewRecipient reports whether the user has never completed a transfer to
+// the destination wallet.
+func (u *TransactionLimitUseCase) IsNewRecipient(userID int64, destinationWalletID int64) (bool, error) {
+	paid, err := u.limitRepo.HasPaidRecipient(userID, destinationWalletID)
+	if err != nil {
+		return false, err
+	}
+	return !paid, nil
+}
+
+// Admin policy management
+
+func (u *TransactionLimitUseCase) GetPolicies() ([]*domain.LimitPolicy, error) {
+	return u.policyRepo.GetAll()
+}
+
+func (u *TransactionLimitUseCase) CreatePolicy(adminID int64, policy *domain.LimitPolicy, client domain.ClientInfo) (*domain.LimitPolicy, error) {
+	if err := u.validatePolicyScope(policy); err != nil {
+		return nil, err
+	}
+	if err := validateLimit(&policy.LimitValues); err != nil {
+		return nil, err
+	}
+
+	existing, err := u.policyRepo.GetByScope(policy.Scope, policy.ScopeValue, policy.TransactionType)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, domain.NewAppError(domain.CodeAlreadyExists, "a policy for this scope and transaction type already exists")
+	}
+
+	policy.UpdatedBy = adminID
+	if err := u.policyRepo.Create(policy); err != nil {
+		return nil, err
+	}
+
+	if err := u.auditUseCase.LogChange(adminID, domain.AuditActionUpdateLimits, entityLimitPolicy, policy.ID, nil, policy, client); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (u *TransactionLimitUseCase) UpdatePolicy(adminID int64, id int64, values domain.LimitValues, client domain.ClientInfo) (*domain.LimitPolicy, error) {
+	if err := validateLimit(&values); err != nil {
+		return nil, err
+	}
+
+	policy, err := u.policyRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	oldPolicy := *policy
+	policy.LimitValues = values
+	policy.UpdatedBy = adminID
+
+	if err := u.policyRepo.Update(policy); err != nil {
+		return nil, err
+	}
+
+	if err := u.auditUseCase.LogChange(adminID, domain.AuditActionUpdateLimits, entityLimitPolicy, policy.ID, &oldPolicy, policy, client); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (u *TransactionLimitUseCase) DeletePolicy(adminID int64, id int64, client domain.ClientInfo) error {
+	policy, err := u.policyRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := u.policyRepo.Delete(id); err != nil {
+		return err
+	}
+
+	return u.auditUseCase.LogChange(adminID, domain.AuditActionUpdateLimits, entityLimitPolicy, policy.ID, policy, nil, client)
+}
+
+// SetUserOverride creates or replaces the USER scoped policy for one user,
+// which takes precedence over role, KYC tier and default policies.
+func (u *TransactionLimitUseCase) SetUserOverride(adminID int64, userID int64, transactionType domain.TransactionType, values domain.LimitValues, client domain.ClientInfo) (*domain.LimitPolicy, error) {
+	scopeValue := strconv.FormatInt(userID, 10)
+
+	existing, err := u.policyRepo.GetByScope(domain.LimitPolicyScopeUser, scopeValue, transactionType)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return u.UpdatePolicy(adminID, existing.ID, values, client)
+	}
+
+	return u.CreatePolicy(adminID, &domain.LimitPolicy{
+		Scope:           domain.LimitPolicyScopeUser,
+		ScopeValue:      scopeValue,
+		TransactionType: transactionType,
+		LimitValues:     values,
+	}, client)
+}
+
+// effectiveLimit combines the user's own limit with the applicable policy.
+// It returns a nil limit if neither exists.
+func (u *TransactionLimitUseCase) effectiveLimit(user *domain.User, transactionType domain.TransactionType) (*domain.LimitValues, *domain.TransactionLimit, *domain.LimitPolicy, error) {
+	userLimit, err := u.limitRepo.GetByUserAndType(user.ID, transactionType)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	policy, err := u.policyRepo.GetApplicable(user, transactionType)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var limit domain.LimitValues
+	switch {
+	case userLimit != nil && policy != nil:
+		limit = userLimit.LimitValues.Stricter(policy.LimitValues)
+	case userLimit != nil:
+		limit = userLimit.LimitValues
+	case policy != nil:
+		limit = policy.LimitValues
+	default:
+		return nil, nil, nil, nil
+	}
+
+	return &limit, userLimit, policy, nil
+}
+
+func (u *TransactionLimitUseCase) limitStatus(user *domain.User, transactionType domain.TransactionType) (*domain.LimitStatus, error) {
+	limit, userLimit, policy, err := u.effectiveLimit(user, transactionType)
+	if err != nil || limit == nil {
+		return nil, err
+	}
+
+	usage, err := u.limitRepo.GetUsage(user.ID, transactionType, limitWindows(time.Now(), userLocation(user), limit.WindowMode))
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.LimitStatus{
+		TransactionType: transactionType,
+		Limit:           limit,
+		UserLimit:       userLimit,
+		Policy:          policy,
+		Usage:           usage,
+		Remaining:       remainingLimit(limit, usage),
+	}, nil
+}
+
+func (u *TransactionLimitUseCase) validatePolicyScope(policy *domain.LimitPolicy) error {
+	switch policy.Scope {
+	case domain.LimitPolicyScopeDefault:
+		if policy.ScopeValue != "" {
+			return domain.NewAppError(domain.CodeValidationFailed, "default policies cannot have a scope value")
+		}
+	case domain.LimitPolicyScopeRole:
+		if !domain.ValidRole(policy.ScopeValue) {
+			return domain.NewAppError(domain.CodeValidationFailed, "unknown role")
+		}
+	case domain.LimitPolicyScopeKYCTier:
+		if tier, err := strconv.Atoi(policy.ScopeValue); err != nil || tier < 0 {
+			return domain.NewAppError(domain.CodeValidationFailed, "KYC tier must be a non-negative number")
+		}
+	case domain.LimitPolicyScopeUser:
+		userID, err := strconv.ParseInt(policy.ScopeValue, 10, 64)
+		if err != nil {
+			return domain.NewAppError(domain.CodeValidationFailed, "user scope value must be a user ID")
+		}
+		if _, err := u.userRepo.GetByID(userID); err != nil {
+			return err
+		}
+	default:
+		return domain.NewAppError(domain.CodeValidationFailed, "unknown policy scope")
+	}
+
+	for _, transactionType := range domain.TransactionTypes {
+		if policy.TransactionType == transactionType {
+			return nil
+		}
+	}
+	return domain.NewAppError(domain.CodeValidationFailed, "unknown transaction type")
+}
+
+func validateLimit(limit *domain.LimitValues) error {
+	switch limit.WindowMode {
+	case "":
+		limit.WindowMode = domain.LimitWindowCalendar
+	case domain.LimitWindowCalendar, domain.LimitWindowRolling:
+	default:
+		return domain.NewAppError(domain.CodeValidationFailed, "window mode must be CALENDAR or ROLLING")
+	}
+
+	if limit.DailyLimit <= 0 || limit.MonthlyLimit <= 0 {
+		return domain.NewAppError(domain.CodeValidationFailed, "limits must be greater than 0")
+	}
+
+	if limit.DailyLimit > limit.MonthlyLimit {
+		return domain.NewAppError(domain.CodeValidationFailed, "daily limit cannot exceed monthly limit")
+	}
+
+	if limit.MaxSingleAmount < 0 || limit.MinAmount < 0 || limit.HourlyCountLimit < 0 || limit.DailyCountLimit < 0 ||
+		limit.NewRecipientMaxAmount < 0 || limit.NewRecipientDailyLimit < 0 {
+		return domain.NewAppError(domain.CodeValidationFailed, "limits cannot be negative")
+	}
+
+	if limit.MaxSingleAmount > limit.DailyLimit {
+		return domain.NewAppError(domain.CodeValidationFailed, "maximum single amount cannot exceed daily limit")
+	}
+
+	if limit.MaxSingleAmount > 0 && limit.MinAmount > limit.MaxSingleAmount {
+		return domain.NewAppError(domain.CodeValidationFailed, "minimum amount cannot exceed maximum single amount")
+	}
+
+	if limit.DailyCountLimit > 0 && limit.HourlyCountLimit > limit.DailyCountLimit {
+		return domain.NewAppError(domain.CodeValidationFailed, "hourly count limit cannot exceed daily count limit")
+	}
+
+	if limit.NewRecipientDailyLimit > 0 && limit.NewRecipientMaxAmount > limit.NewRecipientDailyLimit {
+		return domain.NewAppError(domain.CodeValidationFailed, "new recipient maximum amount cannot exceed new recipient daily limit")
+	}
+
+	return nil
+}
+
+func remainingLimit(limit *domain.LimitValues, usage *domain.LimitUsage) *domain.LimitRemaining {
+	remaining := &domain.LimitRemaining{
+		DailyAmount:   nonNegative(limit.DailyLimit - usage.DailyAmount),
+		MonthlyAmount: nonNegative(limit.MonthlyLimit - usage.MonthlyAmount),
+	}
+
+	if limit.HourlyCountLimit > 0 {
+		count := max(limit.HourlyCountLimit-usage.HourlyCount, 0)
+		remaining.HourlyCount = &count
+	}
+
+	if limit.DailyCountLimit > 0 {
+		count := max(limit.DailyCountLimit-usage.DailyCount, 0)
+		remaining.DailyCount = &count
+	}
+
+	if limit.NewRecipientDailyLimit > 0 {
+		amount := nonNegative(limit.NewRecipientDailyLimit - usage.NewRecipientDailyAmount)
+		remaining.NewRecipientDailyAmount = &amount
+	}
+
+	return remaining
+}
+
+func nonNegative(amount float64) float64 {
+	if amount < 0 {
+		return 0
+	}
+	return amount
+}
+
+// userLocation returns the timezone from the user's preferences, falling back
+// to defaultTimezone if it is missing or unknown.
+func userLocation(user *domain.User) *time.Location {
+	if preferences, err := domain.ParsePreferences(user.Preferences); err == nil {
+		if location, err := time.LoadLocation(preferences.Timezone); err == nil {
+			return location
+		}
+	}
+
+	location, err := time.LoadLocation(defaultTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// limitWindows returns where the hour, day and month windows start. The
+// month window always starts first.
+func limitWindows(now time.Time, location *time.Location, mode domain.LimitWindowMode) domain.LimitWindows {
+	if mode == domain.LimitWindowRolling {
+		return domain.LimitWindows{
+			HourStart:  now.Add(-time.Hour),
+			DayStart:   now.Add(-24 * time.Hour),
+			MonthStart: now.AddDate(0, 0, -30),
+		}
+	}
+
+	local := now.In(location)
+	year, month, day := local.Date()
+	return domain.LimitWindows{
+		HourStart:  time.Date(year, month, day, local.Hour(), 0, 0, 0, location),
+		DayStart:   time.Date(year, month, day, 0, 0, 0, 0, location),
+		MonthStart: time.Date(year, month, 1, 0, 0, 0, 0, location),
+	}
+}
+
+func usageWindows(now time.Time, location *time.Location, windows domain.LimitWindows, limit *domain.LimitValues, usage *domain.LimitUsage) []*domain.LimitWindowUsage {
+	hour := &domain.LimitWindowUsage{
+		Window: "HOUR",
+		Start:  windows.HourStart.In(location),
+		End:    windows.HourStart.Add(time.Hour).In(location),
+		Count:  usage.HourlyCount,
+	}
+	day := &domain.LimitWindowUsage{
+		Window: "DAY",
+		Start:  windows.DayStart.In(location),
+		End:    windows.DayStart.In(location).AddDate(0, 0, 1),
+		Amount: usage.DailyAmount,
+		Count:  usage.DailyCount,
+	}
+	month := &domain.LimitWindowUsage{
+		Window: "MONTH",
+		Start:  windows.MonthStart.In(location),
+		End:    windows.MonthStart.In(location).AddDate(0, 1, 0),
+		Amount: usage.MonthlyAmount,
+	}
+
+	if limit.WindowMode == domain.LimitWindowRolling {
+		hour.End = now.In(location)
+		day.End = now.In(location)
+		month.End = now.In(location)
+	}
+
+	day.AmountLimit, day.RemainingAmount = amountHeadroom(limit.DailyLimit, usage.DailyAmount)
+	month.AmountLimit, month.RemainingAmount = amountHeadroom(limit.MonthlyLimit, usage.MonthlyAmount)
+	hour.CountLimit, hour.RemainingCount = countHeadroom(limit.HourlyCountLimit, usage.HourlyCount)
+	day.CountLimit, day.RemainingCount = countHeadroom(limit.DailyCountLimit, usage.DailyCount)
+
+	return []*domain.LimitWindowUsage{hour, day, month}
+}
+
+func amountHeadroom(limit, used float64) (*float64, *float64) {
+	if limit == 0 {
+		return nil, nil
+	}
+	remaining := nonNegative(limit - used)
+	return &limit, &remaining
+}
+
+func countHeadroom(limit, used int) (*int, *int) {
+	if limit == 0 {
+		return nil, nil
+	}
+	remaining := max(limit-used, 0)
+	return &limit, &remaining
 }
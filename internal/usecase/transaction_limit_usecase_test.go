@@ -0,0 +1,101 @@
+// internal/usecase/transaction_limit_usecase_test.go
+package usecase
+
+import (
+	"GonPay_Backend/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestLimitWindows(t *testing.T) {
+	tests := []struct {
+		name     string
+		now      string
+		timezone string
+		mode     domain.LimitWindowMode
+		hour     string
+		day      string
+		month    string
+	}{
+		{
+			name:     "calendar, already the next month in Vietnam",
+			now:      "2024-10-31T18:30:00Z",
+			timezone: "Asia/Ho_Chi_Minh",
+			mode:     domain.LimitWindowCalendar,
+			hour:     "2024-10-31T18:00:00Z",
+			day:      "2024-10-31T17:00:00Z",
+			month:    "2024-10-31T17:00:00Z",
+		},
+		{
+			name:     "calendar, still the previous month in New York",
+			now:      "2024-12-01T03:15:00Z",
+			timezone: "America/New_York",
+			mode:     domain.LimitWindowCalendar,
+			hour:     "2024-12-01T03:00:00Z",
+			day:      "2024-11-30T05:00:00Z",
+			// November started under daylight saving time, UTC-4
+			month: "2024-11-01T04:00:00Z",
+		},
+		{
+			name:     "calendar, half hour offset on a leap day",
+			now:      "2024-03-01T00:10:00Z",
+			timezone: "Asia/Kolkata",
+			mode:     domain.LimitWindowCalendar,
+			hour:     "2024-02-29T23:30:00Z",
+			day:      "2024-02-29T18:30:00Z",
+			month:    "2024-02-29T18:30:00Z",
+		},
+		{
+			name:     "calendar in UTC",
+			now:      "2024-11-18T10:45:30Z",
+			timezone: "UTC",
+			mode:     domain.LimitWindowCalendar,
+			hour:     "2024-11-18T10:00:00Z",
+			day:      "2024-11-18T00:00:00Z",
+			month:    "2024-11-01T00:00:00Z",
+		},
+		{
+			name:     "rolling ignores the timezone",
+			now:      "2024-03-01T00:10:00Z",
+			timezone: "Asia/Ho_Chi_Minh",
+			mode:     domain.LimitWindowRolling,
+			hour:     "2024-02-29T23:10:00Z",
+			day:      "2024-02-29T00:10:00Z",
+			month:    "2024-01-31T00:10:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location, err := time.LoadLocation(tt.timezone)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			windows := limitWindows(mustParseTime(t, tt.now), location, tt.mode)
+
+			for _, check := range []struct {
+				window string
+				got    time.Time
+				want   string
+			}{
+				{"hour", windows.HourStart, tt.hour},
+				{"day", windows.DayStart, tt.day},
+				{"month", windows.MonthStart, tt.month},
+			} {
+				if want := mustParseTime(t, check.want); !check.got.Equal(want) {
+					t.Errorf("%s start = %s, want %s", check.window, check.got.UTC().Format(time.RFC3339), check.want)
+				}
+			}
+		})
+	}
+}
+
+func mustParseTime(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
@@ -0,0 +1,265 @@
+// internal/usecase/transaction_pin_usecase.go
+package usecase
+
+import (
+	"GonPay_Backend/internal/domain"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	entityTransactionPIN = "TRANSACTION_PIN"
+	pinLength            = 6
+)
+
+// StepUpPolicy decides which payments must be confirmed with the transaction
+// PIN or a TOTP code, and how wrong PINs are throttled.
+type StepUpPolicy struct {
+	// Payments of at least Threshold need step-up. Zero requires it for
+	// every payment.
+	Threshold float64
+	// NewRecipient also requires step-up for the first payment to a
+	// recipient, whatever the amount.
+	NewRecipient bool
+	// After MaxFailedAttempts wrong PINs or TOTP codes in a row, step-up is
+	// locked for LockoutDuration and neither credential is accepted. Zero
+	// disables the lock.
+	MaxFailedAttempts int
+	LockoutDuration   time.Duration
+}
+
+type TransactionPINUseCase struct {
+	pinRepo             domain.TransactionPINRepository
+	userRepo            domain.UserRepository
+	twoFactorUseCase    *TwoFactorUseCase
+	lockoutUseCase      *LockoutUseCase
+	auditUseCase        *AuditUseCase
+	notificationUseCase *NotificationUseCase
+	policy              StepUpPolicy
+}
+
+func NewTransactionPINUseCase(
+	pinRepo domain.TransactionPINRepository,
+	userRepo domain.UserRepository,
+	twoFactorUseCase *TwoFactorUseCase,
+	lockoutUseCase *LockoutUseCase,
+	auditUseCase *AuditUseCase,
+	notificationUseCase *NotificationUseCase,
+	policy StepUpPolicy,
+) *TransactionPINUseCase {
+	return &TransactionPINUseCase{
+		pinRepo:             pinRepo,
+		userRepo:            userRepo,
+		twoFactorUseCase:    twoFactorUseCase,
+		lockoutUseCase:      lockoutUseCase,
+		auditUseCase:        auditUseCase,
+		notificationUseCase: notificationUseCase,
+		policy:              policy,
+	}
+}
+
+func (u *TransactionPINUseCase) GetStatus(userID int64) (*domain.TransactionPINStatus, error) {
+	pin, err := u.pinRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &domain.TransactionPINStatus{Set: pin != nil}
+	status.LockedUntil, err = u.pinRepo.LockedUntil(userID)
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// SetPIN sets the PIN for the first time. The login password is required so
+// a stolen access token cannot be used to set one.
+func (u *TransactionPINUseCase) SetPIN(userID int64, password, pin string, client domain.ClientInfo) error {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.lockoutUseCase.Reauthenticate(user, password, client); err != nil {
+		return err
+	}
+
+	existing, err := u.pinRepo.GetByUserID(userID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return domain.ErrPINAlreadySet
+	}
+
+	if err := u.savePIN(userID, pin); err != nil {
+		return err
+	}
+
+	u.auditUseCase.LogChange(userID, domain.AuditActionSetPIN, entityTransactionPIN, userID, nil, map[string]bool{"set": true}, client)
+
+	return nil
+}
+
+// ChangePIN replaces the PIN after checking the current one. Wrong current
+// PINs count towards the lock like any other failure.
+func (u *TransactionPINUseCase) ChangePIN(userID int64, currentPIN, newPIN string, client domain.ClientInfo) error {
+	if err := u.VerifyPIN(userID, currentPIN); err != nil {
+		return err
+	}
+
+	if err := u.savePIN(userID, newPIN); err != nil {
+		return err
+	}
+
+	u.auditUseCase.LogChange(userID, domain.AuditActionSetPIN, entityTransactionPIN, userID, nil, map[string]bool{"changed": true}, client)
+
+	u.notificationUseCase.Notify(userID, domain.NotificationTypeSecurity, "pin_changed")
+
+	return nil
+}
+
+// VerifyPIN checks the PIN, locking step-up once too many wrong PINs or
+// TOTP codes are entered.
+func (u *TransactionPINUseCase) VerifyPIN(userID int64, pin string) error {
+	stored, err := u.pinRepo.GetByUserID(userID)
+	if err != nil {
+		return err
+	}
+	if stored == nil {
+		return domain.ErrPINNotSet
+	}
+
+	return u.checkAttempt(userID, func() error {
+		if err := bcrypt.CompareHashAndPassword([]byte(stored.PINHash), []byte(pin)); err != nil {
+			return domain.ErrInvalidPIN
+		}
+		return nil
+	})
+}
+
+// RequireStepUp is called before a payment leaves the user's wallet. Payments
+// below the threshold to a known recipient pass without credentials; the rest
+// must carry the transaction PIN or, for users with 2FA, a TOTP code.
+func (u *TransactionPINUseCase) RequireStepUp(userID int64, amount float64, newRecipient bool, credentials domain.StepUpCredentials) error {
+	if amount < u.policy.Threshold && !(newRecipient && u.policy.NewRecipient) {
+		return nil
+	}
+
+	return u.VerifyStepUp(userID, credentials)
+}
+
+// VerifyStepUp checks the transaction PIN or TOTP code whatever the amount,
+// for actions that always need step-up.
+func (u *TransactionPINUseCase) VerifyStepUp(userID int64, credentials domain.StepUpCredentials) error {
+	switch {
+	case credentials.PIN != "":
+		return u.VerifyPIN(userID, credentials.PIN)
+	case credentials.TOTPCode != "":
+		enabled, err := u.twoFactorUseCase.IsEnabled(userID)
+		if err != nil {
+			return err
+		}
+		if !enabled {
+			return domain.Err2FANotEnabled
+		}
+		return u.checkAttempt(userID, func() error {
+			return u.twoFactorUseCase.VerifyTOTP(userID, credentials.TOTPCode)
+		})
+	default:
+		return domain.ErrStepUpRequired
+	}
+}
+
+// checkAttempt runs check after claiming an attempt on the step-up counter,
+// which the PIN and TOTP codes share. The attempt is claimed first so that
+// parallel requests cannot guess more often than the policy allows. A
+// successful check clears the counter.
+func (u *TransactionPINUseCase) checkAttempt(userID int64, check func() error) error {
+	attempts, ok, err := u.pinRepo.ClaimAttempt(userID, u.policy.MaxFailedAttempts)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return u.lockedError(userID)
+	}
+
+	if err := check(); err != nil {
+		if errors.Is(err, domain.ErrInvalidPIN) || errors.Is(err, domain.ErrInvalid2FACode) {
+			return u.recordFailure(userID, attempts, err)
+		}
+		return err
+	}
+
+	return u.pinRepo.ResetFailures(userID)
+}
+
+// lockedError reports the end of the step-up lock. Attempts claimed by
+// requests that never finished can leave the counter full without a lock;
+// the lock is then started now, so the counter is cleared when it ends.
+func (u *TransactionPINUseCase) lockedError(userID int64) error {
+	until, err := u.pinRepo.LockedUntil(userID)
+	if err != nil {
+		return err
+	}
+	if until == nil {
+		lockUntil := time.Now().Add(u.policy.LockoutDuration)
+		if err := u.pinRepo.Lock(userID, lockUntil, nil); err != nil {
+			return err
+		}
+		until = &lockUntil
+	}
+	return fmt.Errorf("%w until %s", domain.ErrPINLocked, until.Format(time.RFC3339))
+}
+
+func (u *TransactionPINUseCase) savePIN(userID int64, pin string) error {
+	if err := validatePIN(pin); err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(pin), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	return u.pinRepo.Save(&domain.TransactionPIN{UserID: userID, PINHash: string(hash)})
+}
+
+func (u *TransactionPINUseCase) recordFailure(userID int64, failures int, cause error) error {
+	if u.policy.MaxFailedAttempts <= 0 || failures < u.policy.MaxFailedAttempts {
+		return cause
+	}
+
+	until := time.Now().Add(u.policy.LockoutDuration)
+	notification := u.notificationUseCase.Prepare(userID, domain.NotificationTypeSecurity, "pin_locked", until, failures)
+	if err := u.pinRepo.Lock(userID, until, notification); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w until %s", domain.ErrPINLocked, until.Format(time.RFC3339))
+}
+
+// validatePIN accepts exactly six digits, rejecting repeated digits and
+// straight runs such as 123456.
+func validatePIN(pin string) error {
+	if len(pin) != pinLength || strings.Trim(pin, "0123456789") != "" {
+		return domain.ErrWeakPIN
+	}
+
+	same, ascending, descending := true, true, true
+	for i := 1; i < len(pin); i++ {
+		diff := int(pin[i]) - int(pin[i-1])
+		same = same && diff == 0
+		ascending = ascending && diff == 1
+		descending = descending && diff == -1
+	}
+	if same || ascending || descending {
+		return domain.ErrWeakPIN
+	}
+
+	return nil
+}
@@ -3,7 +3,6 @@ package usecase
 
 import (
 	"GonPay_Backend/internal/domain"
-	"errors"
 )
 
 type TransactionUseCase struct {
@@ -21,7 +20,7 @@ func (u *TransactionUseCase) GetUserTransactions(userID int64, page, limit int)
 
 	// Add validation
 	if page < 1 {
-		return nil, errors.New("page must be greater than 0")
+		return nil, domain.NewAppError(domain.CodeInvalidRequest, "page must be greater than 0")
 	}
 
 	if limit < 1 || limit > 100 {
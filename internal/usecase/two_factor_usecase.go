@@ -0,0 +1,388 @@
+// internal/usecase/two_factor_usecase.go
+package usecase
+
+import (
+	"GonPay_Backend/internal/auth"
+	"GonPay_Backend/internal/domain"
+	"GonPay_Backend/pkg/totp"
+	"crypto/rand"
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const (
+	entityTwoFactor = "TWO_FACTOR"
+
+	totpIssuer = "GonPay"
+	// Accept codes from one step before and after the current one to allow
+	// for clock drift on the user's phone.
+	totpSkew = 1
+
+	recoveryCodeCount    = 10
+	recoveryCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+	challengePurpose = "2fa_login"
+	challengeTTL     = 5 * time.Minute
+)
+
+type TwoFactorUseCase struct {
+	twoFactorRepo  domain.TwoFactorRepository
+	userRepo       domain.UserRepository
+	auditUseCase   *AuditUseCase
+	sessionUseCase *SessionUseCase
+	lockoutUseCase *LockoutUseCase
+	tokens         *auth.TokenManager
+}
+
+func NewTwoFactorUseCase(
+	twoFactorRepo domain.TwoFactorRepository,
+	userRepo domain.UserRepository,
+	auditUseCase *AuditUseCase,
+	sessionUseCase *SessionUseCase,
+	lockoutUseCase *LockoutUseCase,
+	tokens *auth.TokenManager,
+) *TwoFactorUseCase {
+	return &TwoFactorUseCase{
+		twoFactorRepo:  twoFactorRepo,
+		userRepo:       userRepo,
+		auditUseCase:   auditUseCase,
+		sessionUseCase: sessionUseCase,
+		lockoutUseCase: lockoutUseCase,
+		tokens:         tokens,
+	}
+}
+
+func (u *TwoFactorUseCase) GetStatus(userID int64) (*domain.TwoFactorStatus, error) {
+	tf, err := u.twoFactorRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tf == nil || !tf.Enabled {
+		return &domain.TwoFactorStatus{}, nil
+	}
+
+	remaining, err := u.twoFactorRepo.CountRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TwoFactorStatus{
+		Enabled:                true,
+		ConfirmedAt:            tf.ConfirmedAt,
+		RecoveryCodesRemaining: remaining,
+	}, nil
+}
+
+func (u *TwoFactorUseCase) IsEnabled(userID int64) (bool, error) {
+	tf, err := u.twoFactorRepo.GetByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+	return tf != nil && tf.Enabled, nil
+}
+
+// Setup generates a new secret for the user to add to their authenticator
+// app. 2FA stays off until Confirm is called with a code from the app.
+func (u *TwoFactorUseCase) Setup(userID int64) (*domain.TwoFactorSetup, error) {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled, err := u.IsEnabled(userID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, domain.Err2FAAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.twoFactorRepo.Save(&domain.TwoFactor{UserID: userID, Secret: secret}); err != nil {
+		return nil, err
+	}
+
+	return &domain.TwoFactorSetup{
+		Secret:     secret,
+		OTPAuthURI: totp.URI(totpIssuer, user.Email, secret),
+	}, nil
+}
+
+// Confirm enables 2FA once the user proves their app generates valid codes
+// and returns the recovery codes. They are shown only this once.
+func (u *TwoFactorUseCase) Confirm(userID int64, code string, client domain.ClientInfo) ([]string, error) {
+	tf, err := u.twoFactorRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if tf == nil {
+		return nil, domain.Err2FANotEnabled
+	}
+	if tf.Enabled {
+		return nil, domain.Err2FAAlreadyEnabled
+	}
+
+	if err := u.verifyTOTP(tf, code); err != nil {
+		return nil, err
+	}
+
+	if err := u.twoFactorRepo.Enable(userID); err != nil {
+		return nil, err
+	}
+
+	codes, err := u.replaceRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	u.auditUseCase.LogChange(userID, domain.AuditActionEnable2FA, entityTwoFactor, userID, nil, map[string]bool{"enabled": true}, client)
+
+	return codes, nil
+}
+
+// Disable turns 2FA off. It needs the current password and a valid code or
+// recovery code.
+func (u *TwoFactorUseCase) Disable(userID int64, password, code string, client domain.ClientInfo) error {
+	if _, err := u.reauthenticate(userID, password, code, client); err != nil {
+		return err
+	}
+
+	if err := u.twoFactorRepo.Delete(userID, nil); err != nil {
+		return err
+	}
+
+	u.auditUseCase.LogChange(userID, domain.AuditActionDisable2FA, entityTwoFactor, userID, map[string]bool{"enabled": true}, map[string]bool{"enabled": false}, client)
+
+	return nil
+}
+
+// RegenerateRecoveryCodes invalidates the old recovery codes and returns a new set.
+func (u *TwoFactorUseCase) RegenerateRecoveryCodes(userID int64, password, code string, client domain.ClientInfo) ([]string, error) {
+	if _, err := u.reauthenticate(userID, password, code, client); err != nil {
+		return nil, err
+	}
+
+	codes, err := u.replaceRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	u.auditUseCase.LogChange(userID, domain.AuditActionRegenerate2FA, entityTwoFactor, userID, nil, map[string]int{"recovery_codes": len(codes)}, client)
+
+	return codes, nil
+}
+
+// VerifyTOTP checks a TOTP code for a user with 2FA enabled. Recovery codes
+// are not accepted here; they are meant for signing in only.
+func (u *TwoFactorUseCase) VerifyTOTP(userID int64, code string) error {
+	tf, err := u.twoFactorRepo.GetByUserID(userID)
+	if err != nil {
+		return err
+	}
+	if tf == nil || !tf.Enabled {
+		return domain.Err2FANotEnabled
+	}
+
+	return u.verifyTOTP(tf, code)
+}
+
+// MaxTokenLifetime is the longest a JWT issued by GonPay stays valid: the
+// longest of the given token TTLs, in minutes, and the 2FA challenge TTL.
+// Callers pass every TTL they configure (access and impersonation tokens).
+// Retired signing keys must keep verifying tokens for at least this long.
+func MaxTokenLifetime(tokenTTLMinutes ...int64) time.Duration {
+	longest := challengeTTL
+	for _, minutes := range tokenTTLMinutes {
+		if ttl := time.Minute * time.Duration(minutes); ttl > longest {
+			longest = ttl
+		}
+	}
+	return longest
+}
+
+// NewChallenge is returned by Login instead of tokens when the user has 2FA
+// enabled. The challenge token is exchanged for a session by CompleteLogin.
+func (u *TwoFactorUseCase) NewChallenge(user *domain.User) (*AuthResponse, error) {
+	claims := jwt.MapClaims{
+		"user_id": user.ID,
+		"purpose": challengePurpose,
+		"exp":     time.Now().Add(challengeTTL).Unix(),
+	}
+
+	token, err := u.tokens.Sign(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthResponse{
+		TwoFactorRequired: true,
+		ChallengeToken:    token,
+		ExpiresIn:         int64(challengeTTL.Seconds()),
+	}, nil
+}
+
+// CompleteLogin finishes a two-step login with a TOTP or recovery code.
+func (u *TwoFactorUseCase) CompleteLogin(challengeToken, code string, client domain.ClientInfo) (*AuthResponse, error) {
+	userID, err := u.parseChallenge(challengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Status != domain.UserStatusActive {
+		return nil, domain.ErrInvalidToken
+	}
+
+	if err := u.lockoutUseCase.CheckUser(user); err != nil {
+		return nil, err
+	}
+
+	tf, err := u.twoFactorRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if tf == nil || !tf.Enabled {
+		return nil, domain.ErrInvalidToken
+	}
+
+	if err := u.verifyCode(tf, code); err != nil {
+		if err == domain.ErrInvalid2FACode {
+			u.lockoutUseCase.RecordFailure(user, user.Email, client, "invalid 2fa code")
+		}
+		return nil, err
+	}
+
+	if err := u.lockoutUseCase.RecordSuccess(user, client); err != nil {
+		return nil, err
+	}
+
+	return u.sessionUseCase.StartSession(user, client)
+}
+
+func (u *TwoFactorUseCase) parseChallenge(challengeToken string) (int64, error) {
+	claims, err := u.tokens.Parse(challengeToken)
+	if err != nil || claims["purpose"] != challengePurpose {
+		return 0, domain.ErrInvalidToken
+	}
+
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return 0, domain.ErrInvalidToken
+	}
+
+	return int64(userID), nil
+}
+
+func (u *TwoFactorUseCase) reauthenticate(userID int64, password, code string, client domain.ClientInfo) (*domain.TwoFactor, error) {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.lockoutUseCase.CheckPassword(user, password, client); err != nil {
+		return nil, err
+	}
+
+	tf, err := u.twoFactorRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if tf == nil || !tf.Enabled {
+		return nil, domain.Err2FANotEnabled
+	}
+
+	// Wrong codes count like wrong passwords, and the counter is only reset
+	// once both have passed, so the code cannot be guessed with the password
+	if err := u.verifyCode(tf, code); err != nil {
+		if err == domain.ErrInvalid2FACode {
+			u.lockoutUseCase.RecordFailure(user, user.Email, client, "invalid 2fa code on re-authentication")
+		}
+		return nil, err
+	}
+
+	if err := u.lockoutUseCase.RecordSuccess(user, client); err != nil {
+		return nil, err
+	}
+
+	return tf, nil
+}
+
+// verifyCode accepts either a TOTP code or an unused recovery code.
+func (u *TwoFactorUseCase) verifyCode(tf *domain.TwoFactor, code string) error {
+	if err := u.verifyTOTP(tf, code); err == nil {
+		return nil
+	}
+
+	used, err := u.twoFactorRepo.UseRecoveryCode(tf.UserID, hashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return domain.ErrInvalid2FACode
+	}
+	return nil
+}
+
+// verifyTOTP checks a TOTP code and rejects codes that were already used.
+func (u *TwoFactorUseCase) verifyTOTP(tf *domain.TwoFactor, code string) error {
+	step, ok := totp.Validate(tf.Secret, code, time.Now(), totpSkew)
+	if !ok {
+		return domain.ErrInvalid2FACode
+	}
+
+	fresh, err := u.twoFactorRepo.UseStep(tf.UserID, step)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return domain.ErrInvalid2FACode
+	}
+	return nil
+}
+
+func (u *TwoFactorUseCase) replaceRecoveryCodes(userID int64) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = code
+		hashes[i] = hashToken(normalizeRecoveryCode(code))
+	}
+
+	if err := u.twoFactorRepo.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// generateRecoveryCode returns a code like "K7PQ2-XW9MC".
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = recoveryCodeAlphabet[int(b[i])%len(recoveryCodeAlphabet)]
+	}
+	return string(b[:5]) + "-" + string(b[5:]), nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
@@ -4,50 +4,61 @@ package usecase
 import (
 	"GonPay_Backend/internal/domain"
 	"GonPay_Backend/pkg/validator"
-	"errors"
-	"github.com/dgrijalva/jwt-go"
 	"golang.org/x/crypto/bcrypt"
 	"time"
 )
 
+// AuthResponse carries either a signed-in session or, when the user has 2FA
+// enabled, a challenge token to complete the login with a second factor.
 type AuthResponse struct {
-	User  *domain.User `json:"user"`
-	Token string       `json:"token"`
+	User              *domain.User `json:"user,omitempty"`
+	Token             string       `json:"token,omitempty"`
+	RefreshToken      string       `json:"refresh_token,omitempty"`
+	ExpiresIn         int64        `json:"expires_in"`
+	SessionID         int64        `json:"session_id,omitempty"`
+	TwoFactorRequired bool         `json:"two_factor_required,omitempty"`
+	ChallengeToken    string       `json:"challenge_token,omitempty"`
 }
 
 type UserUseCase struct {
-	userRepo  domain.UserRepository
-	validator validator.ValidatorInterface
-	jwtSecret string
-	jwtTTL    int64
+	userRepo            domain.UserRepository
+	validator           validator.ValidatorInterface
+	sessionUseCase      *SessionUseCase
+	twoFactorUseCase    *TwoFactorUseCase
+	lockoutUseCase      *LockoutUseCase
+	verificationUseCase *VerificationUseCase
 }
 
 func NewUserUseCase(
 	userRepo domain.UserRepository,
 	validator validator.ValidatorInterface,
-	jwtSecret string,
-	jwtTTL int64,
+	sessionUseCase *SessionUseCase,
+	twoFactorUseCase *TwoFactorUseCase,
+	lockoutUseCase *LockoutUseCase,
+	verificationUseCase *VerificationUseCase,
 ) *UserUseCase {
 	return &UserUseCase{
-		userRepo:  userRepo,
-		validator: validator,
-		jwtSecret: jwtSecret,
-		jwtTTL:    jwtTTL,
+		userRepo:            userRepo,
+		validator:           validator,
+		sessionUseCase:      sessionUseCase,
+		twoFactorUseCase:    twoFactorUseCase,
+		lockoutUseCase:      lockoutUseCase,
+		verificationUseCase: verificationUseCase,
 	}
 }
 
-func (u *UserUseCase) Register(username, email, phoneNumber, password string) (*AuthResponse, error) {
+func (u *UserUseCase) Register(username, email, phoneNumber, password string, client domain.ClientInfo) (*AuthResponse, error) {
 	if err := u.validator.ValidateUsername(username); err != nil {
-		return nil, err
+		return nil, validationError(err)
 	}
 	if err := u.validator.ValidateEmail(email); err != nil {
-		return nil, err
+		return nil, validationError(err)
 	}
 	if err := u.validator.ValidatePhone(phoneNumber); err != nil {
-		return nil, err
+		return nil, validationError(err)
 	}
 	if err := u.validator.ValidatePassword(password); err != nil {
-		return nil, err
+		return nil, validationError(err)
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -69,40 +80,53 @@ func (u *UserUseCase) Register(username, email, phoneNumber, password string) (*
 		return nil, err
 	}
 
-	token, err := u.generateToken(user)
-	if err != nil {
-		return nil, err
-	}
+	// The user can request another code if this one does not arrive
+	u.verificationUseCase.SendCode(user.ID, domain.VerificationChannelEmail)
 
-	return &AuthResponse{
-		User:  user,
-		Token: token,
-	}, nil
+	return u.sessionUseCase.StartSession(user, client)
 }
 
-func (u *UserUseCase) Login(email, password string) (*AuthResponse, error) {
+func (u *UserUseCase) Login(email, password string, client domain.ClientInfo) (*AuthResponse, error) {
+	if err := u.lockoutUseCase.CheckIP(client); err != nil {
+		return nil, err
+	}
+
 	user, err := u.userRepo.GetByEmail(email)
 	if err != nil {
+		if err == domain.ErrUserNotFound {
+			u.lockoutUseCase.RecordFailure(nil, email, client, "unknown email")
+		}
 		return nil, domain.ErrInvalidCredentials
 	}
 
+	if err := u.lockoutUseCase.CheckUser(user); err != nil {
+		return nil, err
+	}
+
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		u.lockoutUseCase.RecordFailure(user, email, client, "invalid password")
 		return nil, domain.ErrInvalidCredentials
 	}
 
 	if user.Status != domain.UserStatusActive {
-		return nil, errors.New("account is inactive")
+		return nil, domain.ErrAccountInactive
 	}
 
-	token, err := u.generateToken(user)
+	// The failure counter is only cleared once the second factor is verified,
+	// so a known password cannot be used to reset it between code guesses
+	twoFactorEnabled, err := u.twoFactorUseCase.IsEnabled(user.ID)
 	if err != nil {
 		return nil, err
 	}
+	if twoFactorEnabled {
+		return u.twoFactorUseCase.NewChallenge(user)
+	}
 
-	return &AuthResponse{
-		User:  user,
-		Token: token,
-	}, nil
+	if err := u.lockoutUseCase.RecordSuccess(user, client); err != nil {
+		return nil, err
+	}
+
+	return u.sessionUseCase.StartSession(user, client)
 }
 
 // Tiếp tục của file user_usecase.go
@@ -113,33 +137,59 @@ func (u *UserUseCase) GetUserByID(id int64) (*domain.User, error) {
 
 func (u *UserUseCase) UpdateUser(user *domain.User) error {
 	if err := u.validator.ValidateUsername(user.Username); err != nil {
-		return err
+		return validationError(err)
 	}
 	if err := u.validator.ValidateEmail(user.Email); err != nil {
-		return err
+		return validationError(err)
 	}
 	if err := u.validator.ValidatePhone(user.PhoneNumber); err != nil {
+		return validationError(err)
+	}
+
+	existing, err := u.userRepo.GetByID(user.ID)
+	if err != nil {
 		return err
 	}
 
+	// A new email or phone number has to be verified again
+	emailChanged := existing.Email != user.Email
+	phoneChanged := existing.PhoneNumber != user.PhoneNumber
+	if emailChanged {
+		user.EmailVerifiedAt = nil
+	}
+	if phoneChanged {
+		user.PhoneVerifiedAt = nil
+	}
+
 	user.UpdatedAt = time.Now()
-	return u.userRepo.Update(user)
+	if err := u.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	if emailChanged {
+		u.verificationUseCase.SendCode(user.ID, domain.VerificationChannelEmail)
+	}
+	if phoneChanged {
+		u.verificationUseCase.SendCode(user.ID, domain.VerificationChannelPhone)
+	}
+
+	return nil
 }
 
-func (u *UserUseCase) ChangePassword(userID int64, oldPassword, newPassword string) error {
+func (u *UserUseCase) ChangePassword(userID int64, oldPassword, newPassword string, client domain.ClientInfo) error {
 	user, err := u.userRepo.GetByID(userID)
 	if err != nil {
 		return err
 	}
 
 	// Verify old password
-	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(oldPassword)); err != nil {
-		return domain.ErrInvalidCredentials
+	if err := u.lockoutUseCase.Reauthenticate(user, oldPassword, client); err != nil {
+		return err
 	}
 
 	// Validate new password
 	if err := u.validator.ValidatePassword(newPassword); err != nil {
-		return err
+		return validationError(err)
 	}
 
 	// Hash new password
@@ -148,20 +198,11 @@ func (u *UserUseCase) ChangePassword(userID int64, oldPassword, newPassword stri
 		return err
 	}
 
-	user.PasswordHash = string(hashedPassword)
-	user.UpdatedAt = time.Now()
-
-	return u.userRepo.Update(user)
+	return u.userRepo.UpdatePassword(userID, string(hashedPassword))
 }
 
-func (u *UserUseCase) generateToken(user *domain.User) (string, error) {
-	claims := jwt.MapClaims{
-		"user_id": user.ID,
-		"email":   user.Email,
-		"role":    user.Role, // Thêm role vào claims
-		"exp":     time.Now().Add(time.Hour * time.Duration(u.jwtTTL)).Unix(),
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(u.jwtSecret))
+// validationError turns a validator message into an error the client sees
+// as VALIDATION_FAILED.
+func validationError(err error) error {
+	return domain.WrapAppError(domain.CodeValidationFailed, err.Error(), err)
 }
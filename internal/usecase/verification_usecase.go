@@ -0,0 +1,200 @@
+// internal/usecase/verification_usecase.go
+package usecase
+
+import (
+	"GonPay_Backend/internal/domain"
+	"GonPay_Backend/internal/i18n"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// VerificationPolicy configures OTP codes and which contact details must be
+// verified before the user can move money.
+type VerificationPolicy struct {
+	CodeTTL        time.Duration
+	MaxAttempts    int
+	ResendInterval time.Duration
+	RequireEmail   bool
+	RequirePhone   bool
+}
+
+type VerificationUseCase struct {
+	codeRepo     domain.VerificationCodeRepository
+	userRepo     domain.UserRepository
+	auditUseCase *AuditUseCase
+	mailSender   domain.MailSender
+	smsSender    domain.SMSSender
+	policy       VerificationPolicy
+}
+
+func NewVerificationUseCase(
+	codeRepo domain.VerificationCodeRepository,
+	userRepo domain.UserRepository,
+	auditUseCase *AuditUseCase,
+	mailSender domain.MailSender,
+	smsSender domain.SMSSender,
+	policy VerificationPolicy,
+) *VerificationUseCase {
+	return &VerificationUseCase{
+		codeRepo:     codeRepo,
+		userRepo:     userRepo,
+		auditUseCase: auditUseCase,
+		mailSender:   mailSender,
+		smsSender:    smsSender,
+		policy:       policy,
+	}
+}
+
+// SendCode sends a new one-time code to the user's current email or phone.
+func (u *VerificationUseCase) SendCode(userID int64, channel domain.VerificationChannel) error {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	destination, verifiedAt, err := contactFor(user, channel)
+	if err != nil {
+		return err
+	}
+	if verifiedAt != nil {
+		return domain.ErrAlreadyVerified
+	}
+
+	latest, err := u.codeRepo.GetLatest(userID, channel)
+	if err != nil {
+		return err
+	}
+	if latest != nil && latest.Destination == destination && time.Since(latest.CreatedAt) < u.policy.ResendInterval {
+		return domain.ErrOTPResendTooSoon
+	}
+
+	code, err := generateOTP()
+	if err != nil {
+		return err
+	}
+
+	codeHash, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	if err := u.codeRepo.Create(&domain.VerificationCode{
+		UserID:      userID,
+		Channel:     channel,
+		Destination: destination,
+		CodeHash:    string(codeHash),
+		ExpiresAt:   time.Now().Add(u.policy.CodeTTL),
+	}); err != nil {
+		return err
+	}
+
+	locale := i18n.UserLocale(user)
+	message := locale.T("mail.verification_code.body", code, int(u.policy.CodeTTL.Minutes()))
+	if channel == domain.VerificationChannelEmail {
+		return u.mailSender.Send(destination, locale.T("mail.verification_code.subject"), message)
+	}
+	return u.smsSender.SendSMS(destination, message)
+}
+
+// Verify checks a code against the latest one sent on the channel. Each
+// code allows MaxAttempts tries.
+func (u *VerificationUseCase) Verify(userID int64, channel domain.VerificationChannel, code string, client domain.ClientInfo) error {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	destination, verifiedAt, err := contactFor(user, channel)
+	if err != nil {
+		return err
+	}
+	if verifiedAt != nil {
+		return domain.ErrAlreadyVerified
+	}
+
+	latest, err := u.codeRepo.GetLatest(userID, channel)
+	if err != nil {
+		return err
+	}
+
+	if latest == nil || latest.Destination != destination {
+		return domain.ErrInvalidOTP
+	}
+
+	// The attempt is used up before the code is compared, so parallel
+	// requests cannot try more than MaxAttempts codes between them.
+	claimed, err := u.codeRepo.ClaimAttempt(latest.ID, u.policy.MaxAttempts)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return domain.ErrInvalidOTP
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(latest.CodeHash), []byte(code)); err != nil {
+		return domain.ErrInvalidOTP
+	}
+
+	now := time.Now()
+	if err := u.codeRepo.MarkVerified(latest.ID); err != nil {
+		return err
+	}
+	if err := u.userRepo.SetVerified(userID, channel, now); err != nil {
+		return err
+	}
+
+	u.auditUseCase.LogChange(userID, domain.AuditActionVerifyContact, entityUser, userID, nil, map[string]string{
+		"channel":     string(channel),
+		"destination": destination,
+	}, client)
+
+	return nil
+}
+
+// CheckVerified returns an error wrapping domain.ErrNotVerified if the user
+// has not verified the contact details the policy requires for moving money.
+func (u *VerificationUseCase) CheckVerified(userID int64) error {
+	if !u.policy.RequireEmail && !u.policy.RequirePhone {
+		return nil
+	}
+
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if u.policy.RequireEmail && user.EmailVerifiedAt == nil {
+		return fmt.Errorf("%w: verify your email address first", domain.ErrNotVerified)
+	}
+	if u.policy.RequirePhone && user.PhoneVerifiedAt == nil {
+		return fmt.Errorf("%w: verify your phone number first", domain.ErrNotVerified)
+	}
+
+	return nil
+}
+
+func contactFor(user *domain.User, channel domain.VerificationChannel) (string, *time.Time, error) {
+	switch channel {
+	case domain.VerificationChannelEmail:
+		return user.Email, user.EmailVerifiedAt, nil
+	case domain.VerificationChannelPhone:
+		if user.PhoneNumber == "" {
+			return "", nil, fmt.Errorf("%w: add a phone number to your profile first", domain.ErrNoDeliveryAddress)
+		}
+		return user.PhoneNumber, user.PhoneVerifiedAt, nil
+	default:
+		return "", nil, domain.NewAppError(domain.CodeNotFound, "unknown verification channel")
+	}
+}
+
+func generateOTP() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
@@ -3,18 +3,41 @@ package usecase
 
 import (
 	"GonPay_Backend/internal/domain"
-	"errors"
 )
 
 type WalletUseCase struct {
-	walletRepo      domain.WalletRepository
-	transactionRepo domain.TransactionRepository
+	walletRepo          domain.WalletRepository
+	transactionRepo     domain.TransactionRepository
+	payoutRepo          domain.PayoutRepository
+	payoutProvider      domain.PayoutProvider
+	limitUseCase        *TransactionLimitUseCase
+	beneficiaryUseCase  *BeneficiaryUseCase
+	verificationUseCase *VerificationUseCase
+	pinUseCase          *TransactionPINUseCase
+	kycUseCase          *KYCUseCase
 }
 
-func NewWalletUseCase(walletRepo domain.WalletRepository, transactionRepo domain.TransactionRepository) *WalletUseCase {
+func NewWalletUseCase(
+	walletRepo domain.WalletRepository,
+	transactionRepo domain.TransactionRepository,
+	payoutRepo domain.PayoutRepository,
+	payoutProvider domain.PayoutProvider,
+	limitUseCase *TransactionLimitUseCase,
+	beneficiaryUseCase *BeneficiaryUseCase,
+	verificationUseCase *VerificationUseCase,
+	pinUseCase *TransactionPINUseCase,
+	kycUseCase *KYCUseCase,
+) *WalletUseCase {
 	return &WalletUseCase{
-		walletRepo:      walletRepo,
-		transactionRepo: transactionRepo,
+		walletRepo:          walletRepo,
+		transactionRepo:     transactionRepo,
+		payoutRepo:          payoutRepo,
+		payoutProvider:      payoutProvider,
+		limitUseCase:        limitUseCase,
+		beneficiaryUseCase:  beneficiaryUseCase,
+		verificationUseCase: verificationUseCase,
+		pinUseCase:          pinUseCase,
+		kycUseCase:          kycUseCase,
 	}
 }
 
@@ -22,7 +45,7 @@ func (u *WalletUseCase) CreateWallet(userID int64) (*domain.Wallet, error) {
 	wallet := &domain.Wallet{
 		UserID:  userID,
 		Balance: 0,
-		Status:  domain.UserStatusActive,
+		Status:  domain.WalletStatusActive,
 	}
 
 	if err := u.walletRepo.Create(wallet); err != nil {
@@ -47,18 +70,76 @@ func (u *WalletUseCase) DeactivateWallet(walletID int64) error {
 	}
 
 	if wallet.Balance > 0 {
-		return errors.New("cannot deactivate wallet with positive balance")
+		return domain.NewAppError(domain.CodeNonZeroBalance, "cannot deactivate wallet with positive balance")
+	}
+
+	// Frozen and blocked wallets stay open until an admin releases them
+	if err := wallet.Status.CheckDebit(); err != nil {
+		return err
 	}
 
 	return u.walletRepo.Delete(walletID)
 }
 
-func (u *WalletUseCase) Transfer(sourceWalletID, destWalletID int64, amount float64) (*domain.Transaction, error) {
+// Transfer moves money from one of the user's wallets to another wallet.
+// Large transfers and transfers to new recipients must be confirmed with
+// step-up credentials.
+func (u *WalletUseCase) Transfer(userID, sourceWalletID, destWalletID int64, amount float64, credentials domain.StepUpCredentials) (*domain.Transaction, error) {
 	if amount <= 0 {
 		return nil, domain.ErrInvalidAmount
 	}
 
-	// Create transaction record
+	sourceWallet, err := u.walletRepo.GetByID(sourceWalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sourceWallet.UserID != userID {
+		return nil, domain.ErrInvalidOperation
+	}
+
+	if err := sourceWallet.Status.CheckDebit(); err != nil {
+		return nil, err
+	}
+
+	destWallet, err := u.walletRepo.GetByID(destWalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := destWallet.Status.CheckCredit(); err != nil {
+		return nil, err
+	}
+
+	if err := u.verificationUseCase.CheckVerified(sourceWallet.UserID); err != nil {
+		return nil, err
+	}
+
+	newRecipient, err := u.limitUseCase.IsNewRecipient(sourceWallet.UserID, destWalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.limitUseCase.CheckTransactionLimit(sourceWallet.UserID, domain.TransactionTypeTransfer, amount, newRecipient); err != nil {
+		return nil, err
+	}
+
+	if err := u.beneficiaryUseCase.CheckTransfer(sourceWallet.UserID, destWallet.WalletNumber, domain.AccountTypeWallet, amount); err != nil {
+		return nil, err
+	}
+
+	// Moving money between the user's own wallets leaves their total unchanged
+	if destWallet.UserID != sourceWallet.UserID {
+		if err := u.kycUseCase.CheckBalanceCap(destWallet.UserID, amount); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := u.pinUseCase.RequireStepUp(sourceWallet.UserID, amount, newRecipient, credentials); err != nil {
+		return nil, err
+	}
+
+	// Create transaction record, checking the limits again under the lock
 	tx := &domain.Transaction{
 		SourceWalletID:      sourceWalletID,
 		DestinationWalletID: &destWalletID,
@@ -67,7 +148,7 @@ func (u *WalletUseCase) Transfer(sourceWalletID, destWalletID int64, amount floa
 		Status:              domain.TransactionStatusPending,
 	}
 
-	if err := u.transactionRepo.Create(tx); err != nil {
+	if err := u.limitUseCase.RecordTransaction(sourceWallet.UserID, tx, newRecipient); err != nil {
 		return nil, err
 	}
 
@@ -81,7 +162,7 @@ func (u *WalletUseCase) Transfer(sourceWalletID, destWalletID int64, amount floa
 	// Update destination wallet
 	if err := u.walletRepo.UpdateBalance(destWalletID, amount); err != nil {
 		// Rollback both transaction and source wallet if update fails
-		u.walletRepo.UpdateBalance(sourceWalletID, amount)
+		u.walletRepo.AdjustBalance(sourceWalletID, amount)
 		u.transactionRepo.UpdateStatus(tx.ID, domain.TransactionStatusFailed)
 		return nil, err
 	}
@@ -94,17 +175,38 @@ func (u *WalletUseCase) Transfer(sourceWalletID, destWalletID int64, amount floa
 	return tx, nil
 }
 
-func (u *WalletUseCase) Deposit(walletID int64, amount float64) (*domain.Transaction, error) {
+// Deposit adds money to one of the user's wallets.
+func (u *WalletUseCase) Deposit(userID, walletID int64, amount float64) (*domain.Transaction, error) {
+	wallet, err := u.walletRepo.GetByID(walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if wallet.UserID != userID {
+		return nil, domain.ErrInvalidOperation
+	}
+
 	if amount <= 0 {
 		return nil, domain.ErrInvalidAmount
 	}
 
-	// Verify wallet exists
-	if _, err := u.walletRepo.GetByID(walletID); err != nil {
+	if err := wallet.Status.CheckCredit(); err != nil {
+		return nil, err
+	}
+
+	if err := u.verificationUseCase.CheckVerified(wallet.UserID); err != nil {
 		return nil, err
 	}
 
-	// Create transaction record
+	if err := u.limitUseCase.CheckTransactionLimit(wallet.UserID, domain.TransactionTypeDeposit, amount, false); err != nil {
+		return nil, err
+	}
+
+	if err := u.kycUseCase.CheckBalanceCap(wallet.UserID, amount); err != nil {
+		return nil, err
+	}
+
+	// Create transaction record, checking the limits again under the lock
 	tx := &domain.Transaction{
 		SourceWalletID: walletID,
 		Type:           domain.TransactionTypeDeposit,
@@ -112,7 +214,7 @@ func (u *WalletUseCase) Deposit(walletID int64, amount float64) (*domain.Transac
 		Status:         domain.TransactionStatusPending,
 	}
 
-	if err := u.transactionRepo.Create(tx); err != nil {
+	if err := u.limitUseCase.RecordTransaction(wallet.UserID, tx, false); err != nil {
 		return nil, err
 	}
 
@@ -130,14 +232,23 @@ func (u *WalletUseCase) Deposit(walletID int64, amount float64) (*domain.Transac
 	return tx, nil
 }
 
-func (u *WalletUseCase) Withdraw(walletID int64, amount float64) (*domain.Transaction, error) {
+// Withdraw takes money out of one of the user's wallets. Large withdrawals
+// must be confirmed with step-up credentials.
+func (u *WalletUseCase) Withdraw(userID, walletID int64, amount float64, credentials domain.StepUpCredentials) (*domain.Transaction, error) {
+	wallet, err := u.walletRepo.GetByID(walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if wallet.UserID != userID {
+		return nil, domain.ErrInvalidOperation
+	}
+
 	if amount <= 0 {
 		return nil, domain.ErrInvalidAmount
 	}
 
-	// Verify wallet exists and has sufficient funds
-	wallet, err := u.walletRepo.GetByID(walletID)
-	if err != nil {
+	if err := wallet.Status.CheckDebit(); err != nil {
 		return nil, err
 	}
 
@@ -145,7 +256,19 @@ func (u *WalletUseCase) Withdraw(walletID int64, amount float64) (*domain.Transa
 		return nil, domain.ErrInsufficientFunds
 	}
 
-	// Create transaction record
+	if err := u.verificationUseCase.CheckVerified(wallet.UserID); err != nil {
+		return nil, err
+	}
+
+	if err := u.limitUseCase.CheckTransactionLimit(wallet.UserID, domain.TransactionTypeWithdraw, amount, false); err != nil {
+		return nil, err
+	}
+
+	if err := u.pinUseCase.RequireStepUp(wallet.UserID, amount, false, credentials); err != nil {
+		return nil, err
+	}
+
+	// Create transaction record, checking the limits again under the lock
 	tx := &domain.Transaction{
 		SourceWalletID: walletID,
 		Type:           domain.TransactionTypeWithdraw,
@@ -153,7 +276,7 @@ func (u *WalletUseCase) Withdraw(walletID int64, amount float64) (*domain.Transa
 		Status:         domain.TransactionStatusPending,
 	}
 
-	if err := u.transactionRepo.Create(tx); err != nil {
+	if err := u.limitUseCase.RecordTransaction(wallet.UserID, tx, false); err != nil {
 		return nil, err
 	}
 
@@ -170,3 +293,131 @@ func (u *WalletUseCase) Withdraw(walletID int64, amount float64) (*domain.Transa
 
 	return tx, nil
 }
+
+// TransferToBeneficiary sends money from one of the user's wallets to a saved
+// beneficiary. WALLET beneficiaries are paid by an internal transfer to the
+// wallet with the matching wallet number; BANK_ACCOUNT beneficiaries by a
+// payout through the bank provider.
+func (u *WalletUseCase) TransferToBeneficiary(userID, beneficiaryID, sourceWalletID int64, amount float64, credentials domain.StepUpCredentials) (*domain.Transaction, error) {
+	if amount <= 0 {
+		return nil, domain.ErrInvalidAmount
+	}
+
+	beneficiary, err := u.beneficiaryUseCase.GetBeneficiary(beneficiaryID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceWallet, err := u.walletRepo.GetByID(sourceWalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sourceWallet.UserID != userID {
+		return nil, domain.ErrInvalidOperation
+	}
+
+	switch beneficiary.AccountType {
+	case domain.AccountTypeWallet:
+		destWallet, err := u.walletRepo.GetByWalletNumber(beneficiary.AccountIdentifier)
+		if err != nil {
+			return nil, err
+		}
+		return u.Transfer(userID, sourceWalletID, destWallet.ID, amount, credentials)
+	case domain.AccountTypeBankAccount:
+		return u.payout(sourceWallet, beneficiary, amount, credentials)
+	default:
+		return nil, domain.ErrInvalidOperation
+	}
+}
+
+func (u *WalletUseCase) payout(sourceWallet *domain.Wallet, beneficiary *domain.Beneficiary, amount float64, credentials domain.StepUpCredentials) (*domain.Transaction, error) {
+	if err := sourceWallet.Status.CheckDebit(); err != nil {
+		return nil, err
+	}
+
+	if sourceWallet.Balance < amount {
+		return nil, domain.ErrInsufficientFunds
+	}
+
+	if err := u.verificationUseCase.CheckVerified(sourceWallet.UserID); err != nil {
+		return nil, err
+	}
+
+	// A beneficiary that was never paid out to is a new recipient
+	paidBefore, err := u.payoutRepo.HasCompletedPayout(beneficiary.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.limitUseCase.CheckTransactionLimit(sourceWallet.UserID, domain.TransactionTypeWithdraw, amount, !paidBefore); err != nil {
+		return nil, err
+	}
+
+	if err := u.beneficiaryUseCase.CheckTransfer(sourceWallet.UserID, beneficiary.AccountIdentifier, beneficiary.AccountType, amount); err != nil {
+		return nil, err
+	}
+
+	if err := u.pinUseCase.RequireStepUp(sourceWallet.UserID, amount, !paidBefore, credentials); err != nil {
+		return nil, err
+	}
+
+	// Create transaction record, checking the limits again under the lock
+	tx := &domain.Transaction{
+		SourceWalletID: sourceWallet.ID,
+		Type:           domain.TransactionTypeWithdraw,
+		Amount:         amount,
+		Status:         domain.TransactionStatusPending,
+	}
+
+	if err := u.limitUseCase.RecordTransaction(sourceWallet.UserID, tx, !paidBefore); err != nil {
+		return nil, err
+	}
+
+	// Hold the funds before handing the payout to the bank
+	if err := u.walletRepo.UpdateBalance(sourceWallet.ID, -amount); err != nil {
+		u.transactionRepo.UpdateStatus(tx.ID, domain.TransactionStatusFailed)
+		return nil, err
+	}
+
+	accountName := beneficiary.VerifiedName
+	if accountName == "" {
+		accountName = beneficiary.BeneficiaryName
+	}
+
+	payout := &domain.Payout{
+		TransactionID: tx.ID,
+		BeneficiaryID: beneficiary.ID,
+		BankName:      beneficiary.BankName,
+		AccountNumber: beneficiary.AccountIdentifier,
+		AccountName:   accountName,
+		Amount:        amount,
+		Status:        domain.TransactionStatusPending,
+	}
+
+	if err := u.payoutRepo.Create(payout); err != nil {
+		u.walletRepo.AdjustBalance(sourceWallet.ID, amount)
+		u.transactionRepo.UpdateStatus(tx.ID, domain.TransactionStatusFailed)
+		return nil, err
+	}
+
+	reference, err := u.payoutProvider.SendPayout(payout)
+	if err != nil {
+		// Refund the held funds if the bank rejects the payout
+		u.walletRepo.AdjustBalance(sourceWallet.ID, amount)
+		u.payoutRepo.UpdateStatus(payout.ID, domain.TransactionStatusFailed, "")
+		u.transactionRepo.UpdateStatus(tx.ID, domain.TransactionStatusFailed)
+		return nil, err
+	}
+
+	if err := u.payoutRepo.UpdateStatus(payout.ID, domain.TransactionStatusCompleted, reference); err != nil {
+		return nil, err
+	}
+
+	if err := u.transactionRepo.UpdateStatus(tx.ID, domain.TransactionStatusCompleted); err != nil {
+		return nil, err
+	}
+	tx.Status = domain.TransactionStatusCompleted
+
+	return tx, nil
+}
@@ -0,0 +1,270 @@
+// pkg/validator/struct.go
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// FieldError describes why one field failed validation. Field is the JSON
+// name of the field, with a dot between nested struct names. Param is the
+// rule's argument and Unit what a length rule counts ("characters" or
+// "items"), so callers can write the message in another language.
+type FieldError struct {
+	Field   string
+	Rule    string
+	Param   string
+	Unit    string
+	Message string
+}
+
+// ValidationErrors lists every field that failed validation.
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Field + " " + fieldErr.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ValidateStruct checks the `validate` tags of s, which must be a struct or a
+// pointer to one. Rules are separated by commas and checked in order; the
+// first rule a field breaks is reported and the rest are skipped.
+//
+//	required     not the zero value; for slices and maps, not empty
+//	omitempty    skip the remaining rules when the field is the zero value
+//	min=n max=n  length for strings, slices and maps; value for numbers
+//	len=n        exact length for strings, slices and maps
+//	gt=n         number strictly greater than n
+//	email        a valid email address
+//	oneof=a b c  one of the space-separated values
+//
+// A field that fails returns ValidationErrors. A tag with an unknown rule or
+// a rule that does not fit the field's type is a programming error and is
+// returned as a plain error.
+func (v *Validator) ValidateStruct(s interface{}) error {
+	value := reflect.ValueOf(s)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return fmt.Errorf("validator: nil %s", value.Type())
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("validator: cannot validate %s", value.Type())
+	}
+
+	var errs ValidationErrors
+	if err := v.validateFields(value, "", &errs); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (v *Validator) validateFields(value reflect.Value, prefix string, errs *ValidationErrors) error {
+	structType := value.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		fieldValue := value.Field(i)
+
+		// Embedded structs are flattened into the parent, as encoding/json does
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := v.validateFields(fieldValue, prefix, errs); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := jsonName(field)
+		if name == "-" {
+			continue
+		}
+		name = prefix + name
+
+		if tag := field.Tag.Get("validate"); tag != "" {
+			fieldErr, err := v.validateField(fieldValue, tag)
+			if err != nil {
+				return fmt.Errorf("validator: %s.%s: %w", structType.Name(), field.Name, err)
+			}
+			if fieldErr != nil {
+				fieldErr.Field = name
+				*errs = append(*errs, *fieldErr)
+				continue
+			}
+		}
+
+		if fieldValue.Kind() == reflect.Struct {
+			if err := v.validateFields(fieldValue, name+".", errs); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateField returns the first rule in tag that value breaks, or nil.
+func (v *Validator) validateField(value reflect.Value, tag string) (*FieldError, error) {
+	for _, rule := range strings.Split(tag, ",") {
+		name, param := rule, ""
+		if i := strings.IndexByte(rule, '='); i >= 0 {
+			name, param = rule[:i], rule[i+1:]
+		}
+
+		switch name {
+		case "omitempty":
+			if value.IsZero() {
+				return nil, nil
+			}
+			continue
+		case "required":
+			if isEmpty(value) {
+				return &FieldError{Rule: name, Message: "is required"}, nil
+			}
+			continue
+		}
+
+		message, err := v.checkRule(value, name, param)
+		if err != nil {
+			return nil, err
+		}
+		if message != "" {
+			_, unit, _ := sizeOf(value)
+			if name != "min" && name != "max" && name != "len" {
+				unit = ""
+			}
+			return &FieldError{Rule: name, Param: param, Unit: unit, Message: message}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// checkRule returns a message describing how value breaks the rule, or ""
+// when it does not.
+func (v *Validator) checkRule(value reflect.Value, name, param string) (string, error) {
+	switch name {
+	case "min", "max", "len":
+		limit, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s parameter %q", name, param)
+		}
+
+		if size, unit, ok := sizeOf(value); ok {
+			switch {
+			case name == "min" && float64(size) < limit:
+				return fmt.Sprintf("must be at least %s %s", param, unit), nil
+			case name == "max" && float64(size) > limit:
+				return fmt.Sprintf("must be at most %s %s", param, unit), nil
+			case name == "len" && float64(size) != limit:
+				return fmt.Sprintf("must be exactly %s %s", param, unit), nil
+			}
+			return "", nil
+		}
+
+		number, ok := numberOf(value)
+		if !ok || name == "len" {
+			return "", fmt.Errorf("rule %s does not apply to %s", name, value.Type())
+		}
+		switch {
+		case name == "min" && number < limit:
+			return fmt.Sprintf("must be at least %s", param), nil
+		case name == "max" && number > limit:
+			return fmt.Sprintf("must be at most %s", param), nil
+		}
+		return "", nil
+
+	case "gt":
+		limit, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid gt parameter %q", param)
+		}
+		number, ok := numberOf(value)
+		if !ok {
+			return "", fmt.Errorf("rule gt does not apply to %s", value.Type())
+		}
+		if number <= limit {
+			return fmt.Sprintf("must be greater than %s", param), nil
+		}
+		return "", nil
+
+	case "email":
+		if value.Kind() != reflect.String {
+			return "", fmt.Errorf("rule email does not apply to %s", value.Type())
+		}
+		if v.ValidateEmail(value.String()) != nil {
+			return "must be a valid email address", nil
+		}
+		return "", nil
+
+	case "oneof":
+		if value.Kind() != reflect.String {
+			return "", fmt.Errorf("rule oneof does not apply to %s", value.Type())
+		}
+		allowed := strings.Fields(param)
+		for _, option := range allowed {
+			if value.String() == option {
+				return "", nil
+			}
+		}
+		return "must be one of " + strings.Join(allowed, ", "), nil
+
+	default:
+		return "", fmt.Errorf("unknown rule %q", name)
+	}
+}
+
+func jsonName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+func isEmpty(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	default:
+		return value.IsZero()
+	}
+}
+
+// sizeOf returns the length of strings, slices and maps. Strings are
+// measured in characters, not bytes.
+func sizeOf(value reflect.Value) (int, string, bool) {
+	switch value.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(value.String()), "characters", true
+	case reflect.Slice, reflect.Map:
+		return value.Len(), "items", true
+	default:
+		return 0, "", false
+	}
+}
+
+func numberOf(value reflect.Value) (float64, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,199 @@
+// pkg/validator/struct_test.go
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type ruleRequest struct {
+	Name     string            `json:"name" validate:"required"`
+	Tags     []string          `json:"tags" validate:"required"`
+	Meta     map[string]string `json:"meta" validate:"required"`
+	Nickname string            `json:"nickname" validate:"omitempty,min=3"`
+	Username string            `json:"username" validate:"min=3,max=5"`
+	PIN      string            `json:"pin" validate:"len=6"`
+	Items    []int             `json:"items" validate:"max=2"`
+	Age      int               `json:"age" validate:"min=18,max=65"`
+	Amount   float64           `json:"amount" validate:"gt=0"`
+	Count    uint              `json:"count" validate:"gt=1"`
+	Email    string            `json:"email" validate:"email"`
+	Role     string            `json:"role" validate:"oneof=USER ADMIN"`
+	NoTag    string
+	Skipped  string `json:"-" validate:"required"`
+	internal string `validate:"required"`
+}
+
+func validRuleRequest() ruleRequest {
+	return ruleRequest{
+		Name:     "An",
+		Tags:     []string{"a"},
+		Meta:     map[string]string{"k": "v"},
+		Username: "annie",
+		PIN:      "123456",
+		Items:    []int{1, 2},
+		Age:      30,
+		Amount:   0.5,
+		Count:    2,
+		Email:    "an@example.com",
+		Role:     "ADMIN",
+	}
+}
+
+func TestValidateStructRules(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(r *ruleRequest)
+		want   *FieldError
+	}{
+		{"valid", func(r *ruleRequest) {}, nil},
+		{"required string", func(r *ruleRequest) { r.Name = "" }, &FieldError{Field: "name", Rule: "required", Message: "is required"}},
+		{"required slice is not empty", func(r *ruleRequest) { r.Tags = []string{} }, &FieldError{Field: "tags", Rule: "required", Message: "is required"}},
+		{"required map is not empty", func(r *ruleRequest) { r.Meta = map[string]string{} }, &FieldError{Field: "meta", Rule: "required", Message: "is required"}},
+		{"omitempty skips zero value", func(r *ruleRequest) { r.Nickname = "" }, nil},
+		{"omitempty checks set value", func(r *ruleRequest) { r.Nickname = "ab" }, &FieldError{Field: "nickname", Rule: "min", Param: "3", Unit: "characters", Message: "must be at least 3 characters"}},
+		{"min string length", func(r *ruleRequest) { r.Username = "an" }, &FieldError{Field: "username", Rule: "min", Param: "3", Unit: "characters", Message: "must be at least 3 characters"}},
+		{"max string length", func(r *ruleRequest) { r.Username = "annabel" }, &FieldError{Field: "username", Rule: "max", Param: "5", Unit: "characters", Message: "must be at most 5 characters"}},
+		{"length counts characters not bytes", func(r *ruleRequest) { r.Username = "Đặng" }, nil},
+		{"len", func(r *ruleRequest) { r.PIN = "12345" }, &FieldError{Field: "pin", Rule: "len", Param: "6", Unit: "characters", Message: "must be exactly 6 characters"}},
+		{"max slice length", func(r *ruleRequest) { r.Items = []int{1, 2, 3} }, &FieldError{Field: "items", Rule: "max", Param: "2", Unit: "items", Message: "must be at most 2 items"}},
+		{"min number", func(r *ruleRequest) { r.Age = 17 }, &FieldError{Field: "age", Rule: "min", Param: "18", Message: "must be at least 18"}},
+		{"max number", func(r *ruleRequest) { r.Age = 66 }, &FieldError{Field: "age", Rule: "max", Param: "65", Message: "must be at most 65"}},
+		{"gt float", func(r *ruleRequest) { r.Amount = 0 }, &FieldError{Field: "amount", Rule: "gt", Param: "0", Message: "must be greater than 0"}},
+		{"gt unsigned", func(r *ruleRequest) { r.Count = 1 }, &FieldError{Field: "count", Rule: "gt", Param: "1", Message: "must be greater than 1"}},
+		{"email", func(r *ruleRequest) { r.Email = "an@" }, &FieldError{Field: "email", Rule: "email", Message: "must be a valid email address"}},
+		{"oneof", func(r *ruleRequest) { r.Role = "ROOT" }, &FieldError{Field: "role", Rule: "oneof", Param: "USER ADMIN", Message: "must be one of USER, ADMIN"}},
+	}
+
+	v := &Validator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := validRuleRequest()
+			tt.modify(&request)
+
+			err := v.ValidateStruct(&request)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("ValidateStruct() = %v, want nil", err)
+				}
+				return
+			}
+
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("ValidateStruct() = %v, want ValidationErrors", err)
+			}
+			if len(errs) != 1 || !reflect.DeepEqual(errs[0], *tt.want) {
+				t.Errorf("ValidateStruct() = %+v, want [%+v]", errs, *tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateStructReportsFirstBrokenRulePerField(t *testing.T) {
+	type request struct {
+		Username string `json:"username" validate:"required,min=3"`
+		Email    string `json:"email" validate:"required,email"`
+	}
+
+	err := (&Validator{}).ValidateStruct(request{})
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("ValidateStruct() = %v, want ValidationErrors", err)
+	}
+	want := ValidationErrors{
+		{Field: "username", Rule: "required", Message: "is required"},
+		{Field: "email", Rule: "required", Message: "is required"},
+	}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("ValidateStruct() = %+v, want %+v", errs, want)
+	}
+	if got := errs.Error(); got != "username is required; email is required" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+type Pagination struct {
+	Limit int `json:"limit" validate:"min=1"`
+}
+
+type Address struct {
+	City string `json:"city" validate:"required"`
+}
+
+type Contact struct {
+	Phone   string  `json:"phone" validate:"required"`
+	Address Address `json:"address"`
+}
+
+type nestedRequest struct {
+	Pagination
+	Contact Contact `json:"contact"`
+	Billing Address
+}
+
+func TestValidateStructFieldNames(t *testing.T) {
+	err := (&Validator{}).ValidateStruct(&nestedRequest{})
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("ValidateStruct() = %v, want ValidationErrors", err)
+	}
+
+	var fields []string
+	for _, fieldErr := range errs {
+		fields = append(fields, fieldErr.Field)
+	}
+	// Embedded fields are flattened into the parent, nested structs are
+	// joined with a dot, and fields without a json tag use the Go name
+	want := []string{"limit", "contact.phone", "contact.address.city", "Billing.city"}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("fields = %v, want %v", fields, want)
+	}
+}
+
+func TestValidateStructProgrammingErrors(t *testing.T) {
+	var nilRequest *ruleRequest
+
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{"nil pointer", nilRequest},
+		{"not a struct", "name"},
+		{"unknown rule", &struct {
+			Name string `validate:"uppercase"`
+		}{}},
+		{"invalid parameter", &struct {
+			Name string `validate:"min=three"`
+		}{}},
+		{"len on a number", &struct {
+			Age int `validate:"len=2"`
+		}{}},
+		{"gt on a string", &struct {
+			Name string `validate:"gt=1"`
+		}{}},
+		{"email on a number", &struct {
+			Email int `validate:"email"`
+		}{}},
+		{"oneof on a number", &struct {
+			Role int `validate:"oneof=1 2"`
+		}{}},
+	}
+
+	v := &Validator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateStruct(tt.value)
+			if err == nil {
+				t.Fatal("ValidateStruct() = nil, want an error")
+			}
+			var errs ValidationErrors
+			if errors.As(err, &errs) {
+				t.Errorf("ValidateStruct() = %v, want a programming error, not ValidationErrors", err)
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ type ValidatorInterface interface {
 	ValidatePhone(phone string) error
 	ValidateUsername(username string) error
 	ValidateAmount(amount float64) error
+	ValidateStruct(s interface{}) error
 }
 
 type Validator struct{}